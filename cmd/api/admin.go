@@ -0,0 +1,312 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	adminPathPrefix = "/admin/"
+
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// adminAPI is the JSON API for administrators, every request needs the "Authorization: Bearer <ADMIN_TOKEN>" header.
+//
+//	GET  /admin/stats
+//	GET  /admin/users?query=&limit=&offset=
+//	GET  /admin/users/{id}
+//	GET  /admin/users/{id}/likes?limit=&offset=
+//	POST /admin/users/{id}/ban
+//	POST /admin/users/{id}/unban
+type adminAPI struct {
+	token string
+	users internal.UsersRepository
+	likes internal.LikesRepository
+	stats internal.StatsRepository
+	log   *zap.SugaredLogger
+}
+
+var _ http.Handler = &adminAPI{}
+
+type adminUser struct {
+	Id          int64  `json:"id"`
+	Username    string `json:"username"`
+	Name        string `json:"name"`
+	Gender      int    `json:"gender"`
+	Age         int    `json:"age"`
+	City        string `json:"city"`
+	Description string `json:"description"`
+	Started     bool   `json:"started"`
+	Paused      bool   `json:"paused"`
+	Hidden      bool   `json:"hidden"`
+	Banned      bool   `json:"banned"`
+}
+
+type adminLike struct {
+	Id        int64     `json:"id"`
+	FromId    int64     `json:"from_id"`
+	ToId      int64     `json:"to_id"`
+	Value     bool      `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type adminStats struct {
+	Users             int `json:"users"`
+	Active            int `json:"active"`
+	Paused            int `json:"paused"`
+	Hidden            int `json:"hidden"`
+	Banned            int `json:"banned"`
+	Likes             int `json:"likes"`
+	Matches           int `json:"matches"`
+	UnresolvedReports int `json:"unresolved_reports"`
+}
+
+// newAdminAPI returns nil if no admin token is configured, the API is disabled then.
+func newAdminAPI(
+	c *config,
+	users internal.UsersRepository,
+	likes internal.LikesRepository,
+	stats internal.StatsRepository,
+	log *zap.SugaredLogger,
+) *adminAPI {
+	if len(c.AdminToken) == 0 {
+		return nil
+	}
+
+	return &adminAPI{
+		token: c.AdminToken,
+		users: users,
+		likes: likes,
+		stats: stats,
+		log:   log,
+	}
+}
+
+func (api *adminAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, hasBearer := cutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !hasBearer || subtle.ConstantTimeCompare([]byte(token), []byte(api.token)) != 1 {
+		api.log.Warnw("received admin request with wrong token", "path", r.URL.Path)
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, adminPathPrefix), "/"), "/")
+
+	switch {
+	case len(parts) == 1 && parts[0] == "stats":
+		api.route(w, r, http.MethodGet, api.getStats)
+	case len(parts) == 1 && parts[0] == "users":
+		api.route(w, r, http.MethodGet, api.searchUsers)
+	case len(parts) >= 2 && len(parts) <= 3 && parts[0] == "users":
+		userId, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "invalid user id")
+			return
+		}
+
+		action := ""
+		if len(parts) == 3 {
+			action = parts[2]
+		}
+
+		switch action {
+		case "":
+			api.route(w, r, http.MethodGet, func(w http.ResponseWriter, r *http.Request) { api.getUser(w, r, userId) })
+		case "likes":
+			api.route(w, r, http.MethodGet, func(w http.ResponseWriter, r *http.Request) { api.getLikes(w, r, userId) })
+		case "ban", "unban":
+			api.route(w, r, http.MethodPost, func(w http.ResponseWriter, r *http.Request) {
+				api.setBanned(w, r, userId, action == "ban")
+			})
+		default:
+			writeJSONError(w, http.StatusNotFound, "not found")
+		}
+	default:
+		writeJSONError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (api *adminAPI) route(w http.ResponseWriter, r *http.Request, method string, handler http.HandlerFunc) {
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	handler(w, r)
+}
+
+func (api *adminAPI) getStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := api.stats.Get(r.Context())
+	if err != nil {
+		api.internalError(w, "could not get stats", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, adminStats{
+		Users:             stats.Users,
+		Active:            stats.Active,
+		Paused:            stats.Paused,
+		Hidden:            stats.Hidden,
+		Banned:            stats.Banned,
+		Likes:             stats.Likes,
+		Matches:           stats.Matches,
+		UnresolvedReports: stats.UnresolvedReports,
+	})
+}
+
+func (api *adminAPI) searchUsers(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePage(r)
+	if !ok {
+		writeJSONError(w, http.StatusBadRequest, "invalid limit or offset")
+		return
+	}
+
+	users, err := api.users.Search(r.Context(), r.URL.Query().Get("query"), limit, offset)
+	if err != nil {
+		api.internalError(w, "could not search users", err)
+		return
+	}
+
+	response := make([]adminUser, 0, len(users))
+	for _, user := range users {
+		response = append(response, newAdminUser(user))
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (api *adminAPI) getUser(w http.ResponseWriter, r *http.Request, userId int64) {
+	user, err := api.users.GetByUserId(r.Context(), userId)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			writeJSONError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		api.internalError(w, "could not get user", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newAdminUser(user))
+}
+
+func (api *adminAPI) getLikes(w http.ResponseWriter, r *http.Request, userId int64) {
+	limit, offset, ok := parsePage(r)
+	if !ok {
+		writeJSONError(w, http.StatusBadRequest, "invalid limit or offset")
+		return
+	}
+
+	likes, err := api.likes.GetByUserId(r.Context(), userId, limit, offset)
+	if err != nil {
+		api.internalError(w, "could not get likes", err)
+		return
+	}
+
+	response := make([]adminLike, 0, len(likes))
+	for _, like := range likes {
+		response = append(response, adminLike{
+			Id:        like.Id,
+			FromId:    like.FromId,
+			ToId:      like.ToId,
+			Value:     like.Value,
+			CreatedAt: like.CreatedAt,
+			UpdatedAt: like.UpdatedAt,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (api *adminAPI) setBanned(w http.ResponseWriter, r *http.Request, userId int64, banned bool) {
+	if err := api.users.SetBanned(r.Context(), userId, banned); err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			writeJSONError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		api.internalError(w, "could not set banned", err)
+		return
+	}
+
+	api.log.Infow("admin api changed ban", "user", userId, "banned", banned)
+	api.getUser(w, r, userId)
+}
+
+func (api *adminAPI) internalError(w http.ResponseWriter, msg string, err error) {
+	api.log.Errorw(msg, "err", err)
+	writeJSONError(w, http.StatusInternalServerError, "internal error")
+}
+
+func newAdminUser(user *models.User) adminUser {
+	return adminUser{
+		Id:          user.Id,
+		Username:    user.Username,
+		Name:        user.Name,
+		Gender:      user.Gender,
+		Age:         user.Age,
+		City:        user.City,
+		Description: user.Description,
+		Started:     user.Started,
+		Paused:      user.Paused,
+		Hidden:      user.Hidden,
+		Banned:      user.Banned,
+	}
+}
+
+func cutPrefix(s, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
+
+// parsePage reads limit and offset query parameters, limit is capped by maxPageLimit.
+func parsePage(r *http.Request) (limit, offset int, ok bool) {
+	limit, offset = defaultPageLimit, 0
+
+	var err error
+	if s := r.URL.Query().Get("limit"); len(s) > 0 {
+		if limit, err = strconv.Atoi(s); err != nil || limit <= 0 {
+			return 0, 0, false
+		}
+		if limit > maxPageLimit {
+			limit = maxPageLimit
+		}
+	}
+
+	if s := r.URL.Query().Get("offset"); len(s) > 0 {
+		if offset, err = strconv.Atoi(s); err != nil || offset < 0 {
+			return 0, 0, false
+		}
+	}
+
+	return limit, offset, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		status = http.StatusInternalServerError
+		body, _ = json.Marshal(map[string]string{"error": err.Error()})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
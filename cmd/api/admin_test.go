@@ -0,0 +1,188 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/Eretic431/datingTelegramBot/internal/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testAdminToken = "admin-token"
+
+func newTestAdminAPI(t *testing.T, ctrl *gomock.Controller) (*adminAPI, *mock.MockUsersRepository, *mock.MockLikesRepository, *mock.MockStatsRepository) {
+	users := mock.NewMockUsersRepository(ctrl)
+	likes := mock.NewMockLikesRepository(ctrl)
+	stats := mock.NewMockStatsRepository(ctrl)
+
+	api := newAdminAPI(&config{AdminToken: testAdminToken}, users, likes, stats, zaptest.NewLogger(t).Sugar())
+	return api, users, likes, stats
+}
+
+func serveAdminRequest(api *adminAPI, method, target, token string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, nil)
+	if len(token) > 0 {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	api.ServeHTTP(w, r)
+	return w
+}
+
+func TestNewAdminAPI_ShouldReturnNilWithoutToken(t *testing.T) {
+	assert.Nil(t, newAdminAPI(&config{}, nil, nil, nil, zaptest.NewLogger(t).Sugar()))
+}
+
+func TestAdminAPI_ShouldRejectWrongToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api, _, _, _ := newTestAdminAPI(t, ctrl)
+
+	for _, token := range []string{"", "wrong"} {
+		w := serveAdminRequest(api, http.MethodGet, "/admin/stats", token)
+		assert.EqualValues(t, http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestAdminAPI_Stats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api, _, _, stats := newTestAdminAPI(t, ctrl)
+	stats.EXPECT().
+		Get(gomock.Any()).
+		Return(&models.Stats{Users: 10, Banned: 1}, nil).
+		Times(1)
+
+	w := serveAdminRequest(api, http.MethodGet, "/admin/stats", testAdminToken)
+	assert.EqualValues(t, http.StatusOK, w.Code)
+	assert.EqualValues(t, "application/json", w.Header().Get("Content-Type"))
+
+	var response adminStats
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.EqualValues(t, adminStats{Users: 10, Banned: 1}, response)
+}
+
+func TestAdminAPI_SearchUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api, users, _, _ := newTestAdminAPI(t, ctrl)
+	users.EXPECT().
+		Search(gomock.Any(), "anna", maxPageLimit, 5).
+		Return([]*models.User{{Id: 1, Name: "Anna"}}, nil).
+		Times(1)
+
+	w := serveAdminRequest(api, http.MethodGet, "/admin/users?query=anna&limit=1000&offset=5", testAdminToken)
+	assert.EqualValues(t, http.StatusOK, w.Code)
+
+	var response []adminUser
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.EqualValues(t, []adminUser{{Id: 1, Name: "Anna"}}, response)
+}
+
+func TestAdminAPI_SearchUsers_ShouldRejectInvalidPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api, _, _, _ := newTestAdminAPI(t, ctrl)
+
+	w := serveAdminRequest(api, http.MethodGet, "/admin/users?limit=-1", testAdminToken)
+	assert.EqualValues(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAdminAPI_GetLikes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api, _, likes, _ := newTestAdminAPI(t, ctrl)
+	likes.EXPECT().
+		GetByUserId(gomock.Any(), int64(1), defaultPageLimit, 0).
+		Return([]*models.Like{{Id: 1, FromId: 1, ToId: 2, Value: true}}, nil).
+		Times(1)
+
+	w := serveAdminRequest(api, http.MethodGet, "/admin/users/1/likes", testAdminToken)
+	assert.EqualValues(t, http.StatusOK, w.Code)
+
+	var response []adminLike
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.EqualValues(t, []adminLike{{Id: 1, FromId: 1, ToId: 2, Value: true}}, response)
+}
+
+func TestAdminAPI_Ban(t *testing.T) {
+	cases := []struct {
+		action string
+		banned bool
+	}{
+		{"ban", true},
+		{"unban", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.action, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			api, users, _, _ := newTestAdminAPI(t, ctrl)
+			users.EXPECT().
+				SetBanned(gomock.Any(), int64(1), c.banned).
+				Return(nil).
+				Times(1)
+			users.EXPECT().
+				GetByUserId(gomock.Any(), int64(1)).
+				Return(&models.User{Id: 1, Banned: c.banned}, nil).
+				Times(1)
+
+			w := serveAdminRequest(api, http.MethodPost, "/admin/users/1/"+c.action, testAdminToken)
+			assert.EqualValues(t, http.StatusOK, w.Code)
+
+			var response adminUser
+			assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.EqualValues(t, c.banned, response.Banned)
+		})
+	}
+}
+
+func TestAdminAPI_Ban_ShouldReturnNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api, users, _, _ := newTestAdminAPI(t, ctrl)
+	users.EXPECT().
+		SetBanned(gomock.Any(), int64(1), true).
+		Return(models.ErrNoRecord).
+		Times(1)
+
+	w := serveAdminRequest(api, http.MethodPost, "/admin/users/1/ban", testAdminToken)
+	assert.EqualValues(t, http.StatusNotFound, w.Code)
+}
+
+func TestAdminAPI_ShouldRejectWrongMethodAndPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api, _, _, _ := newTestAdminAPI(t, ctrl)
+
+	cases := []struct {
+		method       string
+		target       string
+		expectedCode int
+	}{
+		{http.MethodGet, "/admin/users/1/ban", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/admin/stats", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/admin/users/abc", http.StatusBadRequest},
+		{http.MethodGet, "/admin/unknown", http.StatusNotFound},
+		{http.MethodGet, "/admin/users/1/unknown", http.StatusNotFound},
+	}
+
+	for _, c := range cases {
+		w := serveAdminRequest(api, c.method, c.target, testAdminToken)
+		assert.EqualValues(t, c.expectedCode, w.Code, c.method+" "+c.target)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+// role is what a user is allowed to do in the bot
+type role int
+
+const (
+	roleUser role = iota
+	roleModerator
+)
+
+// commandRoles lists the commands which need more than roleUser
+var commandRoles = map[string]role{
+	"admin": roleModerator,
+}
+
+// roleOf returns the role of the telegram user, moderators are listed in config.
+func (a *application) roleOf(userId int64) role {
+	for _, id := range a.config.Moderators {
+		if id == userId {
+			return roleModerator
+		}
+	}
+
+	return roleUser
+}
+
+// authorized reports whether the telegram user may run command.
+func (a *application) authorized(userId int64, command string) bool {
+	required, ok := commandRoles[command]
+	if !ok {
+		return true
+	}
+
+	return a.roleOf(userId) >= required
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestApplication_Authorized(t *testing.T) {
+	app := &application{config: &config{Moderators: []int64{42}}}
+
+	cases := []struct {
+		name     string
+		userId   int64
+		command  string
+		expected bool
+	}{
+		{"user command", 1, "next", true},
+		{"moderator command by user", 1, "admin", false},
+		{"moderator command by moderator", 42, "admin", true},
+		{"user command by moderator", 42, "next", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.EqualValues(t, c.expected, app.authorized(c.userId, c.command))
+		})
+	}
+}
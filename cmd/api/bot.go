@@ -3,52 +3,172 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
+	"github.com/Eretic431/datingTelegramBot/internal"
 	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/Eretic431/datingTelegramBot/internal/logging"
+	"github.com/Eretic431/datingTelegramBot/internal/metrics"
 	"github.com/Eretic431/datingTelegramBot/internal/usecase"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
 	"log"
-	"strings"
+	"strconv"
+	"time"
 )
 
 var (
-	commands = make(map[string]struct{}, 3)
+	commands = make(map[string]struct{}, 13)
 )
 
+// swipeCommands and swipeActions show other profiles or rate them, they are checked with Usecase.SwipeRestriction
+var (
+	swipeCommands = map[string]struct{}{"next": {}, "likes": {}, "undo": {}}
+	swipeActions  = map[internal.CallbackAction]struct{}{
+		internal.CallbackLike:    {},
+		internal.CallbackDislike: {},
+		internal.CallbackUndo:    {},
+	}
+)
+
+const (
+	cardNotServedText     = "Эта анкета устарела. Показать следующую: /next"
+	likeQuotaExceededText = "Вы поставили максимум лайков за сутки, попробуйте позже. А пока анкеты можно пропускать."
+)
+
+// heartbeatInterval must be shorter than config.UpdateLoopStallTimeout
+const heartbeatInterval = 5 * time.Second
+
 func init() {
 	commands["start"] = struct{}{}
 	commands["profile"] = struct{}{}
+	commands["edit"] = struct{}{}
+	commands["settings"] = struct{}{}
 	commands["next"] = struct{}{}
+	commands["matches"] = struct{}{}
+	commands["likes"] = struct{}{}
+	commands["undo"] = struct{}{}
+	commands["pause"] = struct{}{}
+	commands["resume"] = struct{}{}
+	commands["delete"] = struct{}{}
+	commands["admin"] = struct{}{}
+	commands["cancel"] = struct{}{}
 }
 
-func (a *application) handleUpdates() {
-	for update := range a.updates {
-		ctx := context.Background()
-		var outputMessages []tgbotapi.Chattable
-		var err error
+// handleUpdates returns when stop is closed or the updates channel is exhausted,
+// after every received update is handled. Handlers are cancelled with ctx, shutdown does it at its deadline,
+// so they don't outlive the database pool.
+func (a *application) handleUpdates(ctx context.Context, stop <-chan struct{}) {
+	d := newDispatcher(ctx, a.config.Workers, a.config.UpdateTimeout, a.handleUpdate)
+	d.start()
+
+	// The loop beats even without updates, it only stops when dispatch is blocked by busy workers
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	a.health.beat()
+
+	for {
+		select {
+		case <-stop:
+			a.dispatchReceived(ctx, d)
+			d.stop()
+			return
+		case <-heartbeat.C:
+			a.health.beat()
+		case update, ok := <-a.updates:
+			if !ok {
+				d.stop()
+				return
+			}
+			a.dispatch(ctx, d, update)
+			a.health.beat()
+		}
+	}
+}
 
-		if update.Message != nil {
-			outputMessages, err = a.handleMessage(ctx, update.Message)
-		} else if update.CallbackQuery != nil {
-			outputMessages, err = a.handleCallbackQuery(ctx, update.CallbackQuery)
+// dispatchReceived dispatches the updates left in the channel. Their webhook requests are already answered,
+// so Telegram won't send them again.
+func (a *application) dispatchReceived(ctx context.Context, d *dispatcher) {
+	for {
+		select {
+		case update, ok := <-a.updates:
+			if !ok {
+				return
+			}
+			a.dispatch(ctx, d, update)
+		default:
+			return
 		}
+	}
+}
 
-		if err != nil {
-			continue
+// dispatch hands the update to a worker unless its user is over the rate limit.
+func (a *application) dispatch(ctx context.Context, d *dispatcher, update tgbotapi.Update) {
+	if a.allow(ctx, update) {
+		d.dispatch(update)
+	}
+}
+
+func (a *application) handleUpdate(ctx context.Context, update tgbotapi.Update) {
+	log := updateLogger(a.log, update)
+	ctx = logging.WithLogger(ctx, log)
+
+	var outputMessages []tgbotapi.Chattable
+	var err error
+
+	if update.Message != nil {
+		if update.Message.IsCommand() {
+			a.metrics.Updates.WithLabelValues(metrics.UpdateCommand).Inc()
+		} else {
+			a.metrics.Updates.WithLabelValues(metrics.UpdateMessage).Inc()
 		}
+		outputMessages, err = a.handleMessage(ctx, update.Message)
+	} else if update.CallbackQuery != nil {
+		a.metrics.Updates.WithLabelValues(metrics.UpdateCallback).Inc()
+		outputMessages, err = a.handleCallbackQuery(ctx, update.CallbackQuery)
+		a.answerCallback(ctx, update.CallbackQuery, err)
+	}
 
-		for _, message := range outputMessages {
-			if message != nil {
-				if _, err := a.bot.Send(message); err != nil {
-					a.log.Warnf("could not send message with error %e", err)
-				}
-			}
+	if err != nil {
+		log.Errorw("could not handle update", "err", err)
+		return
+	}
+
+	for _, message := range outputMessages {
+		if message != nil {
+			a.send(ctx, update, message)
 		}
 	}
 }
 
-func (a *application) handleMessage(ctx context.Context, msg *tgbotapi.Message) ([]tgbotapi.Chattable, error) {
-	user, err := a.users.GetByUserId(ctx, msg.From.UserName)
-	if err != nil && !errors.Is(err, models.ErrNoRecord) {
+// updateLogger returns a child logger with the fields identifying the update.
+func updateLogger(log *zap.SugaredLogger, update tgbotapi.Update) *zap.SugaredLogger {
+	fields := []interface{}{"update_id", update.UpdateID}
+	if user := update.SentFrom(); user != nil {
+		fields = append(fields, "user_id", user.ID)
+	}
+	if chat := update.FromChat(); chat != nil {
+		fields = append(fields, "chat_id", chat.ID)
+	}
+	if update.Message != nil && update.Message.IsCommand() {
+		fields = append(fields, "command", update.Message.Command())
+	}
+	if update.CallbackQuery != nil {
+		fields = append(fields, "callback", update.CallbackQuery.Data)
+	}
+
+	return log.With(fields...)
+}
+
+// logger returns the logger of the update being handled.
+func (a *application) logger(ctx context.Context) *zap.SugaredLogger {
+	return logging.FromContext(ctx, a.log)
+}
+
+func (a *application) handleMessage(ctx context.Context, msg *tgbotapi.Message) (_ []tgbotapi.Chattable, err error) {
+	defer a.metrics.ObserveHandler(metrics.UpdateMessage, time.Now(), &err)
+
+	user, err := a.usecase.GetUserByIdOrNil(ctx, msg.From.ID)
+	if err != nil {
 		return nil, err
 	}
 	return a.handleUserMessage(ctx, msg, user)
@@ -58,19 +178,22 @@ func (a *application) handleUserMessage(ctx context.Context, msg *tgbotapi.Messa
 	var outputMsg tgbotapi.Chattable
 	var err error
 
-	if len(msg.Photo) > 0 && msg.Photo[0].FileID != "" {
-		photo := msg.Photo[0].FileID
-		a.log.Infof("receive file with id = %s", photo)
+	// Telegram sends every size of the photo, the largest one is the last
+	fileId := ""
+	if len(msg.Photo) > 0 && msg.Photo[len(msg.Photo)-1].FileID != "" {
+		fileId = msg.Photo[len(msg.Photo)-1].FileID
+		a.logger(ctx).Infow("received photo", "file_id", fileId)
 	}
 
 	if user != nil && user.Stage != usecase.ProfileStageNone {
-		fileId := "-"
-		if len(msg.Photo) > 0 && msg.Photo[0].FileID != "" {
-			fileId = msg.Photo[0].FileID
-		}
 
-		if msg.IsCommand() {
-			outputMsg = tgbotapi.NewMessage(msg.Chat.ID, "Пожалуйста дозаполните анкету.")
+		if msg.IsCommand() && msg.Command() == "cancel" {
+			outputMsg, err = a.usecase.HandleCancel(ctx, msg.Chat.ID, user)
+			if err != nil {
+				return nil, err
+			}
+		} else if msg.IsCommand() {
+			outputMsg = a.usecase.DialogReminder(msg.Chat.ID, user)
 		} else {
 			outputMsg, err = a.usecase.HandleFillingProfile(ctx, msg.Text, msg.Chat.ID, fileId, user)
 			if err != nil {
@@ -79,21 +202,47 @@ func (a *application) handleUserMessage(ctx context.Context, msg *tgbotapi.Messa
 		}
 	} else {
 		_, ok := commands[msg.Command()]
-		if ok {
+		if ok && a.authorized(msg.From.ID, msg.Command()) {
 			started, err := a.usecase.IsStarted(ctx, msg)
 			if err != nil {
 				return nil, err
 			}
 
 			if started {
+				if _, ok := swipeCommands[msg.Command()]; ok {
+					if restriction := a.usecase.SwipeRestriction(msg.Chat.ID, user); restriction != nil {
+						return []tgbotapi.Chattable{restriction}, nil
+					}
+				}
+
 				var err error
 				switch msg.Command() {
 				case "start":
 					outputMsg, err = a.usecase.HandleStart(ctx, msg, started)
 				case "profile":
 					outputMsg, err = a.usecase.HandleProfile(ctx, msg, user)
+				case "edit":
+					outputMsg, err = a.usecase.HandleEdit(ctx, msg.Chat.ID, user)
+				case "settings":
+					outputMsg, err = a.usecase.HandleSettings(ctx, msg, user)
 				case "next":
-					outputMsg, err = a.usecase.HandleCommandNext(ctx, msg.Chat.ID, user)
+					return a.usecase.HandleCommandNext(ctx, msg.Chat.ID, user)
+				case "matches":
+					outputMsg, err = a.usecase.HandleMatches(ctx, msg.Chat.ID, user, 0)
+				case "likes":
+					outputMsg, err = a.usecase.HandleLikes(ctx, msg.Chat.ID, user)
+				case "undo":
+					return a.usecase.HandleUndo(ctx, msg.Chat.ID, user)
+				case "pause":
+					outputMsg, err = a.usecase.HandlePause(ctx, msg.Chat.ID, user)
+				case "resume":
+					outputMsg, err = a.usecase.HandleResume(ctx, msg.Chat.ID, user)
+				case "delete":
+					outputMsg, err = a.usecase.HandleDelete(ctx, msg.Chat.ID, user)
+				case "admin":
+					return a.usecase.HandleAdmin(ctx, msg.Chat.ID, user)
+				case "cancel":
+					outputMsg, err = a.usecase.HandleCancel(ctx, msg.Chat.ID, user)
 				}
 				if err != nil {
 					return nil, err
@@ -105,88 +254,191 @@ func (a *application) handleUserMessage(ctx context.Context, msg *tgbotapi.Messa
 				}
 			}
 		} else {
-			outputMsg = a.handleUndefinedMessage(msg)
+			outputMsg = a.handleUndefinedMessage(ctx, msg)
 		}
 	}
 
 	return []tgbotapi.Chattable{outputMsg}, nil
 }
 
-func (a *application) handleCallbackQuery(ctx context.Context, cq *tgbotapi.CallbackQuery) ([]tgbotapi.Chattable, error) {
-	user, err := a.users.GetByUserId(ctx, cq.From.UserName)
-	if err != nil && !errors.Is(err, models.ErrNoRecord) {
+func (a *application) handleCallbackQuery(ctx context.Context, cq *tgbotapi.CallbackQuery) (_ []tgbotapi.Chattable, err error) {
+	defer a.metrics.ObserveHandler(metrics.UpdateCallback, time.Now(), &err)
+
+	user, err := a.usecase.GetUserByIdOrNil(ctx, cq.From.ID)
+	if err != nil {
 		return nil, err
 	}
 	return a.handleUserCallbackQuery(ctx, cq, user)
 }
 
 func (a *application) handleUserCallbackQuery(ctx context.Context, cq *tgbotapi.CallbackQuery, user *models.User) ([]tgbotapi.Chattable, error) {
-	//callback := tgbotapi.NewCallback(cq.ID, cq.Data)
-	//if _, err := a.bot.Request(callback); err != nil {
-	//	a.log.Errorf("could not request callback with error %e", err)
-	//	return nil, err
-	//}
+	callback, err := a.callbacks.Decode(cq.Data)
+	if err != nil {
+		return nil, fmt.Errorf("decode callback: %w", err)
+	}
+
+	// Buttons are only sent to registered users, but a deleted user can still press an old one
+	if user == nil || cq.Message == nil {
+		return nil, nil
+	}
+	chatId := cq.Message.Chat.ID
+
+	if _, ok := swipeActions[callback.Action]; ok {
+		if restriction := a.usecase.SwipeRestriction(chatId, user); restriction != nil {
+			return []tgbotapi.Chattable{restriction}, nil
+		}
+	}
 
 	var msg tgbotapi.Chattable
-	var err error
 
-	if strings.HasPrefix(cq.Data, "like") || strings.HasPrefix(cq.Data, "dislike") {
-		splitedData := strings.Split(cq.Data, ";")
+	switch callback.Action {
+	case internal.CallbackLike, internal.CallbackDislike:
+		toUserId, fromInbox, ok := internal.ParseLikePayload(callback.Payload)
+		if !ok {
+			return nil, internal.ErrInvalidCallback
+		}
 
-		fromUserId := cq.From.UserName
-		toUserId := splitedData[1]
+		return a.handleLike(ctx, cq, user, callback.Action == internal.CallbackLike, toUserId, fromInbox)
+	case internal.CallbackUndo:
+		return a.usecase.HandleUndo(ctx, chatId, user)
+	case internal.CallbackDelete:
+		msg, err = a.usecase.HandleDeleteConfirm(ctx, chatId, user, callback.Payload == internal.DeleteConfirm)
+	case internal.CallbackReport, internal.CallbackBlock:
+		toUserId, parseErr := strconv.ParseInt(callback.Payload, 10, 64)
+		if parseErr != nil {
+			return nil, internal.ErrInvalidCallback
+		}
+
+		if callback.Action == internal.CallbackBlock {
+			msg, err = a.usecase.HandleBlock(ctx, chatId, user, toUserId)
+		} else if user.Stage == usecase.ProfileStageNone {
+			msg, err = a.usecase.HandleReport(ctx, chatId, user, toUserId)
+		} else {
+			return nil, nil
+		}
+	case internal.CallbackReview:
+		if !a.authorized(cq.From.ID, "admin") {
+			a.logger(ctx).Warn("user is not allowed to moderate")
+			return nil, nil
+		}
+
+		action, userId, ok := internal.ParseReviewAction(callback.Payload)
+		if !ok {
+			return nil, internal.ErrInvalidCallback
+		}
+
+		return a.usecase.HandleReview(ctx, chatId, user, action, userId)
+	case internal.CallbackEdit:
+		if user.Stage != usecase.ProfileStageNone {
+			return nil, nil
+		}
+
+		stage, parseErr := strconv.Atoi(callback.Payload)
+		if parseErr != nil {
+			return nil, internal.ErrInvalidCallback
+		}
+
+		msg, err = a.usecase.HandleEditField(ctx, chatId, user, stage)
+	case internal.CallbackMatches:
+		page, parseErr := strconv.Atoi(callback.Payload)
+		if parseErr != nil {
+			return nil, internal.ErrInvalidCallback
+		}
+
+		msg, err = a.usecase.HandleMatches(ctx, chatId, user, page)
+	case internal.CallbackSkip:
+		msg, err = a.usecase.HandleSkip(ctx, chatId, user)
+	case internal.CallbackCancel:
+		msg, err = a.usecase.HandleCancel(ctx, chatId, user)
+	case internal.CallbackInput:
+		msg, err = a.usecase.HandleFillingProfile(ctx, callback.Payload, chatId, "", user)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []tgbotapi.Chattable{msg}, nil
+}
 
-		likeValue := splitedData[0] == "like"
+// handleLike stores the swipe and shows the next profile in place of the swiped one.
+func (a *application) handleLike(
+	ctx context.Context,
+	cq *tgbotapi.CallbackQuery,
+	user *models.User,
+	likeValue bool,
+	toUserId int64,
+	fromInbox bool,
+) ([]tgbotapi.Chattable, error) {
+	fromUserId := cq.From.ID
+
+	if err := a.usecase.AddOrUpdateLike(ctx, likeValue, fromUserId, toUserId); err != nil {
+		if errors.Is(err, usecase.ErrCardNotServed) {
+			return []tgbotapi.Chattable{tgbotapi.NewMessage(cq.Message.Chat.ID, cardNotServedText)}, nil
+		}
+		if errors.Is(err, usecase.ErrLikeQuotaExceeded) {
+			return []tgbotapi.Chattable{tgbotapi.NewMessage(cq.Message.Chat.ID, likeQuotaExceededText)}, nil
+		}
+		return nil, err
+	}
+	if likeValue {
+		a.metrics.Likes.WithLabelValues(metrics.LikeValueLike).Inc()
+	} else {
+		a.metrics.Likes.WithLabelValues(metrics.LikeValueDislike).Inc()
+	}
 
-		if err := a.usecase.AddOrUpdateLike(ctx, likeValue, fromUserId, toUserId); err != nil {
+	var notification tgbotapi.Chattable
+	if likeValue {
+		hasReverseLike, err := a.usecase.HasLikeWithTrueValue(ctx, toUserId, fromUserId)
+		if err != nil {
+			return nil, err
+		}
+
+		user2, err := a.usecase.GetUserByIdOrNil(ctx, toUserId)
+		if err != nil || user2 == nil {
 			return nil, err
 		}
 
-		if likeValue {
-			hasReverseLike, err := a.usecase.HasLikeWithTrueValue(ctx, toUserId, cq.From.UserName)
+		if hasReverseLike {
+			a.metrics.Matches.Inc()
+			match1Messages, match2Messages, err := a.usecase.CreateMatchMessages(ctx, user, user2)
 			if err != nil {
 				return nil, err
 			}
 
-			if hasReverseLike {
-				user2, err := a.usecase.GetUserByIdOrNil(ctx, toUserId)
-				if err != nil || user2 == nil {
-					return nil, err
-				}
-
-				match1Message, match2Message, err := a.usecase.CreateMatchMessages(user, user2)
-				if err != nil {
-					return nil, err
-				}
+			return append(match2Messages, match1Messages...), nil
+		}
 
-				return []tgbotapi.Chattable{match2Message, match1Message}, nil
-			}
+		notification, err = a.usecase.CreateLikeNotification(ctx, user2)
+		if err != nil {
+			a.logger(ctx).Warnw("could not create like notification", "err", err)
 		}
+	}
 
-		msg, err = a.usecase.HandleCommandNext(ctx, cq.Message.Chat.ID, user)
+	messages := make([]tgbotapi.Chattable, 0, 3)
+	if notification != nil {
+		messages = append(messages, notification)
+	}
+
+	if fromInbox {
+		msg, err := a.usecase.HandleLikes(ctx, cq.Message.Chat.ID, user)
 		if err != nil {
 			return nil, err
 		}
-
-	} else {
-		msg, err = a.usecase.HandleFillingProfile(ctx, cq.Data, cq.Message.Chat.ID, user.Image, user)
+		return append(messages, editCard(cq.Message, []tgbotapi.Chattable{msg})...), nil
 	}
 
+	nextMessages, err := a.usecase.HandleCommandNext(ctx, cq.Message.Chat.ID, user)
 	if err != nil {
-		a.log.Errorf("could not handle profile filling with error %e", err)
 		return nil, err
 	}
-
-	return []tgbotapi.Chattable{msg}, nil
+	return append(messages, editCard(cq.Message, nextMessages)...), nil
 }
 
-func (a *application) handleUndefinedMessage(inputMsg *tgbotapi.Message) tgbotapi.MessageConfig {
-	a.log.Info("handleUndefinedMessage")
+func (a *application) handleUndefinedMessage(ctx context.Context, inputMsg *tgbotapi.Message) tgbotapi.MessageConfig {
+	a.logger(ctx).Info("handleUndefinedMessage")
 	outputMsg := tgbotapi.NewMessage(inputMsg.Chat.ID, "Такой команды не существует.\n\n"+
-		"*Список доступных команд:* \n"+
-		"- /start - начало работы\n"+
-		"- /profile - заполнить анкету\n"+
-		"- /next - показать следующего пользователя",
+		internal.CommandsList,
 	)
 	outputMsg.ParseMode = tgbotapi.ModeMarkdown
 
@@ -205,11 +457,24 @@ func newTgBot(c *config) (*tgbotapi.BotAPI, error) {
 	return bot, nil
 }
 
-func newTgBotUpdatesChan(bot *tgbotapi.BotAPI) tgbotapi.UpdatesChannel {
+func newTgBotUpdatesChan(c *config, bot *tgbotapi.BotAPI, wh *webhook) (tgbotapi.UpdatesChannel, error) {
+	if wh != nil {
+		if err := wh.register(c.WebhookUrl); err != nil {
+			return nil, err
+		}
+
+		return wh.updates, nil
+	}
+
+	// Telegram refuses getUpdates while a webhook is set, e.g. after the bot ran in webhook mode
+	if _, err := bot.Request(tgbotapi.DeleteWebhookConfig{}); err != nil {
+		return nil, fmt.Errorf("delete webhook: %w", err)
+	}
+
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 
 	updates := bot.GetUpdatesChan(u)
 
-	return updates
+	return updates, nil
 }
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/Eretic431/datingTelegramBot/internal/mock"
+	"github.com/Eretic431/datingTelegramBot/internal/usecase"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"testing"
+)
+
+func TestHandleUserCallbackQuery_ShouldRefuseBannedUserInLikesInbox(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &models.User{Id: 1, Banned: true, Stage: usecase.ProfileStageNone}
+	refusal := tgbotapi.NewMessage(1, "banned")
+
+	// Neither the like nor the next profile of the inbox are handled
+	uc := mock.NewMockUsecase(ctrl)
+	uc.EXPECT().SwipeRestriction(int64(1), user).Return(refusal)
+
+	codec := internal.NewCallbackCodec([]byte("key"))
+	app := &application{usecase: uc, log: zap.NewNop().Sugar(), callbacks: codec}
+	cq := &tgbotapi.CallbackQuery{
+		From:    &tgbotapi.User{ID: 1},
+		Data:    callbackData(t, codec, internal.CallbackLike, internal.LikePayload(2, true)),
+		Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 1}},
+	}
+
+	messages, err := app.handleUserCallbackQuery(context.Background(), cq, user)
+	assert.Nil(t, err)
+	assert.EqualValues(t, []tgbotapi.Chattable{refusal}, messages)
+}
+
+func TestHandleUserCallbackQuery_ShouldCancelEditing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &models.User{Id: 1, Started: true, Stage: usecase.ProfileStageCity, Editing: true}
+	cancelled := tgbotapi.NewMessage(1, "cancelled")
+
+	uc := mock.NewMockUsecase(ctrl)
+	uc.EXPECT().HandleCancel(gomock.Any(), int64(1), user).Return(cancelled, nil)
+
+	codec := internal.NewCallbackCodec([]byte("key"))
+	app := &application{usecase: uc, log: zap.NewNop().Sugar(), callbacks: codec}
+	cq := &tgbotapi.CallbackQuery{
+		From:    &tgbotapi.User{ID: 1},
+		Data:    callbackData(t, codec, internal.CallbackCancel, ""),
+		Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 1}},
+	}
+
+	messages, err := app.handleUserCallbackQuery(context.Background(), cq, user)
+	assert.Nil(t, err)
+	assert.EqualValues(t, []tgbotapi.Chattable{cancelled}, messages)
+}
+
+func TestHandleUserMessage_ShouldRefuseBannedUserLikes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &models.User{Id: 1, Banned: true, Started: true, Stage: usecase.ProfileStageNone}
+	refusal := tgbotapi.NewMessage(1, "banned")
+	msg := &tgbotapi.Message{
+		From:     &tgbotapi.User{ID: 1},
+		Chat:     &tgbotapi.Chat{ID: 1},
+		Text:     "/likes",
+		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 6}},
+	}
+
+	uc := mock.NewMockUsecase(ctrl)
+	uc.EXPECT().IsStarted(gomock.Any(), msg).Return(true, nil)
+	uc.EXPECT().SwipeRestriction(int64(1), user).Return(refusal)
+
+	app := &application{usecase: uc, log: zap.NewNop().Sugar(), config: &config{}}
+
+	messages, err := app.handleUserMessage(context.Background(), msg, user)
+	assert.Nil(t, err)
+	assert.EqualValues(t, []tgbotapi.Chattable{refusal}, messages)
+}
+
+func TestHandleUserMessage_ShouldRemindReportReasonsOnCommands(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &models.User{Id: 1, Started: true, Stage: usecase.ReportStageReason, ReportedId: 2}
+	reminder := tgbotapi.NewMessage(1, "reminder")
+	msg := &tgbotapi.Message{
+		From:     &tgbotapi.User{ID: 1},
+		Chat:     &tgbotapi.Chat{ID: 1},
+		Text:     "/next",
+		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 5}},
+	}
+
+	uc := mock.NewMockUsecase(ctrl)
+	uc.EXPECT().DialogReminder(int64(1), user).Return(reminder)
+
+	app := &application{usecase: uc, log: zap.NewNop().Sugar(), config: &config{}}
+
+	messages, err := app.handleUserMessage(context.Background(), msg, user)
+	assert.Nil(t, err)
+	assert.EqualValues(t, []tgbotapi.Chattable{reminder}, messages)
+}
+
+func TestHandleUserMessage_ShouldCancelReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &models.User{Id: 1, Started: true, Stage: usecase.ReportStageReason, ReportedId: 2}
+	cancelled := tgbotapi.NewMessage(1, "cancelled")
+	msg := &tgbotapi.Message{
+		From:     &tgbotapi.User{ID: 1},
+		Chat:     &tgbotapi.Chat{ID: 1},
+		Text:     "/cancel",
+		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 7}},
+	}
+
+	uc := mock.NewMockUsecase(ctrl)
+	uc.EXPECT().HandleCancel(gomock.Any(), int64(1), user).Return(cancelled, nil)
+
+	app := &application{usecase: uc, log: zap.NewNop().Sugar(), config: &config{}}
+
+	messages, err := app.handleUserMessage(context.Background(), msg, user)
+	assert.Nil(t, err)
+	assert.EqualValues(t, []tgbotapi.Chattable{cancelled}, messages)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"time"
+)
+
+const callbackErrorText = "Что-то пошло не так, попробуйте ещё раз."
+
+// answerCallback stops the loading spinner of the tapped button, on errors the user gets a toast.
+func (a *application) answerCallback(ctx context.Context, cq *tgbotapi.CallbackQuery, handleErr error) {
+	text := ""
+	if handleErr != nil {
+		text = callbackErrorText
+	}
+
+	if _, err := a.bot.Request(tgbotapi.NewCallback(cq.ID, text)); err != nil {
+		a.metrics.SendFailures.Inc()
+		a.logger(ctx).Warnw("could not answer callback", "err", err)
+	}
+}
+
+// pruneServedCards deletes the expired served cards every config.ServedCardPrune until stop is closed,
+// so /next doesn't have to. Every run is limited by config.UpdateTimeout and cancelled with ctx.
+func (a *application) pruneServedCards(ctx context.Context, stop <-chan struct{}) {
+	if a.config.ServedCardPrune <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(a.config.ServedCardPrune)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			pruneCtx, cancel := context.WithTimeout(ctx, a.config.UpdateTimeout)
+			// Errors are logged by the usecase, the cards are deleted on the next run
+			_ = a.usecase.DeleteExpiredCards(pruneCtx)
+			cancel()
+		}
+	}
+}
+
+// editCard turns the card sent in reply to a swipe into an edit of the swiped message, so the chat holds a single card.
+// Only the first message of the card is edited, its keyboard goes with the same request; the other photos of the card
+// are sent after it. Telegram can't turn a text into a photo or the other way round: such cards are sent as new
+// messages and only the keyboard is removed from the swiped one, so it can't be swiped twice.
+func editCard(swiped *tgbotapi.Message, card []tgbotapi.Chattable) []tgbotapi.Chattable {
+	if swiped == nil || swiped.Chat == nil || len(card) == 0 {
+		return card
+	}
+
+	chatId, messageId := swiped.Chat.ID, swiped.MessageID
+
+	switch c := card[0].(type) {
+	case tgbotapi.PhotoConfig:
+		if len(swiped.Photo) > 0 {
+			media := tgbotapi.NewInputMediaPhoto(c.File)
+			media.Caption = c.Caption
+			media.ParseMode = c.ParseMode
+
+			edit := tgbotapi.EditMessageMediaConfig{
+				BaseEdit: tgbotapi.BaseEdit{ChatID: chatId, MessageID: messageId},
+				Media:    media,
+			}
+			if keyboard, ok := c.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup); ok {
+				edit.ReplyMarkup = &keyboard
+			}
+			return append([]tgbotapi.Chattable{edit}, card[1:]...)
+		}
+	case tgbotapi.MessageConfig:
+		if len(swiped.Photo) == 0 {
+			edit := tgbotapi.NewEditMessageText(chatId, messageId, c.Text)
+			edit.ParseMode = c.ParseMode
+			if keyboard, ok := c.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup); ok {
+				edit.ReplyMarkup = &keyboard
+			}
+			return append([]tgbotapi.Chattable{edit}, card[1:]...)
+		}
+	}
+
+	removeKeyboard := tgbotapi.NewEditMessageReplyMarkup(chatId, messageId, tgbotapi.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{},
+	})
+
+	return append([]tgbotapi.Chattable{removeKeyboard}, card...)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/mock"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestApplication_PruneServedCards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pruned := make(chan struct{}, 1)
+	uc := mock.NewMockUsecase(ctrl)
+	uc.EXPECT().
+		DeleteExpiredCards(gomock.Any()).
+		DoAndReturn(func(ctx context.Context) error {
+			select {
+			case pruned <- struct{}{}:
+			default:
+			}
+			return nil
+		}).
+		MinTimes(1)
+
+	app := &application{usecase: uc, config: &config{ServedCardPrune: time.Millisecond, UpdateTimeout: time.Second}}
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		app.pruneServedCards(context.Background(), stop)
+		close(stopped)
+	}()
+
+	select {
+	case <-pruned:
+	case <-time.After(time.Second):
+		t.Errorf("served cards were not pruned")
+	}
+	close(stop)
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Errorf("pruning did not stop")
+	}
+}
+
+func TestEditCard(t *testing.T) {
+	const chatId, messageId = 1, 10
+
+	photoMessage := &tgbotapi.Message{
+		MessageID: messageId,
+		Chat:      &tgbotapi.Chat{ID: chatId},
+		Photo:     []tgbotapi.PhotoSize{{FileID: "old"}},
+	}
+	textMessage := &tgbotapi.Message{MessageID: messageId, Chat: &tgbotapi.Chat{ID: chatId}, Text: "old"}
+	keyboard := internal.NewKeyboards(internal.NewCallbackCodec(nil), zap.NewNop().Sugar()).CreateLikeKeyboardMarkup(2)
+	removeKeyboard := tgbotapi.NewEditMessageReplyMarkup(chatId, messageId, tgbotapi.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{},
+	})
+
+	photoCard := tgbotapi.NewPhoto(chatId, tgbotapi.FileID("new"))
+	photoCard.Caption = "caption"
+	photoCard.ParseMode = tgbotapi.ModeMarkdown
+	photoCard.ReplyMarkup = keyboard
+
+	textCard := tgbotapi.NewMessage(chatId, "text")
+	textCard.ParseMode = tgbotapi.ModeMarkdown
+	textCard.ReplyMarkup = keyboard
+
+	media := tgbotapi.NewInputMediaPhoto(tgbotapi.FileID("new"))
+	media.Caption = "caption"
+	media.ParseMode = tgbotapi.ModeMarkdown
+
+	editText := tgbotapi.NewEditMessageTextAndMarkup(chatId, messageId, "text", keyboard)
+	editText.ParseMode = tgbotapi.ModeMarkdown
+
+	editMedia := tgbotapi.EditMessageMediaConfig{
+		BaseEdit: tgbotapi.BaseEdit{ChatID: chatId, MessageID: messageId, ReplyMarkup: &keyboard},
+		Media:    media,
+	}
+
+	other := tgbotapi.NewInputMediaPhoto(tgbotapi.FileID("other"))
+	album := tgbotapi.NewMediaGroup(chatId, []interface{}{other, other})
+
+	cases := []struct {
+		name     string
+		swiped   *tgbotapi.Message
+		card     []tgbotapi.Chattable
+		expected []tgbotapi.Chattable
+	}{
+		{
+			name:   "photo over photo",
+			swiped: photoMessage,
+			card:   []tgbotapi.Chattable{photoCard},
+			expected: []tgbotapi.Chattable{
+				editMedia,
+			},
+		},
+		{
+			name:     "photos over photo",
+			swiped:   photoMessage,
+			card:     []tgbotapi.Chattable{photoCard, album},
+			expected: []tgbotapi.Chattable{editMedia, album},
+		},
+		{
+			name:     "text over text",
+			swiped:   textMessage,
+			card:     []tgbotapi.Chattable{textCard},
+			expected: []tgbotapi.Chattable{editText},
+		},
+		{
+			name:     "text over photo",
+			swiped:   photoMessage,
+			card:     []tgbotapi.Chattable{textCard},
+			expected: []tgbotapi.Chattable{removeKeyboard, textCard},
+		},
+		{
+			name:     "photo over text",
+			swiped:   textMessage,
+			card:     []tgbotapi.Chattable{photoCard},
+			expected: []tgbotapi.Chattable{removeKeyboard, photoCard},
+		},
+		{
+			name:     "photos over text",
+			swiped:   textMessage,
+			card:     []tgbotapi.Chattable{photoCard, album},
+			expected: []tgbotapi.Chattable{removeKeyboard, photoCard, album},
+		},
+		{
+			name:     "inline message",
+			swiped:   nil,
+			card:     []tgbotapi.Chattable{photoCard},
+			expected: []tgbotapi.Chattable{photoCard},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.EqualValues(t, c.expected, editCard(c.swiped, c.card))
+		})
+	}
+}
@@ -1,12 +1,44 @@
 package main
 
-import "github.com/caarlos0/env"
+import (
+	"github.com/caarlos0/env"
+	"time"
+)
 
 type config struct {
-	Production  bool   `env:"PRODUCTION" envDefault:"false"`
-	Port        string `env:"PORT" envDefault:"80"`
-	PostgresUrl string `env:"POSTGRES_URL"`
-	TgBotToken  string `env:"BOT_TOKEN"`
+	Production        bool          `env:"PRODUCTION" envDefault:"false"`
+	Port              string        `env:"PORT" envDefault:"80"`
+	PostgresUrl       string        `env:"POSTGRES_URL"`
+	TgBotToken        string        `env:"BOT_TOKEN"`
+	Workers           int           `env:"WORKERS" envDefault:"8"`
+	UpdateTimeout     time.Duration `env:"UPDATE_TIMEOUT" envDefault:"30s"`
+	ShutdownTimeout   time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"10s"`
+	Webhook           bool          `env:"WEBHOOK" envDefault:"false"` // Receive updates via webhook instead of long polling
+	WebhookUrl        string        `env:"WEBHOOK_URL"`
+	WebhookSecret     string        `env:"WEBHOOK_SECRET"`
+	CallbackSecret    string        `env:"CALLBACK_SECRET"`                    // Key of the callback data signature, the bot token is used if empty
+	ReadyTelegramTTL  time.Duration `env:"READY_TELEGRAM_TTL" envDefault:"1m"` // How long /readyz caches the Telegram check
+	UpdateLoopStall   time.Duration `env:"UPDATE_LOOP_STALL_TIMEOUT" envDefault:"1m"`
+	MinAge            int           `env:"MIN_AGE" envDefault:"18"`
+	MaxAge            int           `env:"MAX_AGE" envDefault:"100"`
+	MaxPhotos         int           `env:"MAX_PHOTOS" envDefault:"5"`
+	LikeNotifications bool          `env:"LIKE_NOTIFICATIONS" envDefault:"true"`
+	UndoWindow        time.Duration `env:"UNDO_WINDOW" envDefault:"10m"`
+	ServedCardTTL     time.Duration `env:"SERVED_CARD_TTL" envDefault:"24h"`            // How long a shown profile can be liked
+	ServedCardPrune   time.Duration `env:"SERVED_CARD_PRUNE_INTERVAL" envDefault:"10m"` // How often expired shown profiles are deleted, 0 disables it
+	ReportsToHide     int           `env:"REPORTS_TO_HIDE" envDefault:"3"`
+	CommandsPerMinute int           `env:"RATE_LIMIT_COMMANDS" envDefault:"30"` // Per user, 0 disables the limit
+	SwipesPerMinute   int           `env:"RATE_LIMIT_SWIPES" envDefault:"60"`
+	TextPerMinute     int           `env:"RATE_LIMIT_TEXT" envDefault:"30"`
+	DailyLikes        int           `env:"DAILY_LIKES" envDefault:"100"`       // Likes per user in 24 hours, 0 disables the quota
+	SendRate          int           `env:"SEND_RATE" envDefault:"30"`          // Messages per second to all chats
+	SendChatInterval  time.Duration `env:"SEND_CHAT_INTERVAL" envDefault:"1s"` // Between messages to the same chat
+	SendMaxAttempts   int           `env:"SEND_MAX_ATTEMPTS" envDefault:"5"`
+	SendMinBackoff    time.Duration `env:"SEND_MIN_BACKOFF" envDefault:"1s"`
+	SendMaxBackoff    time.Duration `env:"SEND_MAX_BACKOFF" envDefault:"1m"`
+	Outbox            bool          `env:"OUTBOX" envDefault:"true"`    // Persist messages to other users, e.g. about matches, until they are sent
+	AdminToken        string        `env:"ADMIN_TOKEN"`                 // Bearer token of the admin HTTP API, the API is disabled if empty
+	Moderators        []int64       `env:"MODERATORS" envSeparator:","` // Telegram ids of the users allowed to use /admin
 }
 
 func getConfig() (*config, error) {
@@ -17,3 +49,11 @@ func getConfig() (*config, error) {
 
 	return c, nil
 }
+
+// callbackKey signs callback data. Changing it makes the buttons of the sent messages invalid.
+func (c *config) callbackKey() []byte {
+	if len(c.CallbackSecret) > 0 {
+		return []byte(c.CallbackSecret)
+	}
+	return []byte(c.TgBotToken)
+}
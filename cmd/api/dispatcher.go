@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"sync"
+	"time"
+)
+
+const workerQueueSize = 16
+
+// dispatcher handles updates concurrently on a fixed pool of workers.
+// Updates from the same user always land on the same worker, so they are processed in order.
+type dispatcher struct {
+	ctx     context.Context // Parent of the update contexts, cancelled when handlers must give up
+	queues  []chan tgbotapi.Update
+	handle  func(context.Context, tgbotapi.Update)
+	timeout time.Duration
+	wg      sync.WaitGroup
+}
+
+func newDispatcher(ctx context.Context, workers int, timeout time.Duration, handle func(context.Context, tgbotapi.Update)) *dispatcher {
+	if workers < 1 {
+		workers = 1
+	}
+
+	queues := make([]chan tgbotapi.Update, workers)
+	for i := range queues {
+		queues[i] = make(chan tgbotapi.Update, workerQueueSize)
+	}
+
+	return &dispatcher{
+		ctx:     ctx,
+		queues:  queues,
+		handle:  handle,
+		timeout: timeout,
+	}
+}
+
+func (d *dispatcher) start() {
+	for _, queue := range d.queues {
+		d.wg.Add(1)
+		go d.work(queue)
+	}
+}
+
+func (d *dispatcher) dispatch(update tgbotapi.Update) {
+	d.queues[d.shard(update)] <- update
+}
+
+// stop waits until every dispatched update is handled. dispatch must not be called after stop.
+func (d *dispatcher) stop() {
+	for _, queue := range d.queues {
+		close(queue)
+	}
+	d.wg.Wait()
+}
+
+func (d *dispatcher) work(queue <-chan tgbotapi.Update) {
+	defer d.wg.Done()
+
+	for update := range queue {
+		ctx, cancel := context.WithTimeout(d.ctx, d.timeout)
+		d.handle(ctx, update)
+		cancel()
+	}
+}
+
+func (d *dispatcher) shard(update tgbotapi.Update) int {
+	from := update.SentFrom()
+	if from == nil {
+		return 0
+	}
+
+	shard := from.ID % int64(len(d.queues))
+	if shard < 0 {
+		shard = -shard
+	}
+
+	return int(shard)
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestUpdate(updateId int, userId int64) tgbotapi.Update {
+	return tgbotapi.Update{
+		UpdateID: updateId,
+		Message: &tgbotapi.Message{
+			From: &tgbotapi.User{ID: userId},
+			Chat: &tgbotapi.Chat{ID: userId},
+		},
+	}
+}
+
+func TestDispatcher_ShouldKeepOrderOfSameUserUpdates(t *testing.T) {
+	var mu sync.Mutex
+	handled := make(map[int64][]int)
+
+	d := newDispatcher(context.Background(), 4, time.Second, func(ctx context.Context, update tgbotapi.Update) {
+		mu.Lock()
+		defer mu.Unlock()
+		userId := update.SentFrom().ID
+		handled[userId] = append(handled[userId], update.UpdateID)
+	})
+	d.start()
+
+	for i := 0; i < 100; i++ {
+		d.dispatch(newTestUpdate(i, int64(i%7)))
+	}
+	d.stop()
+
+	total := 0
+	for _, updateIds := range handled {
+		total += len(updateIds)
+		for i := 1; i < len(updateIds); i++ {
+			assert.Less(t, updateIds[i-1], updateIds[i])
+		}
+	}
+	assert.Equal(t, 100, total)
+}
+
+func TestDispatcher_ShouldNotBlockOtherUsersOnSlowHandler(t *testing.T) {
+	release := make(chan struct{})
+	fastHandled := make(chan struct{})
+
+	d := newDispatcher(context.Background(), 2, time.Second, func(ctx context.Context, update tgbotapi.Update) {
+		if update.SentFrom().ID == 1 {
+			<-release
+			return
+		}
+		close(fastHandled)
+	})
+	d.start()
+
+	d.dispatch(newTestUpdate(1, 1))
+	d.dispatch(newTestUpdate(2, 2))
+
+	select {
+	case <-fastHandled:
+	case <-time.After(time.Second):
+		t.Errorf("update of another user was blocked by slow handler")
+	}
+
+	close(release)
+	d.stop()
+}
+
+func TestDispatcher_ShouldPassContextWithTimeout(t *testing.T) {
+	timeout := 50 * time.Millisecond
+	var deadline time.Time
+	var hasDeadline bool
+
+	d := newDispatcher(context.Background(), 1, timeout, func(ctx context.Context, update tgbotapi.Update) {
+		deadline, hasDeadline = ctx.Deadline()
+	})
+	d.start()
+
+	started := time.Now()
+	d.dispatch(newTestUpdate(1, 1))
+	d.stop()
+
+	assert.True(t, hasDeadline)
+	assert.WithinDuration(t, started.Add(timeout), deadline, timeout)
+}
+
+func TestDispatcher_ShouldHandleUpdatesWithoutSender(t *testing.T) {
+	handled := 0
+
+	d := newDispatcher(context.Background(), 0, time.Second, func(ctx context.Context, update tgbotapi.Update) {
+		handled++
+	})
+	d.start()
+
+	d.dispatch(tgbotapi.Update{UpdateID: 1})
+	d.dispatch(newTestUpdate(2, -5))
+	d.stop()
+
+	assert.Equal(t, 2, handled)
+}
+
+func TestApplication_HandleUpdates_ShouldReturnOnStop(t *testing.T) {
+	app := &application{
+		config:  &config{Workers: 2, UpdateTimeout: time.Second},
+		updates: make(chan tgbotapi.Update),
+		health:  &health{},
+	}
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		app.handleUpdates(context.Background(), stop)
+		close(stopped)
+	}()
+	close(stop)
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Errorf("update loop did not stop")
+	}
+}
+
+func TestApplication_Shutdown_ShouldCancelHandlersAtShutdownTimeout(t *testing.T) {
+	timeout := 100 * time.Millisecond
+	app := &application{config: &config{ShutdownTimeout: timeout}, log: zap.NewNop().Sugar()}
+
+	handlersCtx, cancelHandlers := context.WithCancel(context.Background())
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	stopSender := make(chan struct{})
+	senderStopped := make(chan struct{})
+
+	// A handler which never finishes by itself
+	go func() {
+		<-stop
+		<-handlersCtx.Done()
+		close(stopped)
+	}()
+	go func() {
+		<-stopSender
+		close(senderStopped)
+	}()
+
+	started := time.Now()
+	app.shutdown(stop, stopped, cancelHandlers, stopSender, senderStopped, &http.Server{}, &http.Server{})
+
+	assert.Error(t, handlersCtx.Err())
+	assert.WithinDuration(t, started.Add(timeout), time.Now(), timeout/2)
+}
+
+func TestDispatcher_ShouldCancelUpdatesWithParentContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan struct{})
+
+	d := newDispatcher(ctx, 1, time.Minute, func(ctx context.Context, update tgbotapi.Update) {
+		<-ctx.Done()
+		close(cancelled)
+	})
+	d.start()
+
+	d.dispatch(newTestUpdate(1, 1))
+	cancel()
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Errorf("update context was not cancelled with the parent one")
+	}
+	d.stop()
+}
+
+func TestApplication_DispatchReceived_ShouldDispatchBufferedUpdates(t *testing.T) {
+	updates := make(chan tgbotapi.Update, 3)
+	for i := 0; i < 3; i++ {
+		updates <- newTestUpdate(i, int64(i))
+	}
+	app := &application{updates: updates, limits: newRateLimits(&config{}, nil)}
+
+	var mu sync.Mutex
+	handled := 0
+	d := newDispatcher(context.Background(), 2, time.Second, func(ctx context.Context, update tgbotapi.Update) {
+		mu.Lock()
+		defer mu.Unlock()
+		handled++
+	})
+	d.start()
+
+	app.dispatchReceived(context.Background(), d)
+	d.stop()
+
+	assert.Equal(t, 3, handled)
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Eretic431/datingTelegramBot/internal/data/postgres"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	statusOk   = "ok"
+	statusFail = "fail"
+
+	// readyCheckTimeout limits every dependency check of /readyz
+	readyCheckTimeout = 3 * time.Second
+)
+
+type checkResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type healthResponse struct {
+	Status string                 `json:"status"`
+	Checks map[string]checkResult `json:"checks,omitempty"`
+}
+
+// health serves /healthz, which only shows that the process is alive, and /readyz, which checks the dependencies.
+type health struct {
+	heartbeat int64 // Unix nanoseconds of the last iteration of the update loop, first to be 64-bit aligned for atomic
+
+	db           postgres.PgxPoolIface
+	getMe        func() (tgbotapi.User, error)
+	telegramTTL  time.Duration
+	stallTimeout time.Duration
+	log          *zap.SugaredLogger
+
+	// The result of getMe is cached for telegramTTL, Telegram limits the request rate.
+	// A single getMe runs at a time, the lock isn't held during the request.
+	mu                sync.Mutex
+	telegramCheckedAt time.Time
+	telegramErr       error
+	telegramStartedAt time.Time
+	telegramDone      chan struct{} // Closed when the running getMe returns, nil if none is running
+}
+
+func newHealth(c *config, db postgres.PgxPoolIface, bot *tgbotapi.BotAPI, log *zap.SugaredLogger) *health {
+	return &health{
+		db:           db,
+		getMe:        bot.GetMe,
+		telegramTTL:  c.ReadyTelegramTTL,
+		stallTimeout: c.UpdateLoopStall,
+		log:          log,
+	}
+}
+
+// beat is called by the update loop to show that it isn't stuck.
+func (h *health) beat() {
+	atomic.StoreInt64(&h.heartbeat, time.Now().UnixNano())
+}
+
+func (h *health) liveHandler(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, healthResponse{Status: statusOk})
+}
+
+func (h *health) readyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readyCheckTimeout)
+	defer cancel()
+
+	response := healthResponse{
+		Status: statusOk,
+		Checks: map[string]checkResult{
+			"postgres": newCheckResult(h.db.Ping(ctx)),
+			"telegram": newCheckResult(h.checkTelegram(ctx)),
+			"updates":  newCheckResult(h.checkUpdates()),
+		},
+	}
+
+	status := http.StatusOK
+	for name, check := range response.Checks {
+		if check.Status != statusOk {
+			h.log.Warnw("readiness check failed", "check", name, "err", check.Error)
+			response.Status = statusFail
+			status = http.StatusServiceUnavailable
+		}
+	}
+
+	writeJSON(w, status, response)
+}
+
+// checkTelegram waits for getMe until ctx is done. If it takes longer, the previous result is returned,
+// unless getMe hangs for more than telegramTTL or there is no previous result.
+func (h *health) checkTelegram(ctx context.Context) error {
+	h.mu.Lock()
+	if !h.telegramCheckedAt.IsZero() && time.Since(h.telegramCheckedAt) < h.telegramTTL {
+		defer h.mu.Unlock()
+		return h.telegramErr
+	}
+
+	if h.telegramDone == nil {
+		h.telegramDone = make(chan struct{})
+		h.telegramStartedAt = time.Now()
+		go h.getMeAsync(h.telegramDone)
+	}
+	done, startedAt := h.telegramDone, h.telegramStartedAt
+	h.mu.Unlock()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	select {
+	case <-done:
+		return h.telegramErr
+	default:
+	}
+
+	running := time.Since(startedAt)
+	if h.telegramCheckedAt.IsZero() || running > h.telegramTTL {
+		return fmt.Errorf("getMe is running for %s: %w", running.Round(time.Second), ctx.Err())
+	}
+
+	return h.telegramErr
+}
+
+// getMeAsync stores the result of getMe and closes done.
+func (h *health) getMeAsync(done chan struct{}) {
+	_, err := h.getMe()
+
+	h.mu.Lock()
+	h.telegramErr = err
+	h.telegramCheckedAt = time.Now()
+	h.telegramDone = nil
+	h.mu.Unlock()
+
+	close(done)
+}
+
+func (h *health) checkUpdates() error {
+	heartbeat := atomic.LoadInt64(&h.heartbeat)
+	if heartbeat == 0 {
+		return errors.New("update loop is not started")
+	}
+
+	if stalled := time.Since(time.Unix(0, heartbeat)); stalled > h.stallTimeout {
+		return fmt.Errorf("update loop made no progress for %s", stalled.Round(time.Second))
+	}
+
+	return nil
+}
+
+func newCheckResult(err error) checkResult {
+	if err != nil {
+		return checkResult{Status: statusFail, Error: err.Error()}
+	}
+	return checkResult{Status: statusOk}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestHealth(t *testing.T, getMeErr error) (*health, pgxmock.PgxPoolIface, *int) {
+	pool, err := pgxmock.NewPool(pgxmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("error was not expected while creating pool: %s", err.Error())
+	}
+
+	getMeCalls := 0
+	h := &health{
+		db: pool,
+		getMe: func() (tgbotapi.User, error) {
+			getMeCalls++
+			return tgbotapi.User{}, getMeErr
+		},
+		telegramTTL:  time.Minute,
+		stallTimeout: time.Minute,
+		log:          zaptest.NewLogger(t).Sugar(),
+	}
+
+	return h, pool, &getMeCalls
+}
+
+func serveReady(h *health) (int, healthResponse) {
+	w := httptest.NewRecorder()
+	h.readyHandler(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var response healthResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	return w.Code, response
+}
+
+func TestHealth_LiveHandler(t *testing.T) {
+	h, _, _ := newTestHealth(t, nil)
+
+	w := httptest.NewRecorder()
+	h.liveHandler(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.EqualValues(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+}
+
+func TestHealth_ReadyHandler(t *testing.T) {
+	h, pool, _ := newTestHealth(t, nil)
+	defer pool.Close()
+
+	pool.ExpectPing()
+	h.beat()
+
+	code, response := serveReady(h)
+	assert.EqualValues(t, http.StatusOK, code)
+	assert.EqualValues(t, healthResponse{
+		Status: statusOk,
+		Checks: map[string]checkResult{
+			"postgres": {Status: statusOk},
+			"telegram": {Status: statusOk},
+			"updates":  {Status: statusOk},
+		},
+	}, response)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestHealth_ReadyHandler_ShouldReportFailedChecks(t *testing.T) {
+	h, pool, _ := newTestHealth(t, errors.New("Unauthorized"))
+	defer pool.Close()
+
+	pool.ExpectPing().WillReturnError(errors.New("connection refused"))
+	atomic.StoreInt64(&h.heartbeat, time.Now().Add(-time.Hour).UnixNano())
+
+	code, response := serveReady(h)
+	assert.EqualValues(t, http.StatusServiceUnavailable, code)
+	assert.EqualValues(t, statusFail, response.Status)
+	assert.EqualValues(t, checkResult{Status: statusFail, Error: "connection refused"}, response.Checks["postgres"])
+	assert.EqualValues(t, checkResult{Status: statusFail, Error: "Unauthorized"}, response.Checks["telegram"])
+	assert.EqualValues(t, statusFail, response.Checks["updates"].Status)
+}
+
+func TestHealth_ReadyHandler_ShouldFailBeforeUpdateLoopStarts(t *testing.T) {
+	h, pool, _ := newTestHealth(t, nil)
+	defer pool.Close()
+
+	pool.ExpectPing()
+
+	code, response := serveReady(h)
+	assert.EqualValues(t, http.StatusServiceUnavailable, code)
+	assert.EqualValues(t, statusFail, response.Checks["updates"].Status)
+}
+
+func TestHealth_CheckTelegram_ShouldCacheResult(t *testing.T) {
+	h, pool, getMeCalls := newTestHealth(t, nil)
+	defer pool.Close()
+
+	ctx := context.Background()
+	assert.Nil(t, h.checkTelegram(ctx))
+	assert.Nil(t, h.checkTelegram(ctx))
+	assert.EqualValues(t, 1, *getMeCalls)
+
+	h.telegramCheckedAt = time.Now().Add(-2 * h.telegramTTL)
+	assert.Nil(t, h.checkTelegram(ctx))
+	assert.EqualValues(t, 2, *getMeCalls)
+}
+
+func TestHealth_CheckTelegram_ShouldNotWaitForHangingGetMe(t *testing.T) {
+	release := make(chan struct{})
+	var getMeCalls int32
+	h := &health{
+		getMe: func() (tgbotapi.User, error) {
+			atomic.AddInt32(&getMeCalls, 1)
+			<-release
+			return tgbotapi.User{}, nil
+		},
+		telegramTTL: time.Minute,
+		log:         zaptest.NewLogger(t).Sugar(),
+	}
+
+	check := func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		return h.checkTelegram(ctx)
+	}
+
+	// Nothing to fall back to
+	assert.ErrorIs(t, check(), context.DeadlineExceeded)
+
+	// The expired result is returned while getMe hangs, getMe isn't called again
+	h.mu.Lock()
+	h.telegramCheckedAt = time.Now().Add(-2 * h.telegramTTL)
+	h.telegramErr = nil
+	h.mu.Unlock()
+	assert.Nil(t, check())
+	assert.EqualValues(t, 1, atomic.LoadInt32(&getMeCalls))
+
+	// getMe hangs for too long
+	h.mu.Lock()
+	h.telegramStartedAt = time.Now().Add(-2 * h.telegramTTL)
+	h.mu.Unlock()
+	assert.ErrorIs(t, check(), context.DeadlineExceeded)
+
+	close(release)
+	assert.Nil(t, h.checkTelegram(context.Background()))
+}
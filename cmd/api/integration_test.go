@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/Eretic431/datingTelegramBot/internal"
 	"github.com/Eretic431/datingTelegramBot/internal/data/models"
 	"github.com/Eretic431/datingTelegramBot/internal/usecase"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -14,7 +16,7 @@ func Test_Scenario1_1(t *testing.T) {
 	app := newTestApp()
 
 	msg := &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "test"},
+		From:     &tgbotapi.User{ID: 4, UserName: "test"},
 		Text:     "/start",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 6}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -27,7 +29,16 @@ func Test_Scenario1_1(t *testing.T) {
 		"*Список доступных команд:* \n"+
 		"- /start - начало работы\n"+
 		"- /profile - заполнить анкету\n"+
-		"- /next - показать следующего пользователя",
+		"- /edit - изменить анкету\n"+
+		"- /settings - настроить поиск\n"+
+		"- /next - показать следующего пользователя\n"+
+		"- /matches - показать совпадения\n"+
+		"- /likes - посмотреть, кому Вы понравились\n"+
+		"- /undo - вернуть предыдущую анкету\n"+
+		"- /pause - скрыть анкету из поиска\n"+
+		"- /resume - снова показывать анкету\n"+
+		"- /delete - удалить анкету\n"+
+		"- /cancel - отменить жалобу, изменение анкеты или настроек поиска",
 		app.bot.Self.UserName,
 	)
 
@@ -38,7 +49,7 @@ func Test_Scenario1_2(t *testing.T) {
 	app := newTestApp()
 
 	msg := &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "test"},
+		From:     &tgbotapi.User{ID: 4, UserName: "test"},
 		Text:     "/profile",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 8}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -51,7 +62,16 @@ func Test_Scenario1_2(t *testing.T) {
 		"*Список доступных команд:* \n"+
 		"- /start - начало работы\n"+
 		"- /profile - заполнить анкету\n"+
-		"- /next - показать следующего пользователя",
+		"- /edit - изменить анкету\n"+
+		"- /settings - настроить поиск\n"+
+		"- /next - показать следующего пользователя\n"+
+		"- /matches - показать совпадения\n"+
+		"- /likes - посмотреть, кому Вы понравились\n"+
+		"- /undo - вернуть предыдущую анкету\n"+
+		"- /pause - скрыть анкету из поиска\n"+
+		"- /resume - снова показывать анкету\n"+
+		"- /delete - удалить анкету\n"+
+		"- /cancel - отменить жалобу, изменение анкеты или настроек поиска",
 		app.bot.Self.UserName,
 	)
 
@@ -62,7 +82,7 @@ func Test_Scenario2(t *testing.T) {
 	app := newTestApp()
 
 	msg := &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "test"},
+		From:     &tgbotapi.User{ID: 4, UserName: "test"},
 		Text:     "/start",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 6}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -75,7 +95,16 @@ func Test_Scenario2(t *testing.T) {
 		"*Список доступных команд:* \n"+
 		"- /start - начало работы\n"+
 		"- /profile - заполнить анкету\n"+
-		"- /next - показать следующего пользователя",
+		"- /edit - изменить анкету\n"+
+		"- /settings - настроить поиск\n"+
+		"- /next - показать следующего пользователя\n"+
+		"- /matches - показать совпадения\n"+
+		"- /likes - посмотреть, кому Вы понравились\n"+
+		"- /undo - вернуть предыдущую анкету\n"+
+		"- /pause - скрыть анкету из поиска\n"+
+		"- /resume - снова показывать анкету\n"+
+		"- /delete - удалить анкету\n"+
+		"- /cancel - отменить жалобу, изменение анкеты или настроек поиска",
 		app.bot.Self.UserName,
 	)
 
@@ -86,7 +115,7 @@ func Test_Scenario3(t *testing.T) {
 	app := newTestApp()
 
 	msg := &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "test"},
+		From:     &tgbotapi.User{ID: 4, UserName: "test"},
 		Text:     "/start",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 6}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -99,14 +128,23 @@ func Test_Scenario3(t *testing.T) {
 		"*Список доступных команд:* \n"+
 		"- /start - начало работы\n"+
 		"- /profile - заполнить анкету\n"+
-		"- /next - показать следующего пользователя",
+		"- /edit - изменить анкету\n"+
+		"- /settings - настроить поиск\n"+
+		"- /next - показать следующего пользователя\n"+
+		"- /matches - показать совпадения\n"+
+		"- /likes - посмотреть, кому Вы понравились\n"+
+		"- /undo - вернуть предыдущую анкету\n"+
+		"- /pause - скрыть анкету из поиска\n"+
+		"- /resume - снова показывать анкету\n"+
+		"- /delete - удалить анкету\n"+
+		"- /cancel - отменить жалобу, изменение анкеты или настроек поиска",
 		app.bot.Self.UserName,
 	)
 
 	assert.Equal(t, expected, resp.Text)
 
 	msg = &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "test"},
+		From:     &tgbotapi.User{ID: 4, UserName: "test"},
 		Text:     "/start",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 6}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -123,7 +161,7 @@ func Test_Scenario4(t *testing.T) {
 	app := newTestApp()
 
 	msg := &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "test"},
+		From:     &tgbotapi.User{ID: 4, UserName: "test"},
 		Text:     "/start",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 6}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -136,13 +174,22 @@ func Test_Scenario4(t *testing.T) {
 		"*Список доступных команд:* \n"+
 		"- /start - начало работы\n"+
 		"- /profile - заполнить анкету\n"+
-		"- /next - показать следующего пользователя",
+		"- /edit - изменить анкету\n"+
+		"- /settings - настроить поиск\n"+
+		"- /next - показать следующего пользователя\n"+
+		"- /matches - показать совпадения\n"+
+		"- /likes - посмотреть, кому Вы понравились\n"+
+		"- /undo - вернуть предыдущую анкету\n"+
+		"- /pause - скрыть анкету из поиска\n"+
+		"- /resume - снова показывать анкету\n"+
+		"- /delete - удалить анкету\n"+
+		"- /cancel - отменить жалобу, изменение анкеты или настроек поиска",
 		app.bot.Self.UserName,
 	)
 	assert.Equal(t, expected, resp.Text)
 
 	msg = &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "test"},
+		From:     &tgbotapi.User{ID: 4, UserName: "test"},
 		Text:     "/profile",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 8}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -150,7 +197,7 @@ func Test_Scenario4(t *testing.T) {
 	chattable, _ = app.handleMessage(ctx, msg)
 	resp = chattable[0].(tgbotapi.MessageConfig)
 
-	expected = app.usecase.(*usecase.Usecase).Stages[0]
+	expected = app.usecase.(*usecase.Usecase).Prompt(usecase.ProfileStageName)
 	assert.Equal(t, expected, resp.Text)
 }
 
@@ -159,21 +206,23 @@ func Test_Scenario5(t *testing.T) {
 
 	ctx := context.Background()
 	user1 := &models.User{
-		Id:          "Masha",
-		Name:        "Masha",
-		Sex:         false,
-		Age:         20,
-		Description: "haha",
-		City:        "test",
-		Image:       "hardcoded",
-		Started:     true,
-		Stage:       -1,
-		ChatId:      123,
+		Id:           1,
+		Username:     "Masha",
+		Name:         "Masha",
+		Gender:       models.GenderFemale,
+		InterestedIn: models.GenderMale,
+		Age:          20,
+		Description:  "haha",
+		City:         "test",
+		Image:        "hardcoded",
+		Started:      true,
+		Stage:        -1,
+		ChatId:       123,
 	}
 	_ = app.users.Add(ctx, user1)
 
 	msg := &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "Masha"},
+		From:     &tgbotapi.User{ID: 1, UserName: "Masha"},
 		Text:     "/profile",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 8}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -181,7 +230,7 @@ func Test_Scenario5(t *testing.T) {
 	chattable, _ := app.handleMessage(ctx, msg)
 	resp := chattable[0].(tgbotapi.MessageConfig)
 
-	expected := app.usecase.(*usecase.Usecase).Stages[0]
+	expected := app.usecase.(*usecase.Usecase).Prompt(usecase.ProfileStageName)
 	assert.Equal(t, expected, resp.Text)
 }
 
@@ -189,7 +238,7 @@ func Test_Scenario6(t *testing.T) {
 	app := newTestApp()
 
 	msg := &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "test"},
+		From:     &tgbotapi.User{ID: 4, UserName: "test"},
 		Text:     "/start",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 6}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -202,13 +251,22 @@ func Test_Scenario6(t *testing.T) {
 		"*Список доступных команд:* \n"+
 		"- /start - начало работы\n"+
 		"- /profile - заполнить анкету\n"+
-		"- /next - показать следующего пользователя",
+		"- /edit - изменить анкету\n"+
+		"- /settings - настроить поиск\n"+
+		"- /next - показать следующего пользователя\n"+
+		"- /matches - показать совпадения\n"+
+		"- /likes - посмотреть, кому Вы понравились\n"+
+		"- /undo - вернуть предыдущую анкету\n"+
+		"- /pause - скрыть анкету из поиска\n"+
+		"- /resume - снова показывать анкету\n"+
+		"- /delete - удалить анкету\n"+
+		"- /cancel - отменить жалобу, изменение анкеты или настроек поиска",
 		app.bot.Self.UserName,
 	)
 	assert.Equal(t, expected, resp.Text)
 
 	msg = &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "test"},
+		From:     &tgbotapi.User{ID: 4, UserName: "test"},
 		Text:     "/profile",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 8}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -216,11 +274,11 @@ func Test_Scenario6(t *testing.T) {
 	chattable, _ = app.handleMessage(ctx, msg)
 	resp = chattable[0].(tgbotapi.MessageConfig)
 
-	expected = app.usecase.(*usecase.Usecase).Stages[0]
+	expected = app.usecase.(*usecase.Usecase).Prompt(usecase.ProfileStageName)
 	assert.Equal(t, expected, resp.Text)
 
 	msg = &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "test"},
+		From:     &tgbotapi.User{ID: 4, UserName: "test"},
 		Document: &tgbotapi.Document{},
 		Chat:     &tgbotapi.Chat{ID: 1},
 	}
@@ -236,35 +294,39 @@ func Test_Scenario7(t *testing.T) {
 
 	ctx := context.Background()
 	user1 := &models.User{
-		Id:          "Masha",
-		Name:        "Masha",
-		Sex:         false,
-		Age:         20,
-		Description: "haha",
-		City:        "test",
-		Image:       "hardcoded",
-		Started:     true,
-		Stage:       -1,
-		ChatId:      123,
+		Id:           1,
+		Username:     "Masha",
+		Name:         "Masha",
+		Gender:       models.GenderFemale,
+		InterestedIn: models.GenderMale,
+		Age:          20,
+		Description:  "haha",
+		City:         "test",
+		Image:        "hardcoded",
+		Started:      true,
+		Stage:        -1,
+		ChatId:       123,
 	}
 	_ = app.users.Add(ctx, user1)
 
 	user2 := &models.User{
-		Id:          "Arkasha",
-		Name:        "Arkasha",
-		Sex:         true,
-		Age:         20,
-		Description: "haha",
-		City:        "test",
-		Image:       "hardcoded",
-		Started:     true,
-		Stage:       -1,
-		ChatId:      123,
+		Id:           2,
+		Username:     "Arkasha",
+		Name:         "Arkasha",
+		Gender:       models.GenderMale,
+		InterestedIn: models.GenderFemale,
+		Age:          20,
+		Description:  "haha",
+		City:         "test",
+		Image:        "hardcoded",
+		Started:      true,
+		Stage:        -1,
+		ChatId:       123,
 	}
 	_ = app.users.Add(ctx, user2)
 
 	msg := &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "Masha"},
+		From:     &tgbotapi.User{ID: 1, UserName: "Masha"},
 		Text:     "/next",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 5}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -280,21 +342,23 @@ func Test_Scenario8(t *testing.T) {
 
 	ctx := context.Background()
 	user1 := &models.User{
-		Id:          "Masha",
-		Name:        "Masha",
-		Sex:         false,
-		Age:         20,
-		Description: "haha",
-		City:        "test",
-		Image:       "hardcoded",
-		Started:     true,
-		Stage:       -1,
-		ChatId:      123,
+		Id:           1,
+		Username:     "Masha",
+		Name:         "Masha",
+		Gender:       models.GenderFemale,
+		InterestedIn: models.GenderMale,
+		Age:          20,
+		Description:  "haha",
+		City:         "test",
+		Image:        "hardcoded",
+		Started:      true,
+		Stage:        -1,
+		ChatId:       123,
 	}
 	_ = app.users.Add(ctx, user1)
 
 	msg := &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "Masha"},
+		From:     &tgbotapi.User{ID: 1, UserName: "Masha"},
 		Text:     "/next",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 5}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -312,42 +376,48 @@ func Test_Scenario9(t *testing.T) {
 
 	ctx := context.Background()
 	user1 := &models.User{
-		Id:          "Masha",
-		Name:        "Masha",
-		Sex:         false,
-		Age:         20,
-		Description: "haha",
-		City:        "test",
-		Image:       "hardcoded",
-		Started:     true,
-		Stage:       -1,
-		ChatId:      123,
+		Id:           1,
+		Username:     "Masha",
+		Name:         "Masha",
+		Gender:       models.GenderFemale,
+		InterestedIn: models.GenderMale,
+		Age:          20,
+		Description:  "haha",
+		City:         "test",
+		Image:        "hardcoded",
+		Started:      true,
+		Stage:        -1,
+		ChatId:       123,
 	}
 	_ = app.users.Add(ctx, user1)
 
 	user2 := &models.User{
-		Id:          "Arkasha",
-		Name:        "Arkasha",
-		Sex:         true,
-		Age:         20,
-		Description: "haha",
-		City:        "test",
-		Image:       "hardcoded",
-		Started:     true,
-		Stage:       -1,
-		ChatId:      123,
+		Id:           2,
+		Username:     "Arkasha",
+		Name:         "Arkasha",
+		Gender:       models.GenderMale,
+		InterestedIn: models.GenderFemale,
+		Age:          20,
+		Description:  "haha",
+		City:         "test",
+		Image:        "hardcoded",
+		Started:      true,
+		Stage:        -1,
+		ChatId:       123,
 	}
 	_ = app.users.Add(ctx, user2)
 
+	_ = serveCard(1, 2)
 	cq := &tgbotapi.CallbackQuery{
-		From:    &tgbotapi.User{UserName: "Masha"},
-		Data:    "like;Arkasha",
+		From:    &tgbotapi.User{ID: 1, UserName: "Masha"},
+		Data:    callbackData(t, app.callbacks, internal.CallbackLike, "2"),
 		Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 123}}}
 	_, _ = app.handleCallbackQuery(ctx, cq)
 
+	_ = serveCard(2, 1)
 	cq = &tgbotapi.CallbackQuery{
-		From:    &tgbotapi.User{UserName: "Arkasha"},
-		Data:    "like;Masha",
+		From:    &tgbotapi.User{ID: 2, UserName: "Arkasha"},
+		Data:    callbackData(t, app.callbacks, internal.CallbackLike, "1"),
 		Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 123}}}
 	chattable, _ := app.handleCallbackQuery(ctx, cq)
 	_, ok := chattable[0].(tgbotapi.PhotoConfig)
@@ -361,35 +431,39 @@ func Test_Scenario10(t *testing.T) {
 
 	ctx := context.Background()
 	user1 := &models.User{
-		Id:          "Masha",
-		Name:        "Masha",
-		Sex:         false,
-		Age:         20,
-		Description: "haha",
-		City:        "test",
-		Image:       "hardcoded",
-		Started:     true,
-		Stage:       -1,
-		ChatId:      123,
+		Id:           1,
+		Username:     "Masha",
+		Name:         "Masha",
+		Gender:       models.GenderFemale,
+		InterestedIn: models.GenderMale,
+		Age:          20,
+		Description:  "haha",
+		City:         "test",
+		Image:        "hardcoded",
+		Started:      true,
+		Stage:        -1,
+		ChatId:       123,
 	}
 	_ = app.users.Add(ctx, user1)
 
 	user2 := &models.User{
-		Id:          "Arkasha",
-		Name:        "Arkasha",
-		Sex:         true,
-		Age:         20,
-		Description: "haha",
-		City:        "test",
-		Image:       "hardcoded",
-		Started:     true,
-		Stage:       -1,
-		ChatId:      123,
+		Id:           2,
+		Username:     "Arkasha",
+		Name:         "Arkasha",
+		Gender:       models.GenderMale,
+		InterestedIn: models.GenderFemale,
+		Age:          20,
+		Description:  "haha",
+		City:         "test",
+		Image:        "hardcoded",
+		Started:      true,
+		Stage:        -1,
+		ChatId:       123,
 	}
 	_ = app.users.Add(ctx, user2)
 
 	msg := &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "Masha"},
+		From:     &tgbotapi.User{ID: 1, UserName: "Masha"},
 		Text:     "/next",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 5}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -405,40 +479,45 @@ func Test_Scenario11(t *testing.T) {
 
 	ctx := context.Background()
 	user1 := &models.User{
-		Id:          "Masha",
-		Name:        "Masha",
-		Sex:         false,
-		Age:         20,
-		Description: "haha",
-		City:        "test",
-		Image:       "hardcoded",
-		Started:     true,
-		Stage:       -1,
-		ChatId:      123,
+		Id:           1,
+		Username:     "Masha",
+		Name:         "Masha",
+		Gender:       models.GenderFemale,
+		InterestedIn: models.GenderMale,
+		Age:          20,
+		Description:  "haha",
+		City:         "test",
+		Image:        "hardcoded",
+		Started:      true,
+		Stage:        -1,
+		ChatId:       123,
 	}
 	_ = app.users.Add(ctx, user1)
 
 	user2 := &models.User{
-		Id:          "Arkasha",
-		Name:        "Arkasha",
-		Sex:         true,
-		Age:         20,
-		Description: "haha",
-		City:        "test",
-		Image:       "hardcoded",
-		Started:     true,
-		Stage:       -1,
-		ChatId:      123,
+		Id:           2,
+		Username:     "Arkasha",
+		Name:         "Arkasha",
+		Gender:       models.GenderMale,
+		InterestedIn: models.GenderFemale,
+		Age:          20,
+		Description:  "haha",
+		City:         "test",
+		Image:        "hardcoded",
+		Started:      true,
+		Stage:        -1,
+		ChatId:       123,
 	}
 	_ = app.users.Add(ctx, user2)
 
+	_ = serveCard(1, 2)
 	cq := &tgbotapi.CallbackQuery{
-		From:    &tgbotapi.User{UserName: "Masha"},
-		Data:    "like;Arkasha",
+		From:    &tgbotapi.User{ID: 1, UserName: "Masha"},
+		Data:    callbackData(t, app.callbacks, internal.CallbackLike, "2"),
 		Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 123}}}
 	_, _ = app.handleCallbackQuery(ctx, cq)
 
-	_, err := app.likes.Get(ctx, "Masha", "Arkasha")
+	_, err := app.likes.Get(ctx, 1, 2)
 	assert.Nil(t, err)
 }
 
@@ -446,7 +525,7 @@ func Test_Scenario12(t *testing.T) {
 	app := newTestApp()
 
 	msg := &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "test"},
+		From:     &tgbotapi.User{ID: 4, UserName: "test"},
 		Text:     "/start",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 6}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -459,14 +538,23 @@ func Test_Scenario12(t *testing.T) {
 		"*Список доступных команд:* \n"+
 		"- /start - начало работы\n"+
 		"- /profile - заполнить анкету\n"+
-		"- /next - показать следующего пользователя",
+		"- /edit - изменить анкету\n"+
+		"- /settings - настроить поиск\n"+
+		"- /next - показать следующего пользователя\n"+
+		"- /matches - показать совпадения\n"+
+		"- /likes - посмотреть, кому Вы понравились\n"+
+		"- /undo - вернуть предыдущую анкету\n"+
+		"- /pause - скрыть анкету из поиска\n"+
+		"- /resume - снова показывать анкету\n"+
+		"- /delete - удалить анкету\n"+
+		"- /cancel - отменить жалобу, изменение анкеты или настроек поиска",
 		app.bot.Self.UserName,
 	)
 
 	assert.Equal(t, expected, resp.Text)
 
 	msg = &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "test"},
+		From:     &tgbotapi.User{ID: 4, UserName: "test"},
 		Text:     "/next",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 5}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -479,7 +567,7 @@ func Test_Scenario12(t *testing.T) {
 	assert.Equal(t, expected, resp.Text)
 
 	msg = &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "test"},
+		From:     &tgbotapi.User{ID: 4, UserName: "test"},
 		Text:     "/profile",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 8}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -488,7 +576,7 @@ func Test_Scenario12(t *testing.T) {
 	chattable, _ = app.handleMessage(ctx, msg)
 	resp = chattable[0].(tgbotapi.MessageConfig)
 
-	expected = app.usecase.(*usecase.Usecase).Stages[0]
+	expected = app.usecase.(*usecase.Usecase).Prompt(usecase.ProfileStageName)
 
 	assert.Equal(t, expected, resp.Text)
 }
@@ -498,21 +586,23 @@ func Test_Scenario13(t *testing.T) {
 
 	ctx := context.Background()
 	user1 := &models.User{
-		Id:          "Masha",
-		Name:        "Masha",
-		Sex:         false,
-		Age:         20,
-		Description: "haha",
-		City:        "test",
-		Image:       "hardcoded",
-		Started:     true,
-		Stage:       4,
-		ChatId:      123,
+		Id:           1,
+		Username:     "Masha",
+		Name:         "Masha",
+		Gender:       models.GenderFemale,
+		InterestedIn: models.GenderMale,
+		Age:          20,
+		Description:  "haha",
+		City:         "test",
+		Image:        "hardcoded",
+		Started:      true,
+		Stage:        4,
+		ChatId:       123,
 	}
 	_ = app.users.Add(ctx, user1)
 
 	msg := &tgbotapi.Message{
-		From: &tgbotapi.User{UserName: "Masha"},
+		From: &tgbotapi.User{ID: 1, UserName: "Masha"},
 		Text: "haha",
 		Chat: &tgbotapi.Chat{ID: 1},
 	}
@@ -529,55 +619,62 @@ func Test_Scenario14(t *testing.T) {
 
 	ctx := context.Background()
 	user1 := &models.User{
-		Id:          "Masha",
-		Name:        "Masha",
-		Sex:         false,
-		Age:         20,
-		Description: "haha",
-		City:        "test",
-		Image:       "hardcoded",
-		Started:     true,
-		Stage:       -1,
-		ChatId:      123,
+		Id:           1,
+		Username:     "Masha",
+		Name:         "Masha",
+		Gender:       models.GenderFemale,
+		InterestedIn: models.GenderMale,
+		Age:          20,
+		Description:  "haha",
+		City:         "test",
+		Image:        "hardcoded",
+		Started:      true,
+		Stage:        -1,
+		ChatId:       123,
 	}
 	_ = app.users.Add(ctx, user1)
 
 	user2 := &models.User{
-		Id:          "Arkasha",
-		Name:        "Arkasha",
-		Sex:         true,
-		Age:         20,
-		Description: "haha",
-		City:        "test",
-		Image:       "hardcoded",
-		Started:     true,
-		Stage:       -1,
-		ChatId:      123,
+		Id:           2,
+		Username:     "Arkasha",
+		Name:         "Arkasha",
+		Gender:       models.GenderMale,
+		InterestedIn: models.GenderFemale,
+		Age:          20,
+		Description:  "haha",
+		City:         "test",
+		Image:        "hardcoded",
+		Started:      true,
+		Stage:        -1,
+		ChatId:       123,
 	}
 	_ = app.users.Add(ctx, user2)
 
 	user3 := &models.User{
-		Id:          "Vitya",
-		Name:        "Vitya",
-		Sex:         true,
-		Age:         20,
-		Description: "haha",
-		City:        "test",
-		Image:       "hardcoded",
-		Started:     true,
-		Stage:       -1,
-		ChatId:      123,
+		Id:           3,
+		Username:     "Vitya",
+		Name:         "Vitya",
+		Gender:       models.GenderMale,
+		InterestedIn: models.GenderFemale,
+		Age:          20,
+		Description:  "haha",
+		City:         "test",
+		Image:        "hardcoded",
+		Started:      true,
+		Stage:        -1,
+		ChatId:       123,
 	}
 	_ = app.users.Add(ctx, user3)
 
+	_ = serveCard(3, 1)
 	cq := &tgbotapi.CallbackQuery{
-		From:    &tgbotapi.User{UserName: "Vitya"},
-		Data:    "like;Masha",
+		From:    &tgbotapi.User{ID: 3, UserName: "Vitya"},
+		Data:    callbackData(t, app.callbacks, internal.CallbackLike, "1"),
 		Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 123}}}
 	_, _ = app.handleCallbackQuery(ctx, cq)
 
 	msg := &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "Masha"},
+		From:     &tgbotapi.User{ID: 1, UserName: "Masha"},
 		Text:     "/next",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 5}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -598,7 +695,7 @@ func Test_Scenario15(t *testing.T) {
 	app := newTestApp()
 
 	msg := &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "Masha"},
+		From:     &tgbotapi.User{ID: 1, UserName: "Masha"},
 		Text:     "/nextaaaaaa",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 10}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -611,7 +708,16 @@ func Test_Scenario15(t *testing.T) {
 		"*Список доступных команд:* \n" +
 		"- /start - начало работы\n" +
 		"- /profile - заполнить анкету\n" +
-		"- /next - показать следующего пользователя"
+		"- /edit - изменить анкету\n" +
+		"- /settings - настроить поиск\n" +
+		"- /next - показать следующего пользователя\n" +
+		"- /matches - показать совпадения\n" +
+		"- /likes - посмотреть, кому Вы понравились\n" +
+		"- /undo - вернуть предыдущую анкету\n" +
+		"- /pause - скрыть анкету из поиска\n" +
+		"- /resume - снова показывать анкету\n" +
+		"- /delete - удалить анкету\n" +
+		"- /cancel - отменить жалобу, изменение анкеты или настроек поиска"
 
 	assert.Equal(t, expected, resp.Text)
 }
@@ -621,40 +727,45 @@ func Test_Scenario16(t *testing.T) {
 
 	ctx := context.Background()
 	user1 := &models.User{
-		Id:          "Masha",
-		Name:        "Masha",
-		Sex:         false,
-		Age:         20,
-		Description: "haha",
-		City:        "test",
-		Image:       "hardcoded",
-		Started:     true,
-		Stage:       -1,
-		ChatId:      123,
+		Id:           1,
+		Username:     "Masha",
+		Name:         "Masha",
+		Gender:       models.GenderFemale,
+		InterestedIn: models.GenderMale,
+		Age:          20,
+		Description:  "haha",
+		City:         "test",
+		Image:        "hardcoded",
+		Started:      true,
+		Stage:        -1,
+		ChatId:       123,
 	}
 	_ = app.users.Add(ctx, user1)
 
 	user2 := &models.User{
-		Id:          "Arkasha",
-		Name:        "Arkasha",
-		Sex:         true,
-		Age:         20,
-		Description: "haha",
-		City:        "test",
-		Image:       "hardcoded",
-		Started:     true,
-		Stage:       -1,
-		ChatId:      123,
+		Id:           2,
+		Username:     "Arkasha",
+		Name:         "Arkasha",
+		Gender:       models.GenderMale,
+		InterestedIn: models.GenderFemale,
+		Age:          20,
+		Description:  "haha",
+		City:         "test",
+		Image:        "hardcoded",
+		Started:      true,
+		Stage:        -1,
+		ChatId:       123,
 	}
 	_ = app.users.Add(ctx, user2)
 
+	_ = serveCard(1, 2)
 	cq := &tgbotapi.CallbackQuery{
-		From:    &tgbotapi.User{UserName: "Masha"},
-		Data:    "like;Arkasha",
+		From:    &tgbotapi.User{ID: 1, UserName: "Masha"},
+		Data:    callbackData(t, app.callbacks, internal.CallbackLike, "2"),
 		Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 123}}}
 	_, _ = app.handleCallbackQuery(ctx, cq)
 
-	_, err := app.likes.Get(context.Background(), "Masha", "Arkasha")
+	_, err := app.likes.Get(context.Background(), 1, 2)
 
 	assert.Nil(t, err)
 }
@@ -664,21 +775,23 @@ func Test_Scenario17(t *testing.T) {
 
 	ctx := context.Background()
 	user1 := &models.User{
-		Id:          "Masha",
-		Name:        "Masha",
-		Sex:         false,
-		Age:         20,
-		Description: "haha",
-		City:        "test",
-		Image:       "hardcoded",
-		Started:     true,
-		Stage:       -1,
-		ChatId:      123,
+		Id:           1,
+		Username:     "Masha",
+		Name:         "Masha",
+		Gender:       models.GenderFemale,
+		InterestedIn: models.GenderMale,
+		Age:          20,
+		Description:  "haha",
+		City:         "test",
+		Image:        "hardcoded",
+		Started:      true,
+		Stage:        -1,
+		ChatId:       123,
 	}
 	_ = app.users.Add(ctx, user1)
 
 	msg := &tgbotapi.Message{
-		From:     &tgbotapi.User{UserName: "Masha"},
+		From:     &tgbotapi.User{ID: 1, UserName: "Masha"},
 		Text:     "/profile",
 		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 8}},
 		Chat:     &tgbotapi.Chat{ID: 1},
@@ -686,16 +799,55 @@ func Test_Scenario17(t *testing.T) {
 	chattable, _ := app.handleMessage(ctx, msg)
 	resp := chattable[0].(tgbotapi.MessageConfig)
 
-	expected := app.usecase.(*usecase.Usecase).Stages[0]
+	expected := app.usecase.(*usecase.Usecase).Prompt(usecase.ProfileStageName)
 	assert.Equal(t, expected, resp.Text)
 
 	msg = &tgbotapi.Message{
-		From: &tgbotapi.User{UserName: "Masha"},
+		From: &tgbotapi.User{ID: 1, UserName: "Masha"},
 		Text: "Arkasha",
 		Chat: &tgbotapi.Chat{ID: 1},
 	}
 	_, _ = app.handleMessage(ctx, msg)
 
-	user, _ := app.users.GetByUserId(context.Background(), "Masha")
+	user, _ := app.users.GetByUserId(context.Background(), 1)
 	assert.Equal(t, user.Name, "Arkasha")
 }
+
+func Test_Scenario18(t *testing.T) {
+	app := newTestApp()
+
+	ctx := context.Background()
+	for _, user := range []*models.User{
+		{Id: 1, Username: "Masha", Name: "Masha", Gender: models.GenderFemale, InterestedIn: models.GenderMale,
+			Age: 20, City: "test", Image: "hardcoded", Started: true, Stage: -1, ChatId: 1},
+		{Id: 2, Username: "Sasha", Name: "Sasha", Gender: models.GenderMale, InterestedIn: models.GenderFemale,
+			Age: 20, City: "test", Image: "hardcoded", Started: true, Stage: -1, ChatId: 2},
+	} {
+		_ = app.users.Add(ctx, user)
+	}
+	_ = app.likes.Add(ctx, &models.Like{FromId: 1, ToId: 2, Value: true})
+	_ = app.likes.Add(ctx, &models.Like{FromId: 2, ToId: 1, Value: true})
+
+	msg := &tgbotapi.Message{
+		From:     &tgbotapi.User{ID: 2, UserName: "Sasha"},
+		Text:     "/delete",
+		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 7}},
+		Chat:     &tgbotapi.Chat{ID: 2},
+	}
+	chattable, _ := app.handleMessage(ctx, msg)
+	resp := chattable[0].(tgbotapi.MessageConfig)
+	assert.NotNil(t, resp.ReplyMarkup)
+
+	cq := &tgbotapi.CallbackQuery{
+		From:    &tgbotapi.User{ID: 2, UserName: "Sasha"},
+		Data:    callbackData(t, app.callbacks, internal.CallbackDelete, internal.DeleteConfirm),
+		Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 2}}}
+	_, _ = app.handleCallbackQuery(ctx, cq)
+
+	_, err := app.users.GetByUserId(ctx, 2)
+	assert.True(t, errors.Is(err, models.ErrNoRecord))
+
+	matches, err := app.likes.CountMatches(ctx, 1)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 0, matches)
+}
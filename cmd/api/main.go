@@ -1,26 +1,36 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"github.com/Eretic431/datingTelegramBot/internal"
 	"github.com/Eretic431/datingTelegramBot/internal/data/postgres"
+	"github.com/Eretic431/datingTelegramBot/internal/metrics"
+	"github.com/Eretic431/datingTelegramBot/internal/sender"
+	"github.com/Eretic431/datingTelegramBot/internal/usecase"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/xlab/closer"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"log"
 	"net/http"
-	"os"
-	"strconv"
 )
 
 type application struct {
-	config  *config
-	usecase internal.Usecase
-	log     *zap.SugaredLogger
-	users   *postgres.UserRepository
-	likes   *postgres.LikeRepository
-	bot     *tgbotapi.BotAPI
-	updates tgbotapi.UpdatesChannel
+	config    *config
+	usecase   internal.Usecase
+	log       *zap.SugaredLogger
+	users     *postgres.UserRepository
+	likes     *postgres.LikeRepository
+	bot       *tgbotapi.BotAPI
+	callbacks *internal.CallbackCodec
+	webhook   *webhook
+	admin     *adminAPI
+	metrics   *metrics.Metrics
+	health    *health
+	limits    *rateLimits
+	sender    *sender.Sender
+	updates   tgbotapi.UpdatesChannel
 }
 
 func main() {
@@ -28,30 +38,125 @@ func main() {
 	if err != nil {
 		log.Fatal("could not init application", err)
 	}
+
+	errorLog, err := zap.NewStdLogAt(app.log.Desugar(), zap.ErrorLevel)
+	if err != nil {
+		app.log.Fatalw("could not init server logger", "err", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ping", app.pingPong)
+	mux.HandleFunc("/healthz", app.health.liveHandler)
+	mux.HandleFunc("/readyz", app.health.readyHandler)
+	mux.Handle("/metrics", app.metrics.Handler())
+	if app.admin != nil {
+		mux.Handle(adminPathPrefix, app.admin)
+	}
+	app.registerTestHooks(mux)
+
+	server := &http.Server{Addr: ":8090", Handler: mux, ErrorLog: errorLog}
+
+	var webhookServer *http.Server
+	if app.webhook != nil {
+		webhookMux := http.NewServeMux()
+		webhookMux.Handle(app.webhook.path, app.webhook)
+
+		webhookServer = &http.Server{Addr: ":" + app.config.Port, Handler: webhookMux, ErrorLog: errorLog}
+	}
+
+	handlersCtx, cancelHandlers := context.WithCancel(context.Background())
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	stopSender := make(chan struct{})
+	senderStopped := make(chan struct{})
+
 	closer.Bind(func() {
 		log.Print("stopping server")
+		app.shutdown(stop, stopped, cancelHandlers, stopSender, senderStopped, server, webhookServer)
 		cleanup()
 	})
 
-	_, err = zap.NewStdLogAt(app.log.Desugar(), zap.ErrorLevel)
-	if err != nil {
-		app.log.Fatalw("could not init server logger", "err", err)
+	app.restoreOutbox()
+	go func() {
+		app.sender.Run(stopSender)
+		close(senderStopped)
+	}()
+
+	go app.serve(server)
+	if webhookServer != nil {
+		go app.serve(webhookServer)
 	}
 
 	go func() {
-		http.HandleFunc("/deleteAll", app.deleteAllHandler)
-		http.HandleFunc("/addTestUser", app.addTestUser)
-		http.HandleFunc("/addTestUserWithLike", app.addTestUserWithLike)
-		http.HandleFunc("/panic", app.panicHandler)
-		http.HandleFunc("/ping", app.pingPong)
-
-		if err = http.ListenAndServe(":8090", nil); err != nil {
-			log.Fatalf("couldn't start listen and serve with err = %e", err)
-			return
-		}
+		// The pool is closed after stopped, so the background jobs have to return before it too
+		pruned := make(chan struct{})
+		go func() {
+			app.pruneServedCards(handlersCtx, stop)
+			close(pruned)
+		}()
+
+		app.handleUpdates(handlersCtx, stop)
+		<-pruned
+		close(stopped)
 	}()
 
-	app.handleUpdates()
+	closer.Hold()
+}
+
+func (a *application) serve(server *http.Server) {
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("couldn't start listen and serve on %s with err = %v", server.Addr, err)
+	}
+}
+
+// shutdown stops receiving updates, waits for the in-flight ones to be handled and their answers to be sent,
+// then stops http servers. Everything has to fit into config.ShutdownTimeout.
+func (a *application) shutdown(
+	stop chan<- struct{},
+	stopped <-chan struct{},
+	cancelHandlers context.CancelFunc,
+	stopSender chan<- struct{},
+	senderStopped <-chan struct{},
+	server, webhookServer *http.Server,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), a.config.ShutdownTimeout)
+	defer cancel()
+
+	// Webhook requests which are already accepted still have to be pushed to the updates channel,
+	// so the webhook server is stopped before the update loop.
+	if webhookServer != nil {
+		if err := webhookServer.Shutdown(ctx); err != nil {
+			a.log.Warnw("could not shutdown webhook server", "err", err)
+		}
+	} else {
+		a.bot.StopReceivingUpdates()
+	}
+	close(stop)
+
+	// The database pool is closed after shutdown, so the handlers have to finish even after the timeout,
+	// they are cancelled then and return right away.
+	select {
+	case <-stopped:
+		a.log.Info("all in-flight updates are handled")
+	case <-ctx.Done():
+		a.log.Warn("in-flight updates were not handled before shutdown timeout, cancelling them")
+		cancelHandlers()
+		<-stopped
+	}
+	cancelHandlers()
+
+	close(stopSender)
+
+	select {
+	case <-senderStopped:
+		a.log.Info("all queued messages are sent")
+	case <-ctx.Done():
+		a.log.Warn("queued messages were not sent before shutdown timeout, only the persisted ones will be sent after restart")
+	}
+
+	if err := server.Shutdown(ctx); err != nil {
+		a.log.Warnw("could not shutdown server", "err", err)
+	}
 }
 
 func newLogger(c *config) (*zap.SugaredLogger, func(), error) {
@@ -84,44 +189,27 @@ func newPostgresConfig(c *config, logger *zap.SugaredLogger) *postgres.Config {
 	}
 }
 
-func (a *application) panicHandler(w http.ResponseWriter, r *http.Request) {
-	a.log.Info("panicHandler")
-	//panic("emulate some panic")
-	os.Exit(1)
+func newCallbackCodec(c *config) *internal.CallbackCodec {
+	return internal.NewCallbackCodec(c.callbackKey())
 }
 
-func (a *application) pingPong(w http.ResponseWriter, r *http.Request) {
-	a.log.Info("pingPongHandler")
-	_, _ = w.Write([]byte("pong"))
-}
+func newUsecaseConfig(c *config) *usecase.Config {
+	return &usecase.Config{
+		MinAge: c.MinAge,
+		MaxAge: c.MaxAge,
 
-func (a *application) deleteAllHandler(w http.ResponseWriter, r *http.Request) {
-	_ = a.usecase.DeleteAll(r.Context())
-	a.log.Info("deleting completed")
-	w.WriteHeader(http.StatusOK)
-}
+		MaxPhotos: c.MaxPhotos,
 
-func (a *application) addTestUser(w http.ResponseWriter, r *http.Request) {
-	sexStr := r.URL.Query().Get("sex")
-	sex, err := strconv.ParseBool(sexStr)
-	if err != nil {
-		a.log.Errorf("couldn't parse query parametr sex = %s", sexStr)
-	}
-	_ = a.usecase.AddTestUser(r.Context(), sex)
-	a.log.Info("test user added")
-	w.WriteHeader(http.StatusOK)
-}
+		LikeNotifications: c.LikeNotifications,
+		UndoWindow:        c.UndoWindow,
+		ServedCardTTL:     c.ServedCardTTL,
+		DailyLikes:        c.DailyLikes,
 
-func (a *application) addTestUserWithLike(w http.ResponseWriter, r *http.Request) {
-	sexStr := r.URL.Query().Get("sex")
-	sex, err := strconv.ParseBool(sexStr)
-	if err != nil {
-		a.log.Errorf("couldn't parse query parametr sex = %s", sexStr)
+		ReportsToHide: c.ReportsToHide,
 	}
+}
 
-	toId := r.URL.Query().Get("toId")
-
-	_ = a.usecase.AddTestUserWithLike(r.Context(), sex, toId)
-	a.log.Info("test user with like added")
-	w.WriteHeader(http.StatusOK)
+func (a *application) pingPong(w http.ResponseWriter, r *http.Request) {
+	a.log.Info("pingPongHandler")
+	_, _ = w.Write([]byte("pong"))
 }
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/postgres"
+	"github.com/Eretic431/datingTelegramBot/internal/metrics"
+	"time"
+)
+
+// usersMetricsTimeout limits the query counting users at every scrape
+const usersMetricsTimeout = 5 * time.Second
+
+func newMetrics(db postgres.PgxPoolIface) *metrics.Metrics {
+	m := metrics.New()
+	if pool, ok := db.(metrics.PoolStater); ok {
+		m.MustRegister(metrics.NewPoolCollector(pool))
+	}
+	m.MustRegister(metrics.NewUsersCollector(postgres.NewStatsRepository(db), usersMetricsTimeout))
+
+	return m
+}
+
+func newUsersRepository(db postgres.PgxPoolIface, m *metrics.Metrics) internal.UsersRepository {
+	return metrics.NewUsersRepository(postgres.NewUserRepository(db), m)
+}
+
+func newLikesRepository(db postgres.PgxPoolIface, m *metrics.Metrics) internal.LikesRepository {
+	return metrics.NewLikesRepository(postgres.NewLikeRepository(db), m)
+}
+
+func newPhotosRepository(db postgres.PgxPoolIface, m *metrics.Metrics) internal.PhotosRepository {
+	return metrics.NewPhotosRepository(postgres.NewPhotoRepository(db), m)
+}
+
+func newBlocksRepository(db postgres.PgxPoolIface, m *metrics.Metrics) internal.BlocksRepository {
+	return metrics.NewBlocksRepository(postgres.NewBlockRepository(db), m)
+}
+
+func newReportsRepository(db postgres.PgxPoolIface, m *metrics.Metrics) internal.ReportsRepository {
+	return metrics.NewReportsRepository(postgres.NewReportRepository(db), m)
+}
+
+func newServedCardsRepository(db postgres.PgxPoolIface, m *metrics.Metrics) internal.ServedCardsRepository {
+	return metrics.NewServedCardsRepository(postgres.NewServedCardRepository(db), m)
+}
+
+func newStatsRepository(db postgres.PgxPoolIface, m *metrics.Metrics) internal.StatsRepository {
+	return metrics.NewStatsRepository(postgres.NewStatsRepository(db), m)
+}
+
+func newOutboxRepository(db postgres.PgxPoolIface, m *metrics.Metrics) internal.OutboxRepository {
+	return metrics.NewOutboxRepository(postgres.NewOutboxRepository(db), m)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/logging"
+	"github.com/Eretic431/datingTelegramBot/internal/ratelimit"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"time"
+)
+
+const rateLimitedText = "Слишком много действий подряд. Пожалуйста, попробуйте немного позже."
+
+// Budgets of the rate limits
+const (
+	budgetCommands = "commands" // Commands and buttons other than likes
+	budgetSwipes   = "swipes"
+	budgetText     = "text" // Messages which aren't commands, e.g. answers while filling the profile
+)
+
+// rateLimits are the separate per-user budgets of the updates.
+type rateLimits struct {
+	commands  *ratelimit.Limiter
+	swipes    *ratelimit.Limiter
+	text      *ratelimit.Limiter
+	callbacks *internal.CallbackCodec
+}
+
+func newRateLimits(c *config, callbacks *internal.CallbackCodec) *rateLimits {
+	return &rateLimits{
+		commands:  ratelimit.New(c.CommandsPerMinute, time.Minute),
+		swipes:    ratelimit.New(c.SwipesPerMinute, time.Minute),
+		text:      ratelimit.New(c.TextPerMinute, time.Minute),
+		callbacks: callbacks,
+	}
+}
+
+// budget returns the limiter the update is charged to with its name.
+func (l *rateLimits) budget(update tgbotapi.Update) (*ratelimit.Limiter, string) {
+	switch {
+	case update.CallbackQuery != nil:
+		callback, err := l.callbacks.Decode(update.CallbackQuery.Data)
+		if err == nil && (callback.Action == internal.CallbackLike || callback.Action == internal.CallbackDislike) {
+			return l.swipes, budgetSwipes
+		}
+		return l.commands, budgetCommands
+	case update.Message != nil && update.Message.IsCommand():
+		return l.commands, budgetCommands
+	default:
+		return l.text, budgetText
+	}
+}
+
+// allow charges the update to the budget of its user. It's checked before the update is dispatched, so a flood
+// doesn't take the workers. The updates over the budget are dropped: pressed buttons are always answered with a toast,
+// messages get a reply only once per exhausted budget, so a flood isn't answered with a flood. The answers are queued
+// to the sender, Telegram isn't waited for.
+func (a *application) allow(ctx context.Context, update tgbotapi.Update) bool {
+	user := update.SentFrom()
+	if user == nil {
+		return true
+	}
+
+	limiter, budget := a.limits.budget(update)
+	allowed, firstDenied := limiter.Allow(user.ID)
+	if allowed {
+		return true
+	}
+
+	a.metrics.RateLimited.WithLabelValues(budget).Inc()
+	log := updateLogger(a.log, update)
+	if firstDenied {
+		log.Infow("user hit the rate limit", "budget", budget)
+	}
+	ctx = logging.WithLogger(ctx, log)
+
+	if update.CallbackQuery != nil {
+		a.sender.Enqueue(ctx, tgbotapi.NewCallback(update.CallbackQuery.ID, rateLimitedText))
+		return false
+	}
+
+	if chat := update.FromChat(); chat != nil && firstDenied {
+		a.send(ctx, update, tgbotapi.NewMessage(chat.ID, rateLimitedText))
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/metrics"
+	"github.com/Eretic431/datingTelegramBot/internal/sender"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimits_Budget(t *testing.T) {
+	codec := internal.NewCallbackCodec([]byte("key"))
+	limits := newRateLimits(&config{CommandsPerMinute: 1, SwipesPerMinute: 1, TextPerMinute: 1}, codec)
+
+	command := &tgbotapi.Message{Text: "/next", Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 5}}}
+
+	cases := []struct {
+		name     string
+		update   tgbotapi.Update
+		expected string
+	}{
+		{"like", tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{
+			Data: callbackData(t, codec, internal.CallbackLike, "2"),
+		}}, budgetSwipes},
+		{"dislike", tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{
+			Data: callbackData(t, codec, internal.CallbackDislike, "2"),
+		}}, budgetSwipes},
+		{"other button", tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{
+			Data: callbackData(t, codec, internal.CallbackUndo, ""),
+		}}, budgetCommands},
+		{"invalid button", tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{Data: "like;2"}}, budgetCommands},
+		{"command", tgbotapi.Update{Message: command}, budgetCommands},
+		{"text", tgbotapi.Update{Message: &tgbotapi.Message{Text: "Masha"}}, budgetText},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			limiter, budget := limits.budget(c.update)
+			assert.EqualValues(t, c.expected, budget)
+			assert.NotNil(t, limiter)
+		})
+	}
+}
+
+func TestApplication_Dispatch_ShouldDropUpdatesOverRateLimit(t *testing.T) {
+	codec := internal.NewCallbackCodec([]byte("key"))
+
+	// The bot is nil: denied updates must be answered through the sender, not inline
+	app := &application{
+		log:     zap.NewNop().Sugar(),
+		metrics: metrics.New(),
+		limits:  newRateLimits(&config{CommandsPerMinute: 1}, codec),
+		sender:  sender.New(nil, nil, sender.Config{}, zap.NewNop().Sugar(), metrics.New()),
+	}
+
+	var mu sync.Mutex
+	handled := 0
+	d := newDispatcher(context.Background(), 1, time.Second, func(ctx context.Context, update tgbotapi.Update) {
+		mu.Lock()
+		defer mu.Unlock()
+		handled++
+	})
+	d.start()
+
+	command := newTestUpdate(1, 1)
+	command.Message.Text = "/next"
+	command.Message.Entities = []tgbotapi.MessageEntity{{Type: "bot_command", Length: 5}}
+	button := tgbotapi.Update{UpdateID: 2, CallbackQuery: &tgbotapi.CallbackQuery{
+		ID:      "callback",
+		From:    &tgbotapi.User{ID: 1},
+		Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 1}},
+		Data:    callbackData(t, codec, internal.CallbackUndo, ""),
+	}}
+
+	app.dispatch(context.Background(), d, command)
+	app.dispatch(context.Background(), d, command)
+	app.dispatch(context.Background(), d, button)
+	d.stop()
+
+	assert.Equal(t, 1, handled)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/metrics"
+	"github.com/Eretic431/datingTelegramBot/internal/sender"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+	"time"
+)
+
+// restoreOutboxTimeout limits loading the persisted messages on start
+const restoreOutboxTimeout = 10 * time.Second
+
+func newSender(c *config, bot *tgbotapi.BotAPI, outbox internal.OutboxRepository, log *zap.SugaredLogger, m *metrics.Metrics) *sender.Sender {
+	if !c.Outbox {
+		outbox = nil
+	}
+
+	return sender.New(bot, outbox, sender.Config{
+		MessagesPerSecond: c.SendRate,
+		ChatInterval:      c.SendChatInterval,
+		MaxAttempts:       c.SendMaxAttempts,
+		MinBackoff:        c.SendMinBackoff,
+		MaxBackoff:        c.SendMaxBackoff,
+	}, log, m)
+}
+
+// restoreOutbox queues the messages which weren't sent before the last shutdown.
+// The bot can work without them, so errors are only logged.
+func (a *application) restoreOutbox() {
+	ctx, cancel := context.WithTimeout(context.Background(), restoreOutboxTimeout)
+	defer cancel()
+
+	if err := a.sender.Restore(ctx); err != nil {
+		a.log.Errorw("could not restore persisted messages", "err", err)
+	}
+}
+
+// send queues message. Messages to the chats other than the one of the update, e.g. about a match,
+// are persisted: unlike the user who sent the update, their recipient can't ask for them again.
+func (a *application) send(ctx context.Context, update tgbotapi.Update, message tgbotapi.Chattable) {
+	if chat := update.FromChat(); chat != nil && sender.ChatId(message) != chat.ID {
+		message = sender.Durable(message)
+	}
+
+	a.sender.Enqueue(ctx, message)
+}
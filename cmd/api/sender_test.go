@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/Eretic431/datingTelegramBot/internal/metrics"
+	"github.com/Eretic431/datingTelegramBot/internal/mock"
+	"github.com/Eretic431/datingTelegramBot/internal/sender"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/golang/mock/gomock"
+	"go.uber.org/zap"
+	"testing"
+)
+
+func TestSend_ShouldPersistMessagesToOtherChats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	outbox := mock.NewMockOutboxRepository(ctrl)
+	outbox.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *models.OutboxMessage) error {
+		if m.ChatId != 2 {
+			t.Errorf("message to chat %d was persisted", m.ChatId)
+		}
+		return nil
+	})
+
+	app := &application{
+		log:    zap.NewNop().Sugar(),
+		sender: sender.New(nil, outbox, sender.Config{}, zap.NewNop().Sugar(), metrics.New()),
+	}
+	update := tgbotapi.Update{Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 1}}}
+
+	app.send(context.Background(), update, tgbotapi.NewMessage(1, "reply"))
+	app.send(context.Background(), update, tgbotapi.NewMessage(2, "match"))
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"github.com/Eretic431/datingTelegramBot/internal"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"log"
 	"os"
@@ -58,3 +59,20 @@ func refreshLikesTable() error {
 	}
 	return nil
 }
+
+// serveCard lets userId like toId, as if toId was shown by /next.
+func serveCard(userId, toId int64) error {
+	ctx := context.Background()
+	_, err := db.Exec(ctx, "INSERT INTO served_cards (user_id, to_id) VALUES ($1, $2) "+
+		"ON CONFLICT (user_id, to_id) DO UPDATE SET served_at = now();", userId, toId)
+	return err
+}
+
+// callbackData returns the data of a button created with codec.
+func callbackData(t *testing.T, codec *internal.CallbackCodec, action internal.CallbackAction, payload string) string {
+	data, err := codec.Encode(internal.Callback{Action: action, Payload: payload})
+	if err != nil {
+		t.Fatalf("could not encode callback: %s", err)
+	}
+	return data
+}
@@ -0,0 +1,62 @@
+//go:build testhooks
+// +build testhooks
+
+package main
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+)
+
+// registerTestHooks adds the endpoints system tests use to prepare the database and to restart the bot.
+// They are unauthenticated, so they are compiled only with the testhooks build tag.
+func (a *application) registerTestHooks(mux *http.ServeMux) {
+	a.log.Warn("test hooks are enabled, never run this build in production")
+
+	mux.HandleFunc("/deleteAll", a.deleteAllHandler)
+	mux.HandleFunc("/addTestUser", a.addTestUser)
+	mux.HandleFunc("/addTestUserWithLike", a.addTestUserWithLike)
+	mux.HandleFunc("/panic", a.panicHandler)
+}
+
+func (a *application) panicHandler(w http.ResponseWriter, r *http.Request) {
+	a.log.Info("panicHandler")
+	//panic("emulate some panic")
+	os.Exit(1)
+}
+
+func (a *application) deleteAllHandler(w http.ResponseWriter, r *http.Request) {
+	_ = a.usecase.DeleteAll(r.Context())
+	a.log.Info("deleting completed")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (a *application) addTestUser(w http.ResponseWriter, r *http.Request) {
+	sexStr := r.URL.Query().Get("sex")
+	sex, err := strconv.ParseBool(sexStr)
+	if err != nil {
+		a.log.Errorf("couldn't parse query parametr sex = %s", sexStr)
+	}
+	_ = a.usecase.AddTestUser(r.Context(), sex)
+	a.log.Info("test user added")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (a *application) addTestUserWithLike(w http.ResponseWriter, r *http.Request) {
+	sexStr := r.URL.Query().Get("sex")
+	sex, err := strconv.ParseBool(sexStr)
+	if err != nil {
+		a.log.Errorf("couldn't parse query parametr sex = %s", sexStr)
+	}
+
+	toIdStr := r.URL.Query().Get("toId")
+	toId, err := strconv.ParseInt(toIdStr, 10, 64)
+	if err != nil {
+		a.log.Errorf("couldn't parse query parametr toId = %s", toIdStr)
+	}
+
+	_ = a.usecase.AddTestUserWithLike(r.Context(), sex, toId)
+	a.log.Info("test user with like added")
+	w.WriteHeader(http.StatusOK)
+}
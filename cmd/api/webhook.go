@@ -0,0 +1,85 @@
+package main
+
+import (
+	"crypto/subtle"
+	"errors"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+	"net/http"
+	"net/url"
+)
+
+const secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// webhook receives updates pushed by Telegram and feeds them into the same channel long polling uses.
+type webhook struct {
+	path    string
+	secret  string
+	bot     *tgbotapi.BotAPI
+	log     *zap.SugaredLogger
+	updates chan tgbotapi.Update
+}
+
+var _ http.Handler = &webhook{}
+
+// newWebhook returns nil if the bot works in long polling mode.
+func newWebhook(c *config, bot *tgbotapi.BotAPI, log *zap.SugaredLogger) (*webhook, error) {
+	if !c.Webhook {
+		return nil, nil
+	}
+
+	if len(c.WebhookUrl) == 0 || len(c.WebhookSecret) == 0 {
+		return nil, errors.New("webhook url and secret must be set in webhook mode")
+	}
+
+	webhookUrl, err := url.Parse(c.WebhookUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	path := webhookUrl.Path
+	if len(path) == 0 {
+		path = "/"
+	}
+
+	return &webhook{
+		path:    path,
+		secret:  c.WebhookSecret,
+		bot:     bot,
+		log:     log,
+		updates: make(chan tgbotapi.Update, bot.Buffer),
+	}, nil
+}
+
+// register tells Telegram where to send updates.
+func (wh *webhook) register(webhookUrl string) error {
+	params := make(tgbotapi.Params)
+	params["url"] = webhookUrl
+	params["secret_token"] = wh.secret
+
+	_, err := wh.bot.MakeRequest("setWebhook", params)
+	return err
+}
+
+func (wh *webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	secret := r.Header.Get(secretTokenHeader)
+	if subtle.ConstantTimeCompare([]byte(secret), []byte(wh.secret)) != 1 {
+		wh.log.Warn("received webhook request with wrong secret token")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	update, err := wh.bot.HandleUpdate(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	select {
+	case wh.updates <- *update:
+		w.WriteHeader(http.StatusOK)
+	case <-r.Context().Done():
+		// Telegram will redeliver the update
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+)
+
+const testWebhookUpdate = `{
+	"update_id": 10,
+	"message": {
+		"message_id": 1,
+		"from": {"id": 42, "is_bot": false, "first_name": "Masha", "username": "Masha"},
+		"chat": {"id": 42, "type": "private"},
+		"date": 1640000000,
+		"text": "/start",
+		"entities": [{"offset": 0, "length": 6, "type": "bot_command"}]
+	}
+}`
+
+func newTestWebhook(t *testing.T) *webhook {
+	wh, err := newWebhook(
+		&config{Webhook: true, WebhookUrl: "https://example.com/webhook", WebhookSecret: "secret"},
+		&tgbotapi.BotAPI{Buffer: 1},
+		zaptest.NewLogger(t).Sugar(),
+	)
+	if err != nil {
+		t.Fatalf("error was not expected while creating webhook: %s", err.Error())
+	}
+
+	return wh
+}
+
+func TestNewWebhook_ShouldReturnNilInPollingMode(t *testing.T) {
+	wh, err := newWebhook(&config{}, &tgbotapi.BotAPI{}, zaptest.NewLogger(t).Sugar())
+	assert.Nil(t, err)
+	assert.Nil(t, wh)
+}
+
+func TestNewWebhook_ShouldReturnErrorWithoutSecret(t *testing.T) {
+	wh, err := newWebhook(
+		&config{Webhook: true, WebhookUrl: "https://example.com/webhook"},
+		&tgbotapi.BotAPI{},
+		zaptest.NewLogger(t).Sugar(),
+	)
+	assert.NotNil(t, err)
+	assert.Nil(t, wh)
+}
+
+func TestWebhook_ServeHTTP(t *testing.T) {
+	wh := newTestWebhook(t)
+	assert.Equal(t, "/webhook", wh.path)
+
+	server := httptest.NewServer(wh)
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL+wh.path, strings.NewReader(testWebhookUpdate))
+	req.Header.Set(secretTokenHeader, "secret")
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	update := <-wh.updates
+	assert.Equal(t, 10, update.UpdateID)
+	assert.EqualValues(t, 42, update.Message.From.ID)
+	assert.Equal(t, "start", update.Message.Command())
+}
+
+func TestWebhook_ServeHTTP_ShouldRejectWrongSecret(t *testing.T) {
+	wh := newTestWebhook(t)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, wh.path, strings.NewReader(testWebhookUpdate))
+	req.Header.Set(secretTokenHeader, "wrong")
+
+	wh.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Len(t, wh.updates, 0)
+}
+
+func TestWebhook_ServeHTTP_ShouldRejectMalformedUpdate(t *testing.T) {
+	wh := newTestWebhook(t)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, wh.path, strings.NewReader("not a json"))
+	req.Header.Set(secretTokenHeader, "secret")
+
+	wh.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Len(t, wh.updates, 0)
+}
+
+func TestNewTgBotUpdatesChan_ShouldDeleteWebhookBeforePolling(t *testing.T) {
+	methods := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := path.Base(r.URL.Path)
+		switch method {
+		case "getMe":
+			_, _ = w.Write([]byte(`{"ok":true,"result":{"id":1,"is_bot":true,"first_name":"bot"}}`))
+			return
+		case "getUpdates":
+			_, _ = w.Write([]byte(`{"ok":true,"result":[]}`))
+		default:
+			_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+		}
+
+		select {
+		case methods <- method:
+		default:
+		}
+	}))
+	defer server.Close()
+
+	bot, err := tgbotapi.NewBotAPIWithAPIEndpoint("token", server.URL+"/bot%s/%s")
+	if err != nil {
+		t.Fatalf("error was not expected while creating bot: %s", err.Error())
+	}
+
+	_, err = newTgBotUpdatesChan(&config{}, bot, nil)
+	assert.Nil(t, err)
+	defer bot.StopReceivingUpdates()
+
+	assert.EqualValues(t, "deleteWebhook", <-methods)
+	assert.EqualValues(t, "getUpdates", <-methods)
+}
@@ -15,12 +15,26 @@ func initApp() (*application, func(), error) {
 		newLogger,
 		newPostgresConfig,
 		postgres.NewPsqlPool,
-		postgres.NewUserRepository,
-		postgres.NewLikeRepository,
+		newMetrics,
+		newUsersRepository,
+		newLikesRepository,
+		newPhotosRepository,
+		newBlocksRepository,
+		newReportsRepository,
+		newServedCardsRepository,
+		newStatsRepository,
+		newOutboxRepository,
 		wire.Struct(new(postgres.UserRepository), "*"),
 		wire.Struct(new(postgres.LikeRepository), "*"),
 		newTgBot,
+		newCallbackCodec,
+		newWebhook,
+		newAdminAPI,
+		newHealth,
+		newRateLimits,
+		newSender,
 		newTgBotUpdatesChan,
+		newUsecaseConfig,
 		usecase.NewUsecase,
 		wire.Struct(new(application), "*"),
 	)
@@ -28,30 +28,61 @@ func initApp() (*application, func(), error) {
 		cleanup()
 		return nil, nil, err
 	}
-	usersRepository := postgres.NewUserRepository(pgxPoolIface)
-	likesRepository := postgres.NewLikeRepository(pgxPoolIface)
+	metrics := newMetrics(pgxPoolIface)
+	usersRepository := newUsersRepository(pgxPoolIface, metrics)
+	likesRepository := newLikesRepository(pgxPoolIface, metrics)
+	photosRepository := newPhotosRepository(pgxPoolIface, metrics)
+	blocksRepository := newBlocksRepository(pgxPoolIface, metrics)
+	reportsRepository := newReportsRepository(pgxPoolIface, metrics)
+	servedCardsRepository := newServedCardsRepository(pgxPoolIface, metrics)
+	callbackCodec := newCallbackCodec(mainConfig)
 	botAPI, err := newTgBot(mainConfig)
 	if err != nil {
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	internalUsecase := usecase.NewUsecase(usersRepository, likesRepository, botAPI, sugaredLogger)
+	usecaseConfig := newUsecaseConfig(mainConfig)
+	internalUsecase := usecase.NewUsecase(usersRepository, likesRepository, photosRepository, blocksRepository, reportsRepository, servedCardsRepository, callbackCodec, botAPI, sugaredLogger, usecaseConfig)
 	userRepository := &postgres.UserRepository{
 		DB: pgxPoolIface,
 	}
 	likeRepository := &postgres.LikeRepository{
 		DB: pgxPoolIface,
 	}
-	updatesChannel := newTgBotUpdatesChan(botAPI)
+	mainWebhook, err := newWebhook(mainConfig, botAPI, sugaredLogger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	statsRepository := newStatsRepository(pgxPoolIface, metrics)
+	mainAdminAPI := newAdminAPI(mainConfig, usersRepository, likesRepository, statsRepository, sugaredLogger)
+	mainHealth := newHealth(mainConfig, pgxPoolIface, botAPI, sugaredLogger)
+	mainRateLimits := newRateLimits(mainConfig, callbackCodec)
+	outboxRepository := newOutboxRepository(pgxPoolIface, metrics)
+	sender := newSender(mainConfig, botAPI, outboxRepository, sugaredLogger, metrics)
+	updatesChannel, err := newTgBotUpdatesChan(mainConfig, botAPI, mainWebhook)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	mainApplication := &application{
-		config:  mainConfig,
-		usecase: internalUsecase,
-		log:     sugaredLogger,
-		users:   userRepository,
-		likes:   likeRepository,
-		bot:     botAPI,
-		updates: updatesChannel,
+		config:    mainConfig,
+		usecase:   internalUsecase,
+		log:       sugaredLogger,
+		users:     userRepository,
+		likes:     likeRepository,
+		bot:       botAPI,
+		callbacks: callbackCodec,
+		webhook:   mainWebhook,
+		admin:     mainAdminAPI,
+		metrics:   metrics,
+		health:    mainHealth,
+		limits:    mainRateLimits,
+		sender:    sender,
+		updates:   updatesChannel,
 	}
 	return mainApplication, func() {
 		cleanup2()
@@ -0,0 +1,11 @@
+//go:generate mockgen -source blocks_repository.go -destination mock/blocks_repository.go -package mock
+package internal
+
+import "context"
+
+type BlocksRepository interface {
+	// Add blocks toId for fromId, blocking the same user twice isn't an error
+	Add(ctx context.Context, fromId, toId int64) error
+	// IsBlocked is true if either of the users blocked the other
+	IsBlocked(ctx context.Context, userId, otherId int64) (bool, error)
+}
@@ -0,0 +1,141 @@
+package internal
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Callback data is "<version><action><payload><mac>". The version and the action are single characters,
+// mac is the base64 of the truncated HMAC-SHA256 of everything before it, so the data can't be forged by a client.
+
+// CallbackAction is what the pressed button does.
+type CallbackAction byte
+
+const (
+	CallbackLike    CallbackAction = 'l' // Payload is the id of the liked user, see LikePayload
+	CallbackDislike CallbackAction = 'd' // Payload is the same as for CallbackLike
+	CallbackUndo    CallbackAction = 'u'
+	CallbackReport  CallbackAction = 'r' // Payload is the id of the reported user
+	CallbackBlock   CallbackAction = 'b' // Payload is the id of the blocked user
+	CallbackDelete  CallbackAction = 'x' // Payload is DeleteConfirm or DeleteCancel
+	CallbackReview  CallbackAction = 'a' // Payload is "<review action>;<user id>"
+	CallbackEdit    CallbackAction = 'e' // Payload is the profile stage
+	CallbackMatches CallbackAction = 'm' // Payload is the page
+	CallbackSkip    CallbackAction = 's' // Leaves the field of the current dialog step as is
+	CallbackInput   CallbackAction = 'i' // Payload is handled by the current dialog step as if the user typed it
+	CallbackCancel  CallbackAction = 'c' // Leaves the edited field as is, the same as /cancel
+)
+
+var callbackActions = map[CallbackAction]struct{}{
+	CallbackLike:    {},
+	CallbackDislike: {},
+	CallbackUndo:    {},
+	CallbackReport:  {},
+	CallbackBlock:   {},
+	CallbackDelete:  {},
+	CallbackReview:  {},
+	CallbackEdit:    {},
+	CallbackMatches: {},
+	CallbackSkip:    {},
+	CallbackInput:   {},
+	CallbackCancel:  {},
+}
+
+const (
+	callbackVersion = '1'
+	callbackMacSize = 8
+
+	// maxCallbackData is the Telegram limit in bytes
+	maxCallbackData = 64
+	callbackMacLen  = (callbackMacSize*8 + 5) / 6
+
+	// MaxCallbackPayload is the longest payload in bytes which fits in callback data
+	MaxCallbackPayload = maxCallbackData - 2 - callbackMacLen
+)
+
+var (
+	ErrCallbackTooLong = errors.New("callback payload is too long")
+	ErrCallbackVersion = errors.New("unsupported callback version")
+	ErrInvalidCallback = errors.New("invalid callback")
+)
+
+type Callback struct {
+	Action  CallbackAction
+	Payload string
+}
+
+// CallbackCodec signs callback data with key. The same key has to be used to create keyboards and to decode
+// the pressed buttons, so a single codec is created on start.
+type CallbackCodec struct {
+	key []byte
+}
+
+func NewCallbackCodec(key []byte) *CallbackCodec {
+	return &CallbackCodec{key: key}
+}
+
+// Encode returns ErrCallbackTooLong if the payload doesn't fit in the callback data.
+func (c *CallbackCodec) Encode(callback Callback) (string, error) {
+	if len(callback.Payload) > MaxCallbackPayload {
+		return "", ErrCallbackTooLong
+	}
+
+	data := string([]byte{callbackVersion, byte(callback.Action)}) + callback.Payload
+	return data + c.mac(data), nil
+}
+
+// Decode verifies and decodes data of a button created with the same key.
+func (c *CallbackCodec) Decode(data string) (Callback, error) {
+	if len(data) > 0 && data[0] != callbackVersion {
+		return Callback{}, ErrCallbackVersion
+	}
+	if len(data) < 2+callbackMacLen || len(data) > maxCallbackData {
+		return Callback{}, ErrInvalidCallback
+	}
+
+	signed, mac := data[:len(data)-callbackMacLen], data[len(data)-callbackMacLen:]
+	if !hmac.Equal([]byte(mac), []byte(c.mac(signed))) {
+		return Callback{}, ErrInvalidCallback
+	}
+
+	action := CallbackAction(signed[1])
+	if _, ok := callbackActions[action]; !ok {
+		return Callback{}, ErrInvalidCallback
+	}
+
+	return Callback{Action: action, Payload: signed[2:]}, nil
+}
+
+func (c *CallbackCodec) mac(data string) string {
+	h := hmac.New(sha256.New, c.key)
+	h.Write([]byte(data))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil)[:callbackMacSize])
+}
+
+// LikePayload is the payload of CallbackLike and CallbackDislike. Likes sent from /likes carry LikesInboxSuffix.
+func LikePayload(toId int64, fromInbox bool) string {
+	payload := strconv.FormatInt(toId, 10)
+	if fromInbox {
+		payload += ";" + LikesInboxSuffix
+	}
+	return payload
+}
+
+// ParseLikePayload parses the payload of LikePayload.
+func ParseLikePayload(payload string) (toId int64, fromInbox bool, ok bool) {
+	parts := strings.Split(payload, ";")
+	if len(parts) > 2 || (len(parts) == 2 && parts[1] != LikesInboxSuffix) {
+		return 0, false, false
+	}
+
+	toId, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, false, false
+	}
+
+	return toId, len(parts) == 2, true
+}
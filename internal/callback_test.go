@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestCallbackCodec(t *testing.T) {
+	codec := NewCallbackCodec([]byte("key"))
+	callback := Callback{Action: CallbackLike, Payload: LikePayload(9223372036854775807, true)}
+
+	data, err := codec.Encode(callback)
+	assert.Nil(t, err)
+	assert.LessOrEqual(t, len(data), maxCallbackData)
+
+	decoded, err := codec.Decode(data)
+	assert.Nil(t, err)
+	assert.EqualValues(t, callback, decoded)
+}
+
+func TestCallbackCodec_Decode_Invalid(t *testing.T) {
+	codec := NewCallbackCodec([]byte("key"))
+	data, err := codec.Encode(Callback{Action: CallbackLike, Payload: "2"})
+	assert.Nil(t, err)
+
+	forged, err := NewCallbackCodec([]byte("other key")).Encode(Callback{Action: CallbackLike, Payload: "2"})
+	assert.Nil(t, err)
+
+	unknownAction, err := codec.Encode(Callback{Action: 'z', Payload: "2"})
+	assert.Nil(t, err)
+
+	cases := []struct {
+		name     string
+		data     string
+		expected error
+	}{
+		{"legacy", "like;2", ErrCallbackVersion},
+		{"empty", "", ErrInvalidCallback},
+		{"changed payload", data[:2] + "3" + data[3:], ErrInvalidCallback},
+		{"changed action", data[:1] + string(CallbackDislike) + data[2:], ErrInvalidCallback},
+		{"other key", forged, ErrInvalidCallback},
+		{"unknown action", unknownAction, ErrInvalidCallback},
+		{"next version", "2" + data[1:], ErrCallbackVersion},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := codec.Decode(c.data)
+			assert.ErrorIs(t, err, c.expected)
+		})
+	}
+}
+
+func TestCallbackCodec_Encode_TooLong(t *testing.T) {
+	codec := NewCallbackCodec([]byte("key"))
+
+	data, err := codec.Encode(Callback{Action: CallbackInput, Payload: strings.Repeat("a", MaxCallbackPayload)})
+	assert.Nil(t, err)
+	assert.Len(t, data, maxCallbackData)
+
+	_, err = codec.Encode(Callback{Action: CallbackInput, Payload: strings.Repeat("a", MaxCallbackPayload+1)})
+	assert.ErrorIs(t, err, ErrCallbackTooLong)
+}
+
+func TestParseLikePayload(t *testing.T) {
+	cases := []struct {
+		name      string
+		payload   string
+		toId      int64
+		fromInbox bool
+		ok        bool
+	}{
+		{"like", "2", 2, false, true},
+		{"inbox", "2;" + LikesInboxSuffix, 2, true, true},
+		{"empty", "", 0, false, false},
+		{"unknown suffix", "2;other", 0, false, false},
+		{"too many parts", "2;inbox;inbox", 0, false, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			toId, fromInbox, ok := ParseLikePayload(c.payload)
+			assert.EqualValues(t, c.toId, toId)
+			assert.EqualValues(t, c.fromInbox, fromInbox)
+			assert.EqualValues(t, c.ok, ok)
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package models
 
+import "time"
+
 // Like model
 type Like struct {
-	Id     int64  `db:"id"`
-	FromId string `db:"from_id"`
-	ToId   string `db:"to_id"`
-	Value  bool   `db:"value"`
+	Id        int64     `db:"id"`
+	FromId    int64     `db:"from_id"`
+	ToId      int64     `db:"to_id"`
+	Value     bool      `db:"value"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"` // Time of the last change of Value
 }
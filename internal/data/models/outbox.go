@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// OutboxMessage is a message waiting to be sent to Telegram. Payload is encoded by the sender.
+type OutboxMessage struct {
+	Id        int64     `db:"id"`
+	ChatId    int64     `db:"chat_id"`
+	Payload   []byte    `db:"payload"`
+	CreatedAt time.Time `db:"created_at"`
+}
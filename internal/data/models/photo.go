@@ -0,0 +1,10 @@
+package models
+
+// Photo of a user profile. Photos of a user are shown in Position order, the first one is the cover.
+type Photo struct {
+	Id       int64  `db:"id"`
+	UserId   int64  `db:"user_id"`
+	FileId   string `db:"file_id"`
+	Position int    `db:"position"`
+	Reviewed bool   `db:"reviewed"` // Checked by a moderator
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Report about an abusive profile. Reports stay unresolved until a moderator reviews them.
+type Report struct {
+	Id        int64     `db:"id"`
+	FromId    int64     `db:"from_id"`
+	ToId      int64     `db:"to_id"`
+	Reason    string    `db:"reason"`
+	CreatedAt time.Time `db:"created_at"`
+	Resolved  bool      `db:"resolved"`
+}
@@ -0,0 +1,13 @@
+package models
+
+// Stats are the counters shown to administrators
+type Stats struct {
+	Users             int `db:"users"`
+	Active            int `db:"active"` // Started and shown to others
+	Paused            int `db:"paused"`
+	Hidden            int `db:"hidden"`
+	Banned            int `db:"banned"`
+	Likes             int `db:"likes"`
+	Matches           int `db:"matches"` // Every mutual pair is counted once
+	UnresolvedReports int `db:"unresolved_reports"`
+}
@@ -2,14 +2,37 @@ package models
 
 // User model
 type User struct {
-	Id          string `db:"id"`
-	Name        string `db:"name"`
-	Sex         bool   `db:"sex"` // True if Sex is MALE, False if Sex is FEMALE
-	Age         int    `db:"age"`
-	Description string `db:"description"`
-	City        string `db:"city"`
-	Image       string `db:"image"`
-	Started     bool   `db:"started"`
-	Stage       int    `db:"stage"`
-	ChatId      int64  `db:"chat_id"`
+	Id           int64  `db:"id"` // Telegram user ID
+	Username     string `db:"username"`
+	Name         string `db:"name"`
+	Gender       int    `db:"gender"`
+	InterestedIn int    `db:"interested_in"` // Set of genders the user wants to see
+	Age          int    `db:"age"`
+	Description  string `db:"description"`
+	City         string `db:"city"`
+	Image        string `db:"image"`
+	Started      bool   `db:"started"`
+	Stage        int    `db:"stage"`
+	ChatId       int64  `db:"chat_id"`
+	Editing      bool   `db:"editing"`     // True if the user edits a single field of the profile at Stage
+	Paused       bool   `db:"paused"`      // Paused users aren't shown to others
+	Hidden       bool   `db:"hidden"`      // Reported users are hidden until review. Changed by SetHidden only
+	Banned       bool   `db:"banned"`      // Banned by a moderator. Changed by SetBanned only
+	ReportedId   int64  `db:"reported_id"` // User being reported at the reason stage
+
+	// Search preferences
+	MinAge   int  `db:"min_age"` // 0 if there is no lower bound
+	MaxAge   int  `db:"max_age"` // 0 if there is no upper bound
+	CityOnly bool `db:"city_only"`
 }
+
+// Gender values, stored in database. They are bit flags, so a set of genders fits into a single int.
+const (
+	GenderMale      = 1
+	GenderFemale    = 2
+	GenderNonBinary = 4
+	GenderAny       = GenderMale | GenderFemale | GenderNonBinary
+)
+
+// Genders lists every gender in display order
+var Genders = []int{GenderMale, GenderFemale, GenderNonBinary}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal"
+)
+
+type BlockRepository struct {
+	DB PgxPoolIface
+}
+
+var _ internal.BlocksRepository = &BlockRepository{}
+
+func NewBlockRepository(DB PgxPoolIface) internal.BlocksRepository {
+	return &BlockRepository{DB: DB}
+}
+
+func (br *BlockRepository) Add(ctx context.Context, fromId, toId int64) (err error) {
+	tx, err := br.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := "INSERT INTO blocks (from_id, to_id) VALUES ($1, $2) ON CONFLICT (from_id, to_id) DO NOTHING;"
+	if _, err = tx.Exec(ctx, query, fromId, toId); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (br *BlockRepository) IsBlocked(ctx context.Context, userId, otherId int64) (blocked bool, err error) {
+	tx, err := br.DB.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := "SELECT EXISTS (SELECT 1 FROM blocks WHERE (from_id = $1 AND to_id = $2) OR (from_id = $2 AND to_id = $1));"
+	if err = tx.QueryRow(ctx, query, userId, otherId).Scan(&blocked); err != nil {
+		return false, err
+	}
+
+	return blocked, nil
+}
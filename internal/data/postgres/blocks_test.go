@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBlockRepository_Add(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("INSERT INTO blocks ").WithArgs(
+		int64(1), int64(2),
+	).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectCommit()
+
+	blocks := NewBlockRepository(pool)
+
+	if err := blocks.Add(context.Background(), 1, 2); err != nil {
+		t.Errorf("error was not expected while adding block: %s", err.Error())
+	}
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestBlockRepository_Add_ShouldReturnSameErrorOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	someError := errors.New("some error")
+
+	pool.ExpectBegin()
+	pool.ExpectExec("INSERT INTO blocks ").WithArgs(
+		int64(1), int64(2),
+	).WillReturnError(someError)
+	pool.ExpectRollback()
+
+	blocks := NewBlockRepository(pool)
+
+	err = blocks.Add(context.Background(), 1, 2)
+	assert.True(t, errors.Is(err, someError))
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestBlockRepository_IsBlocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT EXISTS \\(SELECT 1 FROM blocks ").WithArgs(
+		int64(1), int64(2),
+	).WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+	pool.ExpectCommit()
+
+	blocks := NewBlockRepository(pool)
+
+	blocked, err := blocks.IsBlocked(context.Background(), 1, 2)
+	assert.Nil(t, err)
+	assert.True(t, blocked)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
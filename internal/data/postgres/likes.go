@@ -9,6 +9,7 @@ import (
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v4"
+	"time"
 )
 
 type LikeRepository struct {
@@ -55,7 +56,7 @@ func (lr *LikeRepository) Add(ctx context.Context, like *models.Like) (err error
 	return nil
 }
 
-func (lr *LikeRepository) Get(ctx context.Context, userFromId string, userToId string) (like *models.Like, err error) {
+func (lr *LikeRepository) Get(ctx context.Context, userFromId int64, userToId int64) (like *models.Like, err error) {
 	tx, err := lr.DB.Begin(ctx)
 	if err != nil {
 		return nil, err
@@ -84,6 +85,181 @@ func (lr *LikeRepository) Get(ctx context.Context, userFromId string, userToId s
 	return like, nil
 }
 
+// GetLast returns the most recently added or changed like from fromId.
+func (lr *LikeRepository) GetLast(ctx context.Context, fromId int64) (like *models.Like, err error) {
+	tx, err := lr.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	like = &models.Like{}
+	query := "SELECT id, from_id, to_id, value, created_at, updated_at FROM likes WHERE from_id=$1 ORDER BY updated_at DESC, id DESC LIMIT 1"
+
+	if err := pgxscan.Get(ctx, tx, like, query, fromId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNoRecord
+		}
+
+		return nil, err
+	}
+
+	return like, nil
+}
+
+// GetByUserId returns likes sent and received by the user, the newest first.
+func (lr *LikeRepository) GetByUserId(ctx context.Context, userId int64, limit, offset int) (likes []*models.Like, err error) {
+	tx, err := lr.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := `SELECT id, from_id, to_id, value, created_at, updated_at
+		FROM likes
+		WHERE from_id = $1 OR to_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3`
+
+	likes = make([]*models.Like, 0, limit)
+	if err := pgxscan.Select(ctx, tx, &likes, query, userId, limit, offset); err != nil {
+		return nil, err
+	}
+
+	return likes, nil
+}
+
+// GetMatches returns likes from userId which were answered with a like, newest match first.
+// CreatedAt of a returned like is the time of the match.
+func (lr *LikeRepository) GetMatches(ctx context.Context, userId int64, limit, offset int) (matches []*models.Like, err error) {
+	tx, err := lr.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := `SELECT l1.id, l1.from_id, l1.to_id, l1.value, greatest(l1.created_at, l2.created_at) AS created_at
+		FROM likes l1
+		JOIN likes l2 ON l2.from_id = l1.to_id AND l2.to_id = l1.from_id
+		WHERE l1.from_id = $1 AND l1.value AND l2.value
+			AND NOT EXISTS (SELECT 1 FROM blocks WHERE blocks.from_id IN (l1.from_id, l1.to_id) AND blocks.to_id IN (l1.from_id, l1.to_id))
+		ORDER BY created_at DESC, l1.id DESC
+		LIMIT $2 OFFSET $3`
+
+	matches = make([]*models.Like, 0, limit)
+	if err := pgxscan.Select(ctx, tx, &matches, query, userId, limit, offset); err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+func (lr *LikeRepository) CountMatches(ctx context.Context, userId int64) (count int, err error) {
+	tx, err := lr.DB.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := `SELECT count(*)
+		FROM likes l1
+		JOIN likes l2 ON l2.from_id = l1.to_id AND l2.to_id = l1.from_id
+		WHERE l1.from_id = $1 AND l1.value AND l2.value
+			AND NOT EXISTS (SELECT 1 FROM blocks WHERE blocks.from_id IN (l1.from_id, l1.to_id) AND blocks.to_id IN (l1.from_id, l1.to_id))`
+
+	if err := pgxscan.Get(ctx, tx, &count, query, userId); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CountUnansweredLikes returns how many users liked userId and haven't been rated back yet.
+func (lr *LikeRepository) CountUnansweredLikes(ctx context.Context, userId int64, minAge int) (count int, err error) {
+	tx, err := lr.DB.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := `SELECT count(*)
+		FROM likes
+		JOIN users ON users.id = likes.from_id AND NOT users.paused AND NOT users.hidden AND NOT users.banned
+			AND users.age >= $2
+		LEFT JOIN likes answers ON answers.from_id = likes.to_id AND answers.to_id = likes.from_id
+		LEFT JOIN blocks ON blocks.from_id IN (likes.from_id, likes.to_id) AND blocks.to_id IN (likes.from_id, likes.to_id)
+		WHERE likes.to_id = $1 AND likes.value AND answers.id IS NULL AND blocks.id IS NULL`
+
+	if err := pgxscan.Get(ctx, tx, &count, query, userId, minAge); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (lr *LikeRepository) CountLikesSince(ctx context.Context, fromId int64, since time.Time) (count int, err error) {
+	tx, err := lr.DB.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := "SELECT count(*) FROM likes WHERE from_id = $1 AND value AND updated_at > $2;"
+	if err := pgxscan.Get(ctx, tx, &count, query, fromId, since); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (lr *LikeRepository) Update(ctx context.Context, like *models.Like) (err error) {
 	tx, err := lr.DB.Begin(ctx)
 	if err != nil {
@@ -99,7 +275,7 @@ func (lr *LikeRepository) Update(ctx context.Context, like *models.Like) (err er
 		}
 	}()
 
-	query := "UPDATE likes SET from_id=$2, to_id=$3, value=$4 WHERE id=$1"
+	query := "UPDATE likes SET from_id=$2, to_id=$3, value=$4, updated_at=now() WHERE id=$1"
 
 	tag, err := tx.Exec(ctx, query,
 		like.Id,
@@ -11,6 +11,7 @@ import (
 	"github.com/pashagolub/pgxmock"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestLikesRepository_Add(t *testing.T) {
@@ -25,8 +26,8 @@ func TestLikesRepository_Add(t *testing.T) {
 	defer pool.Close()
 
 	like := &models.Like{
-		FromId: "id1",
-		ToId:   "id2",
+		FromId: 1,
+		ToId:   2,
 		Value:  true,
 	}
 
@@ -61,8 +62,8 @@ func TestLikesRepository_Add_OnUniqueViolationReturnErrAlreadyExists(t *testing.
 	defer pool.Close()
 
 	like := &models.Like{
-		FromId: "id1",
-		ToId:   "id2",
+		FromId: 1,
+		ToId:   2,
 		Value:  true,
 	}
 
@@ -99,8 +100,8 @@ func TestLikesRepository_Add_OnErrorShouldRollbackTransaction(t *testing.T) {
 	defer pool.Close()
 
 	like := &models.Like{
-		FromId: "id1",
-		ToId:   "id2",
+		FromId: 1,
+		ToId:   2,
 		Value:  true,
 	}
 
@@ -140,8 +141,8 @@ func TestLikeRepository_Get_ShouldReturnRows(t *testing.T) {
 
 	like := &models.Like{
 		Id:     1,
-		FromId: "id1",
-		ToId:   "id2",
+		FromId: 1,
+		ToId:   2,
 		Value:  true,
 	}
 
@@ -179,8 +180,8 @@ func TestLikeRepository_Get_ShouldReturnErrNoRecordIfUserIsNotExists(t *testing.
 	defer pool.Close()
 
 	like := &models.Like{
-		FromId: "id1",
-		ToId:   "id2",
+		FromId: 1,
+		ToId:   2,
 	}
 
 	pool.ExpectBegin()
@@ -214,8 +215,8 @@ func TestLikeRepository_Get_ShouldReturnSameErrorOnFailure(t *testing.T) {
 	defer pool.Close()
 
 	like := &models.Like{
-		FromId: "id1",
-		ToId:   "id2",
+		FromId: 1,
+		ToId:   2,
 	}
 
 	expectedErr := errors.New("some err")
@@ -239,6 +240,265 @@ func TestLikeRepository_Get_ShouldReturnSameErrorOnFailure(t *testing.T) {
 	}
 }
 
+func TestLikeRepository_GetLast_ShouldReturnRows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	like := &models.Like{Id: 1, FromId: 1, ToId: 2, Value: false, CreatedAt: time.Unix(100, 0), UpdatedAt: time.Unix(200, 0)}
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT (.+) FROM likes ").WithArgs(
+		like.FromId,
+	).WillReturnRows(pgxmock.NewRows([]string{"id", "from_id", "to_id", "value", "created_at", "updated_at"}).AddRow(
+		like.Id, like.FromId, like.ToId, like.Value, like.CreatedAt, like.UpdatedAt,
+	))
+	pool.ExpectCommit()
+
+	likes := NewLikeRepository(pool)
+
+	actualLike, err := likes.GetLast(context.Background(), like.FromId)
+	if err != nil {
+		t.Errorf("error was not expected while getting like: %s", err.Error())
+	}
+
+	assert.EqualValues(t, like, actualLike)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestLikeRepository_GetLast_ShouldReturnErrNoRecordIfThereAreNoLikes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT (.+) FROM likes ").WithArgs(
+		int64(1),
+	).WillReturnError(pgx.ErrNoRows)
+	pool.ExpectRollback()
+
+	likes := NewLikeRepository(pool)
+
+	actualLike, err := likes.GetLast(context.Background(), 1)
+	assert.EqualValues(t, models.ErrNoRecord, err)
+	assert.Nil(t, actualLike)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestLikeRepository_GetByUserId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	received := &models.Like{Id: 2, FromId: 3, ToId: 1, Value: true, CreatedAt: time.Unix(200, 0), UpdatedAt: time.Unix(200, 0)}
+	sent := &models.Like{Id: 1, FromId: 1, ToId: 2, CreatedAt: time.Unix(100, 0), UpdatedAt: time.Unix(100, 0)}
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT (.+) FROM likes ").WithArgs(
+		int64(1), 20, 0,
+	).WillReturnRows(pgxmock.NewRows([]string{"id", "from_id", "to_id", "value", "created_at", "updated_at"}).
+		AddRow(received.Id, received.FromId, received.ToId, received.Value, received.CreatedAt, received.UpdatedAt).
+		AddRow(sent.Id, sent.FromId, sent.ToId, sent.Value, sent.CreatedAt, sent.UpdatedAt),
+	)
+	pool.ExpectCommit()
+
+	likes := NewLikeRepository(pool)
+
+	actualLikes, err := likes.GetByUserId(context.Background(), 1, 20, 0)
+	assert.Nil(t, err)
+	assert.EqualValues(t, []*models.Like{received, sent}, actualLikes)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestLikeRepository_GetMatches_ShouldReturnRows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	newer := &models.Like{Id: 2, FromId: 1, ToId: 3, Value: true, CreatedAt: time.Unix(200, 0)}
+	older := &models.Like{Id: 1, FromId: 1, ToId: 2, Value: true, CreatedAt: time.Unix(100, 0)}
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT (.+) FROM likes ").WithArgs(
+		int64(1), 5, 10,
+	).WillReturnRows(pgxmock.NewRows([]string{"id", "from_id", "to_id", "value", "created_at"}).
+		AddRow(newer.Id, newer.FromId, newer.ToId, newer.Value, newer.CreatedAt).
+		AddRow(older.Id, older.FromId, older.ToId, older.Value, older.CreatedAt),
+	)
+	pool.ExpectCommit()
+
+	likes := NewLikeRepository(pool)
+
+	matches, err := likes.GetMatches(context.Background(), 1, 5, 10)
+	if err != nil {
+		t.Errorf("error was not expected while getting matches: %s", err.Error())
+	}
+
+	assert.EqualValues(t, []*models.Like{newer, older}, matches)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestLikeRepository_GetMatches_ShouldReturnSameErrorOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	expectedErr := errors.New("some err")
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT (.+) FROM likes ").WithArgs(
+		int64(1), 5, 0,
+	).WillReturnError(expectedErr)
+	pool.ExpectRollback()
+
+	likes := NewLikeRepository(pool)
+
+	matches, err := likes.GetMatches(context.Background(), 1, 5, 0)
+	assert.True(t, errors.Is(err, expectedErr))
+	assert.Nil(t, matches)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestLikeRepository_CountMatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT count(.+) FROM likes ").WithArgs(
+		int64(1),
+	).WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(3))
+	pool.ExpectCommit()
+
+	likes := NewLikeRepository(pool)
+
+	count, err := likes.CountMatches(context.Background(), 1)
+	if err != nil {
+		t.Errorf("error was not expected while counting matches: %s", err.Error())
+	}
+
+	assert.EqualValues(t, 3, count)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestLikeRepository_CountUnansweredLikes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT count(.+) FROM likes ").WithArgs(
+		int64(1), 18,
+	).WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(2))
+	pool.ExpectCommit()
+
+	likes := NewLikeRepository(pool)
+
+	count, err := likes.CountUnansweredLikes(context.Background(), 1, 18)
+	if err != nil {
+		t.Errorf("error was not expected while counting likes: %s", err.Error())
+	}
+
+	assert.EqualValues(t, 2, count)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestLikeRepository_CountLikesSince(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	since := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT count(.+) FROM likes ").WithArgs(
+		int64(1), since,
+	).WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(3))
+	pool.ExpectCommit()
+
+	likes := NewLikeRepository(pool)
+
+	count, err := likes.CountLikesSince(context.Background(), 1, since)
+	if err != nil {
+		t.Errorf("error was not expected while counting likes: %s", err.Error())
+	}
+
+	assert.EqualValues(t, 3, count)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestUserRepository_Update(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -252,8 +512,8 @@ func TestUserRepository_Update(t *testing.T) {
 
 	like := &models.Like{
 		Id:     1,
-		FromId: "id1",
-		ToId:   "id2",
+		FromId: 1,
+		ToId:   2,
 		Value:  true,
 	}
 
@@ -290,8 +550,8 @@ func TestUserRepository_Update_ShouldReturnErrNoRecordOnEmptyRawsAffected(t *tes
 
 	like := &models.Like{
 		Id:     1,
-		FromId: "id1",
-		ToId:   "id2",
+		FromId: 1,
+		ToId:   2,
 		Value:  true,
 	}
 
@@ -330,8 +590,8 @@ func TestUserRepository_Update_ShouldReturnErrAlreadyExistsOnUniqueViolation(t *
 
 	like := &models.Like{
 		Id:     1,
-		FromId: "id1",
-		ToId:   "id2",
+		FromId: 1,
+		ToId:   2,
 		Value:  true,
 	}
 
@@ -372,8 +632,8 @@ func TestUserRepository_Update_ShouldReturnSameErrorOnFailure(t *testing.T) {
 
 	like := &models.Like{
 		Id:     1,
-		FromId: "id1",
-		ToId:   "id2",
+		FromId: 1,
+		ToId:   2,
 		Value:  true,
 	}
 
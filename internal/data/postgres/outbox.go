@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+type OutboxRepository struct {
+	DB PgxPoolIface
+}
+
+var _ internal.OutboxRepository = &OutboxRepository{}
+
+func NewOutboxRepository(DB PgxPoolIface) internal.OutboxRepository {
+	return &OutboxRepository{DB: DB}
+}
+
+func (or *OutboxRepository) Add(ctx context.Context, message *models.OutboxMessage) (err error) {
+	tx, err := or.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := "INSERT INTO outbox (chat_id, payload) VALUES ($1, $2) RETURNING id;"
+	if err = tx.QueryRow(ctx, query, message.ChatId, message.Payload).Scan(&message.Id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (or *OutboxRepository) Delete(ctx context.Context, id int64) (err error) {
+	tx, err := or.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	_, err = tx.Exec(ctx, "DELETE FROM outbox WHERE id = $1;", id)
+
+	return err
+}
+
+func (or *OutboxRepository) GetAll(ctx context.Context) (messages []*models.OutboxMessage, err error) {
+	tx, err := or.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := "SELECT id, chat_id, payload, created_at FROM outbox ORDER BY id;"
+
+	messages = make([]*models.OutboxMessage, 0)
+	if err := pgxscan.Select(ctx, tx, &messages, query); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/golang/mock/gomock"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestOutboxRepository_Add(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	message := &models.OutboxMessage{ChatId: 1, Payload: []byte(`{"type":"message"}`)}
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^INSERT INTO outbox ").WithArgs(
+		message.ChatId, message.Payload,
+	).WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(5)))
+	pool.ExpectCommit()
+
+	outbox := NewOutboxRepository(pool)
+
+	err = outbox.Add(context.Background(), message)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 5, message.Id)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestOutboxRepository_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("^DELETE FROM outbox ").WithArgs(
+		int64(5),
+	).WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	pool.ExpectCommit()
+
+	outbox := NewOutboxRepository(pool)
+
+	if err := outbox.Delete(context.Background(), 5); err != nil {
+		t.Errorf("error was not expected while deleting outbox message: %s", err.Error())
+	}
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestOutboxRepository_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	expectedMessages := []*models.OutboxMessage{
+		{Id: 1, ChatId: 2, Payload: []byte(`{"type":"message"}`), CreatedAt: time.Unix(1, 0)},
+	}
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT (.+) FROM outbox ").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "chat_id", "payload", "created_at"}).
+			AddRow(expectedMessages[0].Id, expectedMessages[0].ChatId, expectedMessages[0].Payload, expectedMessages[0].CreatedAt),
+		)
+	pool.ExpectCommit()
+
+	outbox := NewOutboxRepository(pool)
+
+	actualMessages, err := outbox.GetAll(context.Background())
+	assert.Nil(t, err)
+	assert.EqualValues(t, expectedMessages, actualMessages)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+)
+
+type PhotoRepository struct {
+	DB PgxPoolIface
+}
+
+var _ internal.PhotosRepository = &PhotoRepository{}
+
+func NewPhotoRepository(DB PgxPoolIface) internal.PhotosRepository {
+	return &PhotoRepository{DB: DB}
+}
+
+func (pr *PhotoRepository) GetByUserId(ctx context.Context, userId int64) (photos []*models.Photo, err error) {
+	tx, err := pr.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := "SELECT id, user_id, file_id, position, reviewed FROM user_photos WHERE user_id=$1 ORDER BY position;"
+
+	photos = make([]*models.Photo, 0)
+	if err := pgxscan.Select(ctx, tx, &photos, query, userId); err != nil {
+		return nil, err
+	}
+
+	return photos, nil
+}
+
+// ReplaceByUserId stores fileIds as the only photos of the user, in the given order.
+func (pr *PhotoRepository) ReplaceByUserId(ctx context.Context, userId int64, fileIds []string) (err error) {
+	tx, err := pr.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	reviewedIds := make([]string, 0)
+	if err = pgxscan.Select(ctx, tx, &reviewedIds, "SELECT file_id FROM user_photos WHERE user_id=$1 AND reviewed;", userId); err != nil {
+		return err
+	}
+
+	reviewed := make(map[string]bool, len(reviewedIds))
+	for _, fileId := range reviewedIds {
+		reviewed[fileId] = true
+	}
+
+	if _, err = tx.Exec(ctx, "DELETE FROM user_photos WHERE user_id=$1;", userId); err != nil {
+		return err
+	}
+
+	query := "INSERT INTO user_photos (user_id, file_id, position, reviewed) VALUES ($1, $2, $3, $4);"
+	for position, fileId := range fileIds {
+		if _, err = tx.Exec(ctx, query, userId, fileId, position, reviewed[fileId]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetNextUnreviewedUserId returns the user with the oldest photo which hasn't been checked by a moderator.
+func (pr *PhotoRepository) GetNextUnreviewedUserId(ctx context.Context) (userId int64, err error) {
+	tx, err := pr.DB.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := "SELECT user_photos.user_id FROM user_photos" +
+		" JOIN users ON users.id = user_photos.user_id AND NOT users.banned" +
+		" WHERE NOT user_photos.reviewed" +
+		" ORDER BY user_photos.id" +
+		" LIMIT 1;"
+
+	if err := pgxscan.Get(ctx, tx, &userId, query); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, models.ErrNoRecord
+		}
+
+		return 0, err
+	}
+
+	return userId, nil
+}
+
+func (pr *PhotoRepository) SetReviewedByUserId(ctx context.Context, userId int64) (err error) {
+	tx, err := pr.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	_, err = tx.Exec(ctx, "UPDATE user_photos SET reviewed=true WHERE user_id=$1;", userId)
+
+	return err
+}
@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/golang/mock/gomock"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPhotoRepository_GetByUserId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	expectedPhotos := []*models.Photo{
+		{Id: 1, UserId: 1, FileId: "first", Position: 0, Reviewed: true},
+		{Id: 2, UserId: 1, FileId: "second", Position: 1},
+	}
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT (.+) FROM user_photos ").WithArgs(
+		int64(1),
+	).WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "file_id", "position", "reviewed"}).
+		AddRow(expectedPhotos[0].Id, expectedPhotos[0].UserId, expectedPhotos[0].FileId, expectedPhotos[0].Position, expectedPhotos[0].Reviewed).
+		AddRow(expectedPhotos[1].Id, expectedPhotos[1].UserId, expectedPhotos[1].FileId, expectedPhotos[1].Position, expectedPhotos[1].Reviewed),
+	)
+	pool.ExpectCommit()
+
+	photos := NewPhotoRepository(pool)
+
+	actualPhotos, err := photos.GetByUserId(context.Background(), 1)
+	if err != nil {
+		t.Errorf("error was not expected while getting photos: %s", err.Error())
+	}
+
+	assert.EqualValues(t, expectedPhotos, actualPhotos)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPhotoRepository_ReplaceByUserId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT file_id FROM user_photos ").WithArgs(int64(1)).
+		WillReturnRows(pgxmock.NewRows([]string{"file_id"}).AddRow("first"))
+	pool.ExpectExec("DELETE FROM user_photos ").WithArgs(int64(1)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	pool.ExpectExec("INSERT INTO user_photos ").WithArgs(int64(1), "second", 0, false).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectExec("INSERT INTO user_photos ").WithArgs(int64(1), "first", 1, true).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectCommit()
+
+	photos := NewPhotoRepository(pool)
+
+	if err := photos.ReplaceByUserId(context.Background(), 1, []string{"second", "first"}); err != nil {
+		t.Errorf("error was not expected while replacing photos: %s", err.Error())
+	}
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPhotoRepository_ReplaceByUserId_ShouldRollbackOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	expectedErr := errors.New("some err")
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT file_id FROM user_photos ").WithArgs(int64(1)).
+		WillReturnRows(pgxmock.NewRows([]string{"file_id"}))
+	pool.ExpectExec("DELETE FROM user_photos ").WithArgs(int64(1)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	pool.ExpectExec("INSERT INTO user_photos ").WithArgs(int64(1), "first", 0, false).
+		WillReturnError(expectedErr)
+	pool.ExpectRollback()
+
+	photos := NewPhotoRepository(pool)
+
+	err = photos.ReplaceByUserId(context.Background(), 1, []string{"first"})
+	assert.True(t, errors.Is(err, expectedErr))
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPhotoRepository_GetNextUnreviewedUserId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT user_photos.user_id FROM user_photos ").
+		WillReturnRows(pgxmock.NewRows([]string{"user_id"}).AddRow(int64(2)))
+	pool.ExpectCommit()
+
+	photos := NewPhotoRepository(pool)
+
+	userId, err := photos.GetNextUnreviewedUserId(context.Background())
+	assert.Nil(t, err)
+	assert.EqualValues(t, 2, userId)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPhotoRepository_GetNextUnreviewedUserId_ShouldReturnErrNoRecordNoRows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT user_photos.user_id FROM user_photos ").
+		WillReturnRows(pgxmock.NewRows([]string{"user_id"}))
+	pool.ExpectRollback()
+
+	photos := NewPhotoRepository(pool)
+
+	_, err = photos.GetNextUnreviewedUserId(context.Background())
+	assert.True(t, errors.Is(err, models.ErrNoRecord))
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
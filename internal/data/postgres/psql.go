@@ -19,5 +19,6 @@ var _ PgxPoolIface = &pgxpool.Pool{}
 
 type PgxPoolIface interface {
 	Begin(context.Context) (pgx.Tx, error)
+	Ping(context.Context) error
 	Close()
 }
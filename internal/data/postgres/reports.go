@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+)
+
+type ReportRepository struct {
+	DB PgxPoolIface
+}
+
+var _ internal.ReportsRepository = &ReportRepository{}
+
+func NewReportRepository(DB PgxPoolIface) internal.ReportsRepository {
+	return &ReportRepository{DB: DB}
+}
+
+func (rr *ReportRepository) Add(ctx context.Context, report *models.Report) (err error) {
+	tx, err := rr.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := "INSERT INTO reports (from_id, to_id, reason) VALUES ($1, $2, $3);"
+	if _, err = tx.Exec(ctx, query, report.FromId, report.ToId, report.Reason); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CountReporters counts users rather than reports, so a single user can't hide anybody by reporting many times.
+func (rr *ReportRepository) CountReporters(ctx context.Context, toId int64) (count int, err error) {
+	tx, err := rr.DB.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := "SELECT count(DISTINCT from_id) FROM reports WHERE to_id = $1 AND NOT resolved;"
+	if err := pgxscan.Get(ctx, tx, &count, query, toId); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (rr *ReportRepository) GetNextReportedId(ctx context.Context) (toId int64, err error) {
+	tx, err := rr.DB.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := "SELECT to_id FROM reports WHERE NOT resolved ORDER BY created_at, id LIMIT 1;"
+	if err := pgxscan.Get(ctx, tx, &toId, query); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, models.ErrNoRecord
+		}
+
+		return 0, err
+	}
+
+	return toId, nil
+}
+
+func (rr *ReportRepository) GetUnresolvedByUserId(ctx context.Context, toId int64) (reports []*models.Report, err error) {
+	tx, err := rr.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := "SELECT id, from_id, to_id, reason, created_at, resolved FROM reports WHERE to_id = $1 AND NOT resolved ORDER BY created_at, id;"
+
+	reports = make([]*models.Report, 0)
+	if err := pgxscan.Select(ctx, tx, &reports, query, toId); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+func (rr *ReportRepository) ResolveByUserId(ctx context.Context, toId int64) (err error) {
+	tx, err := rr.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	_, err = tx.Exec(ctx, "UPDATE reports SET resolved=true WHERE to_id = $1 AND NOT resolved;", toId)
+
+	return err
+}
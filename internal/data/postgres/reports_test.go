@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/golang/mock/gomock"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestReportRepository_Add(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	report := &models.Report{FromId: 1, ToId: 2, Reason: "Спам"}
+
+	pool.ExpectBegin()
+	pool.ExpectExec("INSERT INTO reports ").WithArgs(
+		report.FromId, report.ToId, report.Reason,
+	).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectCommit()
+
+	reports := NewReportRepository(pool)
+
+	if err := reports.Add(context.Background(), report); err != nil {
+		t.Errorf("error was not expected while adding report: %s", err.Error())
+	}
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestReportRepository_CountReporters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT count(.+) FROM reports ").WithArgs(
+		int64(2),
+	).WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(3))
+	pool.ExpectCommit()
+
+	reports := NewReportRepository(pool)
+
+	count, err := reports.CountReporters(context.Background(), 2)
+	if err != nil {
+		t.Errorf("error was not expected while counting reporters: %s", err.Error())
+	}
+
+	assert.EqualValues(t, 3, count)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestReportRepository_GetUnresolvedByUserId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	expectedReports := []*models.Report{
+		{Id: 1, FromId: 1, ToId: 2, Reason: "Спам", CreatedAt: time.Unix(1, 0)},
+	}
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT (.+) FROM reports ").WithArgs(
+		int64(2),
+	).WillReturnRows(pgxmock.NewRows([]string{"id", "from_id", "to_id", "reason", "created_at", "resolved"}).
+		AddRow(expectedReports[0].Id, expectedReports[0].FromId, expectedReports[0].ToId, expectedReports[0].Reason,
+			expectedReports[0].CreatedAt, expectedReports[0].Resolved),
+	)
+	pool.ExpectCommit()
+
+	reports := NewReportRepository(pool)
+
+	actualReports, err := reports.GetUnresolvedByUserId(context.Background(), 2)
+	assert.Nil(t, err)
+	assert.EqualValues(t, expectedReports, actualReports)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestReportRepository_GetNextReportedId_ShouldReturnErrNoRecordNoRows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT to_id FROM reports ").
+		WillReturnRows(pgxmock.NewRows([]string{"to_id"}))
+	pool.ExpectRollback()
+
+	reports := NewReportRepository(pool)
+
+	_, err = reports.GetNextReportedId(context.Background())
+	assert.True(t, errors.Is(err, models.ErrNoRecord))
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
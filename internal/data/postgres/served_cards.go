@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+	"time"
+)
+
+type ServedCardRepository struct {
+	DB PgxPoolIface
+}
+
+var _ internal.ServedCardsRepository = &ServedCardRepository{}
+
+func NewServedCardRepository(DB PgxPoolIface) internal.ServedCardsRepository {
+	return &ServedCardRepository{DB: DB}
+}
+
+func (sr *ServedCardRepository) Add(ctx context.Context, userId, toId int64) (err error) {
+	tx, err := sr.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := "INSERT INTO served_cards (user_id, to_id) VALUES ($1, $2) " +
+		"ON CONFLICT (user_id, to_id) DO UPDATE SET served_at = now();"
+	if _, err = tx.Exec(ctx, query, userId, toId); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (sr *ServedCardRepository) Delete(ctx context.Context, userId, toId int64) (err error) {
+	tx, err := sr.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := "DELETE FROM served_cards WHERE user_id = $1 AND to_id = $2;"
+	tag, err := tx.Exec(ctx, query, userId, toId)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrNoRecord
+	}
+
+	return nil
+}
+
+func (sr *ServedCardRepository) GetServedAt(ctx context.Context, userId, toId int64) (_ time.Time, err error) {
+	tx, err := sr.DB.Begin(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	// scany scans time.Time as a struct with fields, so the column needs a struct of its own
+	var card struct {
+		ServedAt time.Time `db:"served_at"`
+	}
+
+	query := "SELECT served_at FROM served_cards WHERE user_id = $1 AND to_id = $2;"
+	if err := pgxscan.Get(ctx, tx, &card, query, userId, toId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Time{}, models.ErrNoRecord
+		}
+
+		return time.Time{}, err
+	}
+
+	return card.ServedAt, nil
+}
+
+func (sr *ServedCardRepository) DeleteExpired(ctx context.Context, ttl time.Duration) (_ int64, err error) {
+	tx, err := sr.DB.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := "DELETE FROM served_cards WHERE served_at < now() - $1 * interval '1 second';"
+	tag, err := tx.Exec(ctx, query, ttl.Seconds())
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
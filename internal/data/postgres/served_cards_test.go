@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/golang/mock/gomock"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestServedCardRepository_Add(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("^INSERT INTO served_cards ").WithArgs(
+		int64(1), int64(2),
+	).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectCommit()
+
+	servedCards := NewServedCardRepository(pool)
+
+	if err := servedCards.Add(context.Background(), 1, 2); err != nil {
+		t.Errorf("error was not expected while adding served card: %s", err.Error())
+	}
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestServedCardRepository_DeleteExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("^DELETE FROM served_cards WHERE served_at ").WithArgs(
+		time.Hour.Seconds(),
+	).WillReturnResult(pgxmock.NewResult("DELETE", 3))
+	pool.ExpectCommit()
+
+	servedCards := NewServedCardRepository(pool)
+
+	deleted, err := servedCards.DeleteExpired(context.Background(), time.Hour)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 3, deleted)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestServedCardRepository_GetServedAt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	servedAt := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT served_at FROM served_cards ").WithArgs(
+		int64(1), int64(2),
+	).WillReturnRows(pgxmock.NewRows([]string{"served_at"}).AddRow(servedAt))
+	pool.ExpectCommit()
+
+	servedCards := NewServedCardRepository(pool)
+
+	actual, err := servedCards.GetServedAt(context.Background(), 1, 2)
+	assert.Nil(t, err)
+	assert.EqualValues(t, servedAt, actual)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestServedCardRepository_GetServedAt_ShouldReturnErrNoRecordNoRows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT served_at FROM served_cards ").WithArgs(
+		int64(1), int64(2),
+	).WillReturnRows(pgxmock.NewRows([]string{"served_at"}))
+	pool.ExpectRollback()
+
+	servedCards := NewServedCardRepository(pool)
+
+	_, err = servedCards.GetServedAt(context.Background(), 1, 2)
+	assert.True(t, errors.Is(err, models.ErrNoRecord))
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestServedCardRepository_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("^DELETE FROM served_cards WHERE user_id ").WithArgs(
+		int64(1), int64(2),
+	).WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	pool.ExpectCommit()
+
+	servedCards := NewServedCardRepository(pool)
+
+	if err := servedCards.Delete(context.Background(), 1, 2); err != nil {
+		t.Errorf("error was not expected while deleting served card: %s", err.Error())
+	}
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestServedCardRepository_Delete_ShouldReturnErrNoRecordIfNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("^DELETE FROM served_cards WHERE user_id ").WithArgs(
+		int64(1), int64(2),
+	).WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	pool.ExpectRollback()
+
+	servedCards := NewServedCardRepository(pool)
+
+	err = servedCards.Delete(context.Background(), 1, 2)
+	assert.True(t, errors.Is(err, models.ErrNoRecord))
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+type StatsRepository struct {
+	DB PgxPoolIface
+}
+
+var _ internal.StatsRepository = &StatsRepository{}
+
+func NewStatsRepository(DB PgxPoolIface) internal.StatsRepository {
+	return &StatsRepository{DB: DB}
+}
+
+func (sr *StatsRepository) Get(ctx context.Context) (stats *models.Stats, err error) {
+	tx, err := sr.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := `SELECT
+			(SELECT count(*) FROM users) AS users,
+			(SELECT count(*) FROM users WHERE started AND NOT paused AND NOT hidden AND NOT banned) AS active,
+			(SELECT count(*) FROM users WHERE paused) AS paused,
+			(SELECT count(*) FROM users WHERE hidden) AS hidden,
+			(SELECT count(*) FROM users WHERE banned) AS banned,
+			(SELECT count(*) FROM likes WHERE value) AS likes,
+			(SELECT count(*) FROM likes l1 JOIN likes l2 ON l2.from_id = l1.to_id AND l2.to_id = l1.from_id
+				WHERE l1.from_id < l1.to_id AND l1.value AND l2.value) AS matches,
+			(SELECT count(*) FROM reports WHERE NOT resolved) AS unresolved_reports;`
+
+	stats = &models.Stats{}
+	if err := pgxscan.Get(ctx, tx, stats, query); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
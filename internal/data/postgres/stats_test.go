@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/golang/mock/gomock"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStatsRepository_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	expectedStats := &models.Stats{Users: 10, Active: 6, Paused: 1, Hidden: 2, Banned: 3, Likes: 20, Matches: 4, UnresolvedReports: 5}
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT (.+) FROM users").
+		WillReturnRows(pgxmock.NewRows([]string{"users", "active", "paused", "hidden", "banned", "likes", "matches", "unresolved_reports"}).
+			AddRow(10, 6, 1, 2, 3, 20, 4, 5))
+	pool.ExpectCommit()
+
+	stats := NewStatsRepository(pool)
+
+	actualStats, err := stats.Get(context.Background())
+	assert.Nil(t, err)
+	assert.EqualValues(t, expectedStats, actualStats)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestStatsRepository_Get_ShouldReturnSameErrorOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	expectedErr := errors.New("some err")
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT (.+) FROM users").WillReturnError(expectedErr)
+	pool.ExpectRollback()
+
+	stats := NewStatsRepository(pool)
+
+	actualStats, err := stats.Get(context.Background())
+	assert.True(t, errors.Is(err, expectedErr))
+	assert.Nil(t, actualStats)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -41,12 +41,15 @@ func (ur *UserRepository) Add(ctx context.Context, user *models.User) error {
 		stage = -1
 	}
 
-	query := "INSERT INTO users (id, name, sex, age, description, city, image, started, stage, chat_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);"
+	query := "INSERT INTO users (id, username, name, gender, interested_in, age, description, city, image, started, stage, chat_id, min_age, max_age, city_only, editing, paused, reported_id)" +
+		" VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18);"
 
 	if _, err = tx.Exec(ctx, query,
 		user.Id,
+		user.Username,
 		user.Name,
-		user.Sex,
+		user.Gender,
+		user.InterestedIn,
 		user.Age,
 		user.Description,
 		user.City,
@@ -54,6 +57,12 @@ func (ur *UserRepository) Add(ctx context.Context, user *models.User) error {
 		user.Started,
 		stage,
 		user.ChatId,
+		user.MinAge,
+		user.MaxAge,
+		user.CityOnly,
+		user.Editing,
+		user.Paused,
+		user.ReportedId,
 	); err != nil {
 		pgErr := &pgconn.PgError{}
 
@@ -67,7 +76,7 @@ func (ur *UserRepository) Add(ctx context.Context, user *models.User) error {
 	return nil
 }
 
-func (ur *UserRepository) GetByUserId(ctx context.Context, userId string) (user *models.User, err error) {
+func (ur *UserRepository) GetByUserId(ctx context.Context, userId int64) (user *models.User, err error) {
 	tx, err := ur.DB.Begin(ctx)
 	if err != nil {
 		return nil, err
@@ -83,7 +92,7 @@ func (ur *UserRepository) GetByUserId(ctx context.Context, userId string) (user
 	}()
 
 	user = &models.User{}
-	query := "SELECT id, name, sex, age, description, city, image, started, stage, chat_id FROM users WHERE id=$1;"
+	query := "SELECT id, username, name, gender, interested_in, age, description, city, image, started, stage, chat_id, min_age, max_age, city_only, editing, paused, hidden, banned, reported_id FROM users WHERE id=$1;"
 
 	if err := pgxscan.Get(ctx, tx,
 		user,
@@ -101,6 +110,36 @@ func (ur *UserRepository) GetByUserId(ctx context.Context, userId string) (user
 	return user, nil
 }
 
+// Search returns users whose id is query or whose name, username or city contains it. An empty query matches everyone.
+func (ur *UserRepository) Search(ctx context.Context, query string, limit, offset int) (users []*models.User, err error) {
+	tx, err := ur.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	sqlQuery := `SELECT id, username, name, gender, interested_in, age, description, city, image, started, stage, chat_id, min_age, max_age, city_only, editing, paused, hidden, banned, reported_id
+		FROM users
+		WHERE $1 = '' OR id::text = $1 OR name ILIKE '%' || $1 || '%' OR username ILIKE '%' || $1 || '%' OR city ILIKE '%' || $1 || '%'
+		ORDER BY id
+		LIMIT $2 OFFSET $3;`
+
+	users = make([]*models.User, 0, limit)
+	if err := pgxscan.Select(ctx, tx, &users, sqlQuery, query, limit, offset); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
 func (ur *UserRepository) UpdateByUserId(ctx context.Context, user *models.User) error {
 	tx, err := ur.DB.Begin(ctx)
 	if err != nil {
@@ -116,12 +155,15 @@ func (ur *UserRepository) UpdateByUserId(ctx context.Context, user *models.User)
 		}
 	}()
 
-	query := "UPDATE users SET name=$2, sex=$3, age=$4, description=$5, city=$6, image=$7, started=$8, stage=$9, chat_id=$10 WHERE id=$1;"
+	// The username is written by UpdateUsername only, so a stale copy of the user can't revert it
+	query := "UPDATE users SET name=$2, gender=$3, interested_in=$4, age=$5, description=$6, city=$7, image=$8, started=$9," +
+		" stage=$10, chat_id=$11, min_age=$12, max_age=$13, city_only=$14, editing=$15, paused=$16, reported_id=$17 WHERE id=$1;"
 
 	tag, err := tx.Exec(ctx, query,
 		user.Id,
 		user.Name,
-		user.Sex,
+		user.Gender,
+		user.InterestedIn,
 		user.Age,
 		user.Description,
 		user.City,
@@ -129,6 +171,12 @@ func (ur *UserRepository) UpdateByUserId(ctx context.Context, user *models.User)
 		user.Started,
 		user.Stage,
 		user.ChatId,
+		user.MinAge,
+		user.MaxAge,
+		user.CityOnly,
+		user.Editing,
+		user.Paused,
+		user.ReportedId,
 	)
 	if err != nil {
 		return err
@@ -142,7 +190,8 @@ func (ur *UserRepository) UpdateByUserId(ctx context.Context, user *models.User)
 	return nil
 }
 
-func (ur *UserRepository) DeleteByUserId(ctx context.Context, userId string) (err error) {
+// DeleteByUserId erases the user together with the likes and photos, so the user also disappears from matches of others.
+func (ur *UserRepository) DeleteByUserId(ctx context.Context, userId int64) (err error) {
 	tx, err := ur.DB.Begin(ctx)
 	if err != nil {
 		return err
@@ -157,6 +206,14 @@ func (ur *UserRepository) DeleteByUserId(ctx context.Context, userId string) (er
 		}
 	}()
 
+	if _, err = tx.Exec(ctx, "DELETE FROM likes WHERE from_id = $1 OR to_id = $1;", userId); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, "DELETE FROM user_photos WHERE user_id = $1;", userId); err != nil {
+		return err
+	}
+
 	query := "DELETE FROM users WHERE id = $1;"
 	tag, err := tx.Exec(ctx, query, userId)
 	if err != nil {
@@ -170,7 +227,7 @@ func (ur *UserRepository) DeleteByUserId(ctx context.Context, userId string) (er
 	return nil
 }
 
-func (ur *UserRepository) GetNextUser(ctx context.Context, userId string, sex bool) (*models.User, error) {
+func (ur *UserRepository) GetNextUser(ctx context.Context, user *models.User) (*models.User, error) {
 	tx, err := ur.DB.Begin(ctx)
 	if err != nil {
 		return nil, err
@@ -185,8 +242,8 @@ func (ur *UserRepository) GetNextUser(ctx context.Context, userId string, sex bo
 		}
 	}()
 
-	user := &models.User{}
-	query := "SELECT id, name, sex, age, description, city, image, started, stage, chat_id FROM users" +
+	nextUser := &models.User{}
+	query := "SELECT id, username, name, gender, interested_in, age, description, city, image, started, stage, chat_id, min_age, max_age, city_only, editing, paused, hidden, banned, reported_id FROM users" +
 		" WHERE id IN (" +
 		" SELECT user_ids.id as user_id FROM likes as likes2 " +
 		" 	RIGHT JOIN ( " +
@@ -195,20 +252,34 @@ func (ur *UserRepository) GetNextUser(ctx context.Context, userId string, sex bo
 		"				SELECT * FROM likes WHERE likes.from_id != $1" +
 		"			) likes1 ON users.id = likes1.to_id" +
 		"				 WHERE users.id != $1 " +
+		"						AND NOT users.paused AND NOT users.hidden AND NOT users.banned" +
+		"						AND users.id NOT IN (" +
+		"							SELECT to_id as id FROM blocks WHERE from_id = $1" +
+		"							UNION SELECT from_id as id FROM blocks WHERE to_id = $1" +
+		"						) " +
 		"						AND users.id NOT IN (" +
 		"							SELECT to_id as id FROM likes WHERE from_id = $1" +
 		"						) " +
-		"						AND users.sex != $2" +
+		"						AND (users.gender & $3) != 0" +
+		"						AND (users.interested_in & $2) != 0" +
+		"						AND ($4 = 0 OR users.age >= $4)" +
+		"						AND ($5 = 0 OR users.age <= $5)" +
+		"						AND (NOT $6 OR lower(users.city) = lower($7))" +
 		"	) user_ids ON likes2.from_id = user_ids.id AND likes2.to_id = $1 " +
 		"	ORDER BY likes2.value DESC NULLS LAST" +
 		"	LIMIT 1" +
 		");"
 
 	err = pgxscan.Get(ctx, tx,
-		user,
+		nextUser,
 		query,
-		userId,
-		sex,
+		user.Id,
+		user.Gender,
+		user.InterestedIn,
+		user.MinAge,
+		user.MaxAge,
+		user.CityOnly,
+		user.City,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -218,7 +289,130 @@ func (ur *UserRepository) GetNextUser(ctx context.Context, userId string, sex bo
 		return nil, err
 	}
 
-	return user, nil
+	return nextUser, nil
+}
+
+// GetNextLiker returns the earliest user who liked userId and hasn't been rated back yet.
+func (ur *UserRepository) GetNextLiker(ctx context.Context, userId int64, minAge int) (liker *models.User, err error) {
+	tx, err := ur.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	liker = &models.User{}
+	query := "SELECT users.id, users.username, users.name, users.gender, users.interested_in, users.age, users.description," +
+		" users.city, users.image, users.started, users.stage, users.chat_id, users.min_age, users.max_age, users.city_only, users.editing, users.paused," +
+		" users.hidden, users.banned, users.reported_id FROM users" +
+		"	JOIN likes ON likes.from_id = users.id AND likes.to_id = $1 AND likes.value" +
+		"	LEFT JOIN likes answers ON answers.from_id = $1 AND answers.to_id = users.id" +
+		"	LEFT JOIN blocks ON blocks.from_id IN ($1, users.id) AND blocks.to_id IN ($1, users.id)" +
+		" WHERE answers.id IS NULL AND blocks.id IS NULL AND NOT users.paused AND NOT users.hidden AND NOT users.banned" +
+		" AND users.age >= $2" +
+		" ORDER BY likes.created_at, likes.id" +
+		" LIMIT 1;"
+
+	if err := pgxscan.Get(ctx, tx, liker, query, userId, minAge); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNoRecord
+		}
+
+		return nil, err
+	}
+
+	return liker, nil
+}
+
+// SetBanned bans or unbans the user. It isn't done by UpdateByUserId, so a ban can't be overwritten by the user.
+func (ur *UserRepository) SetBanned(ctx context.Context, userId int64, banned bool) (err error) {
+	tx, err := ur.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	tag, err := tx.Exec(ctx, "UPDATE users SET banned=$2 WHERE id=$1;", userId, banned)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrNoRecord
+	}
+
+	return nil
+}
+
+// UpdateUsername changes only the username, users may change it in Telegram at any time.
+func (ur *UserRepository) UpdateUsername(ctx context.Context, userId int64, username string) (err error) {
+	tx, err := ur.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	tag, err := tx.Exec(ctx, "UPDATE users SET username=$2 WHERE id=$1;", userId, username)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrNoRecord
+	}
+
+	return nil
+}
+
+// SetHidden hides the user from others or shows them again, the rest of the profile is left as is.
+func (ur *UserRepository) SetHidden(ctx context.Context, userId int64, hidden bool) (err error) {
+	tx, err := ur.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(ctx)
+		default:
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	tag, err := tx.Exec(ctx, "UPDATE users SET hidden=$2 WHERE id=$1;", userId, hidden)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrNoRecord
+	}
+
+	return nil
 }
 
 func (ur *UserRepository) DeleteAll(ctx context.Context) (err error) {
@@ -29,8 +29,10 @@ func TestUserRepository_Add(t *testing.T) {
 	pool.ExpectBegin()
 	pool.ExpectExec("INSERT INTO users ").WithArgs(
 		user.Id,
+		user.Username,
 		user.Name,
-		user.Sex,
+		user.Gender,
+		user.InterestedIn,
 		user.Age,
 		user.Description,
 		user.City,
@@ -38,6 +40,12 @@ func TestUserRepository_Add(t *testing.T) {
 		user.Started,
 		-1,
 		user.ChatId,
+		user.MinAge,
+		user.MaxAge,
+		user.CityOnly,
+		user.Editing,
+		user.Paused,
+		user.ReportedId,
 	).WillReturnResult(pgxmock.NewResult("INSERT", 1))
 	pool.ExpectCommit()
 
@@ -68,8 +76,10 @@ func TestUserRepository_Add_ShouldReturnErrorAlreadyExistsOnUniqueViolationFailu
 	pool.ExpectBegin()
 	pool.ExpectExec("INSERT INTO users ").WithArgs(
 		user.Id,
+		user.Username,
 		user.Name,
-		user.Sex,
+		user.Gender,
+		user.InterestedIn,
 		user.Age,
 		user.Description,
 		user.City,
@@ -77,6 +87,12 @@ func TestUserRepository_Add_ShouldReturnErrorAlreadyExistsOnUniqueViolationFailu
 		user.Started,
 		-1,
 		user.ChatId,
+		user.MinAge,
+		user.MaxAge,
+		user.CityOnly,
+		user.Editing,
+		user.Paused,
+		user.ReportedId,
 	).WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
 	pool.ExpectRollback()
 
@@ -111,8 +127,10 @@ func TestUserRepository_Add_ShouldReturnSameErrorOnFailure(t *testing.T) {
 	pool.ExpectBegin()
 	pool.ExpectExec("INSERT INTO users ").WithArgs(
 		user.Id,
+		user.Username,
 		user.Name,
-		user.Sex,
+		user.Gender,
+		user.InterestedIn,
 		user.Age,
 		user.Description,
 		user.City,
@@ -120,6 +138,12 @@ func TestUserRepository_Add_ShouldReturnSameErrorOnFailure(t *testing.T) {
 		user.Started,
 		-1,
 		user.ChatId,
+		user.MinAge,
+		user.MaxAge,
+		user.CityOnly,
+		user.Editing,
+		user.Paused,
+		user.ReportedId,
 	).WillReturnError(someError)
 	pool.ExpectRollback()
 
@@ -151,7 +175,8 @@ func TestUserRepository_UpdateByUserId(t *testing.T) {
 	pool.ExpectExec("UPDATE users ").WithArgs(
 		user.Id,
 		user.Name,
-		user.Sex,
+		user.Gender,
+		user.InterestedIn,
 		user.Age,
 		user.Description,
 		user.City,
@@ -159,6 +184,12 @@ func TestUserRepository_UpdateByUserId(t *testing.T) {
 		user.Started,
 		user.Stage,
 		user.ChatId,
+		user.MinAge,
+		user.MaxAge,
+		user.CityOnly,
+		user.Editing,
+		user.Paused,
+		user.ReportedId,
 	).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 	pool.ExpectCommit()
 
@@ -190,7 +221,8 @@ func TestUserRepository_UpdateByUserId_ShouldReturnErrNoRecordOnEmptyRawsAffecte
 	pool.ExpectExec("UPDATE users ").WithArgs(
 		user.Id,
 		user.Name,
-		user.Sex,
+		user.Gender,
+		user.InterestedIn,
 		user.Age,
 		user.Description,
 		user.City,
@@ -198,6 +230,12 @@ func TestUserRepository_UpdateByUserId_ShouldReturnErrNoRecordOnEmptyRawsAffecte
 		user.Started,
 		user.Stage,
 		user.ChatId,
+		user.MinAge,
+		user.MaxAge,
+		user.CityOnly,
+		user.Editing,
+		user.Paused,
+		user.ReportedId,
 	).WillReturnResult(pgxmock.NewResult("UPDATE", 0))
 	pool.ExpectRollback()
 
@@ -232,7 +270,8 @@ func TestUserRepository_UpdateByUserId_ShouldReturnSameErrorOnFailure(t *testing
 	pool.ExpectExec("UPDATE users ").WithArgs(
 		user.Id,
 		user.Name,
-		user.Sex,
+		user.Gender,
+		user.InterestedIn,
 		user.Age,
 		user.Description,
 		user.City,
@@ -240,6 +279,12 @@ func TestUserRepository_UpdateByUserId_ShouldReturnSameErrorOnFailure(t *testing
 		user.Started,
 		user.Stage,
 		user.ChatId,
+		user.MinAge,
+		user.MaxAge,
+		user.CityOnly,
+		user.Editing,
+		user.Paused,
+		user.ReportedId,
 	).WillReturnError(someError)
 	pool.ExpectRollback()
 
@@ -268,14 +313,20 @@ func TestUserRepository_DeleteByUserId(t *testing.T) {
 	defer pool.Close()
 
 	pool.ExpectBegin()
+	pool.ExpectExec("DELETE FROM likes ").WithArgs(
+		int64(1),
+	).WillReturnResult(pgxmock.NewResult("DELETE", 2))
+	pool.ExpectExec("DELETE FROM user_photos ").WithArgs(
+		int64(1),
+	).WillReturnResult(pgxmock.NewResult("DELETE", 1))
 	pool.ExpectExec("DELETE FROM users ").WithArgs(
-		"1",
+		int64(1),
 	).WillReturnResult(pgxmock.NewResult("DELETE", 1))
 	pool.ExpectCommit()
 
 	users := NewUserRepository(pool)
 
-	if err := users.DeleteByUserId(context.Background(), "1"); err != nil {
+	if err := users.DeleteByUserId(context.Background(), 1); err != nil {
 		t.Errorf("error was not expected while deleting user: %s", err.Error())
 	}
 
@@ -298,14 +349,14 @@ func TestUserRepository_DeleteByUserId_ShouldReturnError(t *testing.T) {
 	someError := errors.New("some error")
 
 	pool.ExpectBegin()
-	pool.ExpectExec("DELETE FROM users ").WithArgs(
-		"1",
+	pool.ExpectExec("DELETE FROM likes ").WithArgs(
+		int64(1),
 	).WillReturnError(someError)
 	pool.ExpectRollback()
 
 	users := NewUserRepository(pool)
 
-	if err := users.DeleteByUserId(context.Background(), "1"); err != nil {
+	if err := users.DeleteByUserId(context.Background(), 1); err != nil {
 		assert.EqualValues(t, someError, err)
 	} else {
 		t.Errorf("was expecting an error, but there was none")
@@ -328,14 +379,20 @@ func TestUserRepository_DeleteByUserId_ShouldReturnErrNoRecordIfRawsNoAffected(t
 	defer pool.Close()
 
 	pool.ExpectBegin()
+	pool.ExpectExec("DELETE FROM likes ").WithArgs(
+		int64(1),
+	).WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	pool.ExpectExec("DELETE FROM user_photos ").WithArgs(
+		int64(1),
+	).WillReturnResult(pgxmock.NewResult("DELETE", 0))
 	pool.ExpectExec("DELETE FROM users ").WithArgs(
-		"1",
+		int64(1),
 	).WillReturnResult(pgxmock.NewResult("DELETE", 0))
 	pool.ExpectRollback()
 
 	users := NewUserRepository(pool)
 
-	if err := users.DeleteByUserId(context.Background(), "1"); err != nil {
+	if err := users.DeleteByUserId(context.Background(), 1); err != nil {
 		assert.EqualValues(t, models.ErrNoRecord, err)
 	} else {
 		t.Errorf("was expecting an error, but there was none")
@@ -359,13 +416,13 @@ func TestUserRepository_GetByUserIdShouldReturnErrNoRecordIfUserIsNotExists(t *t
 
 	pool.ExpectBegin()
 	pool.ExpectQuery("^SELECT (.+) FROM users ").WithArgs(
-		"1",
+		int64(1),
 	).WillReturnError(pgx.ErrNoRows)
 	pool.ExpectRollback()
 
 	users := NewUserRepository(pool)
 
-	if _, err := users.GetByUserId(context.Background(), "1"); err != nil {
+	if _, err := users.GetByUserId(context.Background(), 1); err != nil {
 		assert.EqualValues(t, models.ErrNoRecord, err)
 	} else {
 		t.Errorf("was expecting an error, but there was none")
@@ -388,20 +445,20 @@ func TestUserRepository_GetByUserIdShouldReturnRows(t *testing.T) {
 	defer pool.Close()
 
 	user := &models.User{
-		Id: "1",
+		Id: 1,
 	}
 
 	pool.ExpectBegin()
 	pool.ExpectQuery("^SELECT (.+) FROM users ").WithArgs(
-		"1",
-	).WillReturnRows(pgxmock.NewRows([]string{"id", "name", "sex", "age", "description", "city", "image", "started", "stage", "chat_id"}).AddRow(
-		user.Id, user.Name, user.Sex, user.Age, user.Description, user.City, user.Image, user.Started, user.Stage, user.ChatId,
+		int64(1),
+	).WillReturnRows(pgxmock.NewRows([]string{"id", "username", "name", "gender", "interested_in", "age", "description", "city", "image", "started", "stage", "chat_id", "min_age", "max_age", "city_only", "editing", "paused", "hidden", "banned", "reported_id"}).AddRow(
+		user.Id, user.Username, user.Name, user.Gender, user.InterestedIn, user.Age, user.Description, user.City, user.Image, user.Started, user.Stage, user.ChatId, user.MinAge, user.MaxAge, user.CityOnly, user.Editing, user.Paused, user.Hidden, user.Banned, user.ReportedId,
 	))
 	pool.ExpectCommit()
 
 	users := NewUserRepository(pool)
 
-	actualUser, err := users.GetByUserId(context.Background(), "1")
+	actualUser, err := users.GetByUserId(context.Background(), 1)
 	if err != nil {
 		t.Errorf("error was not expected while getting user: %s", err.Error())
 	}
@@ -413,6 +470,38 @@ func TestUserRepository_GetByUserIdShouldReturnRows(t *testing.T) {
 	}
 }
 
+func TestUserRepository_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	user := &models.User{Id: 1, Name: "Anna"}
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT (.+) FROM users ").WithArgs(
+		"ann", 20, 40,
+	).WillReturnRows(pgxmock.NewRows([]string{"id", "username", "name", "gender", "interested_in", "age", "description", "city", "image", "started", "stage", "chat_id", "min_age", "max_age", "city_only", "editing", "paused", "hidden", "banned", "reported_id"}).AddRow(
+		user.Id, user.Username, user.Name, user.Gender, user.InterestedIn, user.Age, user.Description, user.City, user.Image, user.Started, user.Stage, user.ChatId, user.MinAge, user.MaxAge, user.CityOnly, user.Editing, user.Paused, user.Hidden, user.Banned, user.ReportedId,
+	))
+	pool.ExpectCommit()
+
+	users := NewUserRepository(pool)
+
+	actualUsers, err := users.Search(context.Background(), "ann", 20, 40)
+	assert.Nil(t, err)
+	assert.EqualValues(t, []*models.User{user}, actualUsers)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestUserRepository_GetByUserId_ShouldReturnSameErrorOnFailure(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -425,7 +514,7 @@ func TestUserRepository_GetByUserId_ShouldReturnSameErrorOnFailure(t *testing.T)
 	defer pool.Close()
 
 	user := &models.User{
-		Id: "1",
+		Id: 1,
 	}
 
 	expectedErr := errors.New("some err")
@@ -438,7 +527,7 @@ func TestUserRepository_GetByUserId_ShouldReturnSameErrorOnFailure(t *testing.T)
 
 	users := NewUserRepository(pool)
 
-	actualUser, err := users.GetByUserId(context.Background(), "1")
+	actualUser, err := users.GetByUserId(context.Background(), 1)
 	assert.NotNil(t, err)
 	assert.True(t, errors.Is(err, expectedErr))
 	assert.Nil(t, actualUser)
@@ -459,16 +548,31 @@ func TestUserRepository_GetNextUser_ShouldReturnErrNoRecordNoRows(t *testing.T)
 	}
 	defer pool.Close()
 
+	searcher := &models.User{
+		Id:           1,
+		Gender:       models.GenderMale,
+		InterestedIn: models.GenderFemale | models.GenderNonBinary,
+		City:         "city",
+		MinAge:       18,
+		MaxAge:       30,
+		CityOnly:     true,
+	}
+
 	pool.ExpectBegin()
 	pool.ExpectQuery("^SELECT (.+) FROM users ").WithArgs(
-		"1",
-		true,
+		searcher.Id,
+		searcher.Gender,
+		searcher.InterestedIn,
+		searcher.MinAge,
+		searcher.MaxAge,
+		searcher.CityOnly,
+		searcher.City,
 	).WillReturnError(pgx.ErrNoRows)
 	pool.ExpectRollback()
 
 	users := NewUserRepository(pool)
 
-	if _, err := users.GetNextUser(context.Background(), "1", true); err != nil {
+	if _, err := users.GetNextUser(context.Background(), searcher); err != nil {
 		assert.EqualValues(t, models.ErrNoRecord, err)
 	} else {
 		t.Errorf("was expecting an error, but there was none")
@@ -492,16 +596,31 @@ func TestUserRepository_GetNextUser_ShouldReturnSameErrOnFailure(t *testing.T) {
 
 	expectedErr := errors.New("some err")
 
+	searcher := &models.User{
+		Id:           1,
+		Gender:       models.GenderMale,
+		InterestedIn: models.GenderFemale | models.GenderNonBinary,
+		City:         "city",
+		MinAge:       18,
+		MaxAge:       30,
+		CityOnly:     true,
+	}
+
 	pool.ExpectBegin()
 	pool.ExpectQuery("^SELECT (.+) FROM users ").WithArgs(
-		"1",
-		true,
+		searcher.Id,
+		searcher.Gender,
+		searcher.InterestedIn,
+		searcher.MinAge,
+		searcher.MaxAge,
+		searcher.CityOnly,
+		searcher.City,
 	).WillReturnError(expectedErr)
 	pool.ExpectRollback()
 
 	users := NewUserRepository(pool)
 
-	actualUser, err := users.GetNextUser(context.Background(), "1", true)
+	actualUser, err := users.GetNextUser(context.Background(), searcher)
 	assert.NotNil(t, err)
 	assert.True(t, errors.Is(err, expectedErr))
 	assert.Nil(t, actualUser)
@@ -523,30 +642,166 @@ func TestUserRepository_GetNextUserShouldReturnRows(t *testing.T) {
 	defer pool.Close()
 
 	user := &models.User{
-		Id:  "1",
-		Sex: false,
+		Id:           1,
+		Gender:       models.GenderMale,
+		InterestedIn: models.GenderFemale,
+	}
+
+	searcher := &models.User{
+		Id:           1,
+		Gender:       models.GenderMale,
+		InterestedIn: models.GenderFemale | models.GenderNonBinary,
+		City:         "city",
+		MinAge:       18,
+		MaxAge:       30,
+		CityOnly:     true,
 	}
 
 	pool.ExpectBegin()
 	pool.ExpectQuery("^SELECT (.+) FROM users ").WithArgs(
-		"1",
-		true,
-	).WillReturnRows(pgxmock.NewRows([]string{"id", "name", "sex", "age", "description", "city", "image", "started", "stage", "chat_id"}).AddRow(
-		"2", user.Name, !user.Sex, user.Age, user.Description, user.City, user.Image, user.Started, user.Stage, user.ChatId,
+		searcher.Id,
+		searcher.Gender,
+		searcher.InterestedIn,
+		searcher.MinAge,
+		searcher.MaxAge,
+		searcher.CityOnly,
+		searcher.City,
+	).WillReturnRows(pgxmock.NewRows([]string{"id", "username", "name", "gender", "interested_in", "age", "description", "city", "image", "started", "stage", "chat_id", "min_age", "max_age", "city_only", "editing", "paused", "hidden", "banned", "reported_id"}).AddRow(
+		int64(2), user.Username, user.Name, models.GenderNonBinary, models.GenderMale, user.Age, user.Description, user.City, user.Image, user.Started, user.Stage, user.ChatId, user.MinAge, user.MaxAge, user.CityOnly, user.Editing, user.Paused, user.Hidden, user.Banned, user.ReportedId,
 	))
 	pool.ExpectCommit()
 
 	users := NewUserRepository(pool)
 
-	actualUser, err := users.GetNextUser(context.Background(), "1", true)
+	actualUser, err := users.GetNextUser(context.Background(), searcher)
 	if err != nil {
 		t.Errorf("error was not expected while updating user: %s", err.Error())
 	}
 
-	assert.NotEqualValues(t, user.Sex, actualUser.Sex)
+	assert.EqualValues(t, models.GenderNonBinary, actualUser.Gender)
+	assert.EqualValues(t, models.GenderMale, actualUser.InterestedIn)
 	assert.NotEqualValues(t, user.Id, actualUser.Id)
 
 	if err := pool.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestUserRepository_GetNextLiker_ShouldReturnRows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	liker := &models.User{Id: 2, Name: "Masha", Gender: models.GenderFemale, InterestedIn: models.GenderMale}
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT (.+) FROM users ").WithArgs(
+		int64(1), 18,
+	).WillReturnRows(pgxmock.NewRows([]string{"id", "username", "name", "gender", "interested_in", "age", "description", "city", "image", "started", "stage", "chat_id", "min_age", "max_age", "city_only", "editing", "paused", "hidden", "banned", "reported_id"}).AddRow(
+		liker.Id, liker.Username, liker.Name, liker.Gender, liker.InterestedIn, liker.Age, liker.Description, liker.City, liker.Image, liker.Started, liker.Stage, liker.ChatId, liker.MinAge, liker.MaxAge, liker.CityOnly, liker.Editing, liker.Paused, liker.Hidden, liker.Banned, liker.ReportedId,
+	))
+	pool.ExpectCommit()
+
+	users := NewUserRepository(pool)
+
+	actualUser, err := users.GetNextLiker(context.Background(), 1, 18)
+	if err != nil {
+		t.Errorf("error was not expected while getting liker: %s", err.Error())
+	}
+
+	assert.EqualValues(t, liker, actualUser)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUserRepository_GetNextLiker_ShouldReturnErrNoRecordNoRows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT (.+) FROM users ").WithArgs(
+		int64(1), 18,
+	).WillReturnError(pgx.ErrNoRows)
+	pool.ExpectRollback()
+
+	users := NewUserRepository(pool)
+
+	actualUser, err := users.GetNextLiker(context.Background(), 1, 18)
+	assert.EqualValues(t, models.ErrNoRecord, err)
+	assert.Nil(t, actualUser)
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUserRepository_UpdateUsername(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("UPDATE users SET username").WithArgs(
+		int64(1), "new_username",
+	).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	pool.ExpectCommit()
+
+	users := NewUserRepository(pool)
+
+	if err := users.UpdateUsername(context.Background(), 1, "new_username"); err != nil {
+		t.Errorf("error was not expected while updating username: %s", err.Error())
+	}
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUserRepository_SetHidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("error was not expected while creating pool: %s", err.Error())
+		return
+	}
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("UPDATE users SET hidden").WithArgs(
+		int64(1), true,
+	).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	pool.ExpectCommit()
+
+	users := NewUserRepository(pool)
+
+	if err := users.SetHidden(context.Background(), 1, true); err != nil {
+		t.Errorf("error was not expected while hiding user: %s", err.Error())
+	}
+
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
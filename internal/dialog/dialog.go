@@ -0,0 +1,230 @@
+// Package dialog runs conversations where the bot asks the user a series of questions.
+// Every question is a Step, the id of the current step is stored in user.Stage.
+package dialog
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Done is the Next of the last step, it is also stored in user.Stage when there is no dialog in progress
+const Done = -1
+
+const InvalidInputText = "Данные введены некорректно, попробуйте снова."
+
+// ErrInvalidInput is returned by Validate and Parse when the input can't be used
+var ErrInvalidInput = &InvalidInputError{Text: InvalidInputText}
+
+// InvalidInputError keeps the user on the step, Text is sent instead of the prompt.
+type InvalidInputError struct {
+	Text string
+}
+
+func (e *InvalidInputError) Error() string {
+	return e.Text
+}
+
+// Invalid returns an error which shows text to the user.
+func Invalid(text string) error {
+	return &InvalidInputError{Text: text}
+}
+
+// Input is a message or a pressed button. PhotoId is empty if the message has no photo.
+type Input struct {
+	Text    string
+	PhotoId string
+}
+
+// Result of Parse. By default the user moves to the next step.
+type Result struct {
+	Stay        bool        // Keep the user on the step, e.g. after one of several choices is toggled
+	Text        string      // Replaces the prompt when the user stays
+	ReplyMarkup interface{} // Replaces the keyboard when the user stays
+}
+
+// Step is a single question of a dialog. Only Prompt and Parse are required.
+type Step struct {
+	Prompt string
+
+	// Keyboard is sent with the prompt. Steps without it get a skip button if Skip returns a value.
+	Keyboard func(ctx context.Context, user *models.User) (interface{}, error)
+
+	// Skip returns the current value of the field, sending it back leaves the field as is.
+	// An empty value means there is nothing to skip to.
+	Skip func(user *models.User) string
+
+	// Validate rejects the input before Parse is called
+	Validate func(input Input, user *models.User) error
+
+	// Parse stores the input in user
+	Parse func(ctx context.Context, input Input, user *models.User) (Result, error)
+
+	Next int
+}
+
+// SaveFunc stores user after its stage or fields are changed.
+type SaveFunc func(ctx context.Context, user *models.User) error
+
+// FinishFunc returns the message sent after the last step.
+type FinishFunc func(ctx context.Context, chatId int64, user *models.User) (tgbotapi.Chattable, error)
+
+type Dialog struct {
+	steps      map[int]*Step
+	save       SaveFunc
+	finish     FinishFunc
+	keyboards  *internal.Keyboards
+	cancelable bool
+}
+
+func New(steps map[int]*Step, save SaveFunc, finish FinishFunc, keyboards *internal.Keyboards) *Dialog {
+	return &Dialog{
+		steps:     steps,
+		save:      save,
+		finish:    finish,
+		keyboards: keyboards,
+	}
+}
+
+// Cancelable adds the cancel button to every prompt, not only to the prompts of a single edited field.
+// The button is handled by the caller.
+func (d *Dialog) Cancelable() *Dialog {
+	d.cancelable = true
+	return d
+}
+
+// Has reports whether stage is one of the dialog steps.
+func (d *Dialog) Has(stage int) bool {
+	_, ok := d.steps[stage]
+	return ok
+}
+
+// Step returns the step with id, or nil if there is none.
+func (d *Dialog) Step(id int) *Step {
+	return d.steps[id]
+}
+
+// Start moves the user to the step with id and asks its question.
+func (d *Dialog) Start(ctx context.Context, chatId int64, user *models.User, id int) (tgbotapi.MessageConfig, error) {
+	step, ok := d.steps[id]
+	if !ok {
+		return tgbotapi.NewMessage(chatId, InvalidInputText), nil
+	}
+
+	user.Stage = id
+	if err := d.save(ctx, user); err != nil {
+		return tgbotapi.MessageConfig{}, err
+	}
+
+	return d.prompt(ctx, chatId, user, step, step.Prompt)
+}
+
+// Handle applies input to the current step of the user.
+// After a single edited field (user.Editing) the dialog is finished instead of moving to the next step.
+func (d *Dialog) Handle(ctx context.Context, chatId int64, input Input, user *models.User) (tgbotapi.Chattable, error) {
+	step, ok := d.steps[user.Stage]
+	if !ok {
+		return tgbotapi.NewMessage(chatId, InvalidInputText), nil
+	}
+
+	var err error
+	if step.Validate != nil {
+		err = step.Validate(input, user)
+	}
+
+	var result Result
+	if err == nil {
+		result, err = step.Parse(ctx, input, user)
+	}
+
+	var invalid *InvalidInputError
+	if errors.As(err, &invalid) {
+		return d.prompt(ctx, chatId, user, step, invalid.Text)
+	}
+	if err != nil {
+		return tgbotapi.MessageConfig{}, err
+	}
+
+	if result.Stay {
+		if err := d.save(ctx, user); err != nil {
+			return tgbotapi.MessageConfig{}, err
+		}
+
+		text := step.Prompt
+		if len(result.Text) > 0 {
+			text = result.Text
+		}
+		if result.ReplyMarkup != nil {
+			outputMsg := tgbotapi.NewMessage(chatId, text)
+			outputMsg.ReplyMarkup = d.withCancel(user, result.ReplyMarkup)
+			return outputMsg, nil
+		}
+		return d.prompt(ctx, chatId, user, step, text)
+	}
+
+	next := step.Next
+	if user.Editing {
+		next = Done
+	}
+
+	nextStep, ok := d.steps[next]
+	if !ok {
+		user.Stage = Done
+		user.Editing = false
+		if err := d.save(ctx, user); err != nil {
+			return tgbotapi.MessageConfig{}, err
+		}
+
+		return d.finish(ctx, chatId, user)
+	}
+
+	user.Stage = next
+	if err := d.save(ctx, user); err != nil {
+		return tgbotapi.MessageConfig{}, err
+	}
+
+	return d.prompt(ctx, chatId, user, nextStep, nextStep.Prompt)
+}
+
+// Skip handles the skip button of the current step as if the user sent the current value of the field.
+func (d *Dialog) Skip(ctx context.Context, chatId int64, user *models.User) (tgbotapi.Chattable, error) {
+	step, ok := d.steps[user.Stage]
+	if !ok || step.Skip == nil {
+		return tgbotapi.NewMessage(chatId, InvalidInputText), nil
+	}
+
+	return d.Handle(ctx, chatId, Input{Text: step.Skip(user)}, user)
+}
+
+func (d *Dialog) prompt(ctx context.Context, chatId int64, user *models.User, step *Step, text string) (tgbotapi.MessageConfig, error) {
+	outputMsg := tgbotapi.NewMessage(chatId, text)
+
+	var replyMarkup interface{}
+	switch {
+	case step.Keyboard != nil:
+		var err error
+		if replyMarkup, err = step.Keyboard(ctx, user); err != nil {
+			return tgbotapi.MessageConfig{}, err
+		}
+	case step.Skip != nil:
+		if skip := step.Skip(user); len(skip) > 0 {
+			replyMarkup = d.keyboards.CreateSkipKeyboardMarkup()
+		}
+	}
+
+	if replyMarkup = d.withCancel(user, replyMarkup); replyMarkup != nil {
+		outputMsg.ReplyMarkup = replyMarkup
+	}
+
+	return outputMsg, nil
+}
+
+// withCancel lets the user leave a cancelable dialog or a single edited field without changing it.
+func (d *Dialog) withCancel(user *models.User, replyMarkup interface{}) interface{} {
+	if !d.cancelable && !user.Editing {
+		return replyMarkup
+	}
+	return d.keyboards.AppendCancelRow(replyMarkup)
+}
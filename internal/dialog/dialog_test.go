@@ -0,0 +1,200 @@
+package dialog
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"testing"
+)
+
+const finishText = "finished"
+
+var testCallbacks = internal.NewCallbackCodec([]byte("key"))
+
+func newTestDialog(saved *int) *Dialog {
+	steps := map[int]*Step{
+		0: {
+			Prompt: "name",
+			Skip:   func(user *models.User) string { return user.Name },
+			Validate: func(input Input, _ *models.User) error {
+				if len(input.Text) == 0 {
+					return ErrInvalidInput
+				}
+				return nil
+			},
+			Parse: func(_ context.Context, input Input, user *models.User) (Result, error) {
+				user.Name = input.Text
+				return Result{}, nil
+			},
+			Next: 1,
+		},
+		1: {
+			Prompt: "city",
+			Parse: func(_ context.Context, input Input, user *models.User) (Result, error) {
+				if input.Text == "again" {
+					return Result{Stay: true, Text: "once more"}, nil
+				}
+				if input.Text == "nowhere" {
+					return Result{}, Invalid("no such city")
+				}
+				user.City = input.Text
+				return Result{}, nil
+			},
+			Next: Done,
+		},
+	}
+
+	save := func(context.Context, *models.User) error {
+		*saved++
+		return nil
+	}
+
+	finish := func(_ context.Context, chatId int64, _ *models.User) (tgbotapi.Chattable, error) {
+		return tgbotapi.NewMessage(chatId, finishText), nil
+	}
+
+	return New(steps, save, finish, internal.NewKeyboards(testCallbacks, zap.NewNop().Sugar()))
+}
+
+func TestDialog_Start(t *testing.T) {
+	saved := 0
+	d := newTestDialog(&saved)
+	user := &models.User{Name: "name", Stage: Done}
+
+	msgCfg, err := d.Start(context.Background(), 1, user, 0)
+	assert.Nil(t, err)
+	assert.EqualValues(t, "name", msgCfg.Text)
+	assert.EqualValues(t, 0, user.Stage)
+	assert.EqualValues(t, 1, saved)
+
+	keyboard, ok := msgCfg.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup)
+	assert.True(t, ok)
+	callback, err := testCallbacks.Decode(*keyboard.InlineKeyboard[0][0].CallbackData)
+	assert.Nil(t, err)
+	assert.EqualValues(t, internal.CallbackSkip, callback.Action)
+}
+
+func TestDialog_Start_ShouldReturnErrorOnSaveFailure(t *testing.T) {
+	expectedError := errors.New("some error")
+	d := New(map[int]*Step{0: {Prompt: "name"}}, func(context.Context, *models.User) error {
+		return expectedError
+	}, nil, nil)
+
+	_, err := d.Start(context.Background(), 1, &models.User{}, 0)
+	assert.True(t, errors.Is(err, expectedError))
+}
+
+func TestDialog_Handle(t *testing.T) {
+	saved := 0
+	d := newTestDialog(&saved)
+	user := &models.User{Stage: 0}
+
+	chattable, err := d.Handle(context.Background(), 1, Input{Text: "name"}, user)
+	assert.Nil(t, err)
+	assert.EqualValues(t, "city", chattable.(tgbotapi.MessageConfig).Text)
+	assert.EqualValues(t, 1, user.Stage)
+
+	chattable, err = d.Handle(context.Background(), 1, Input{Text: "city"}, user)
+	assert.Nil(t, err)
+	assert.EqualValues(t, finishText, chattable.(tgbotapi.MessageConfig).Text)
+	assert.EqualValues(t, Done, user.Stage)
+	assert.EqualValues(t, "city", user.City)
+	assert.EqualValues(t, 2, saved)
+}
+
+func TestDialog_Handle_InvalidInput(t *testing.T) {
+	cases := []struct {
+		name         string
+		stage        int
+		inputText    string
+		expectedText string
+	}{
+		{"validator", 0, "", InvalidInputText},
+		{"parser", 1, "nowhere", "no such city"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			saved := 0
+			d := newTestDialog(&saved)
+			user := &models.User{Stage: c.stage}
+
+			chattable, err := d.Handle(context.Background(), 1, Input{Text: c.inputText}, user)
+			assert.Nil(t, err)
+			assert.EqualValues(t, c.expectedText, chattable.(tgbotapi.MessageConfig).Text)
+			assert.EqualValues(t, c.stage, user.Stage)
+			assert.EqualValues(t, 0, saved)
+		})
+	}
+}
+
+func TestDialog_Handle_Stay(t *testing.T) {
+	saved := 0
+	d := newTestDialog(&saved)
+	user := &models.User{Stage: 1}
+
+	chattable, err := d.Handle(context.Background(), 1, Input{Text: "again"}, user)
+	assert.Nil(t, err)
+	assert.EqualValues(t, "once more", chattable.(tgbotapi.MessageConfig).Text)
+	assert.EqualValues(t, 1, user.Stage)
+	assert.EqualValues(t, 1, saved)
+}
+
+func TestDialog_Handle_EditingShouldFinish(t *testing.T) {
+	saved := 0
+	d := newTestDialog(&saved)
+	user := &models.User{Stage: 0, Editing: true}
+
+	chattable, err := d.Handle(context.Background(), 1, Input{Text: "name"}, user)
+	assert.Nil(t, err)
+	assert.EqualValues(t, finishText, chattable.(tgbotapi.MessageConfig).Text)
+	assert.EqualValues(t, Done, user.Stage)
+	assert.False(t, user.Editing)
+}
+
+func TestDialog_Start_CancelableShouldAddCancelButton(t *testing.T) {
+	saved := 0
+	d := newTestDialog(&saved).Cancelable()
+
+	msgCfg, err := d.Start(context.Background(), 1, &models.User{Stage: Done}, 1)
+	assert.Nil(t, err)
+
+	keyboard, ok := msgCfg.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup)
+	assert.True(t, ok)
+	callback, err := testCallbacks.Decode(*keyboard.InlineKeyboard[0][0].CallbackData)
+	assert.Nil(t, err)
+	assert.EqualValues(t, internal.CallbackCancel, callback.Action)
+}
+
+func TestDialog_Skip(t *testing.T) {
+	saved := 0
+	d := newTestDialog(&saved)
+	user := &models.User{Name: "name", Stage: 0}
+
+	chattable, err := d.Skip(context.Background(), 1, user)
+	assert.Nil(t, err)
+	assert.EqualValues(t, "city", chattable.(tgbotapi.MessageConfig).Text)
+	assert.EqualValues(t, "name", user.Name)
+	assert.EqualValues(t, 1, user.Stage)
+
+	chattable, err = d.Skip(context.Background(), 1, user)
+	assert.Nil(t, err)
+	assert.EqualValues(t, InvalidInputText, chattable.(tgbotapi.MessageConfig).Text)
+	assert.EqualValues(t, 1, user.Stage)
+}
+
+func TestDialog_Handle_UnknownStage(t *testing.T) {
+	saved := 0
+	d := newTestDialog(&saved)
+	user := &models.User{Stage: 42}
+
+	chattable, err := d.Handle(context.Background(), 1, Input{Text: "name"}, user)
+	assert.Nil(t, err)
+	assert.EqualValues(t, InvalidInputText, chattable.(tgbotapi.MessageConfig).Text)
+	assert.EqualValues(t, 42, user.Stage)
+	assert.EqualValues(t, 0, saved)
+}
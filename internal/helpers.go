@@ -4,47 +4,396 @@ import (
 	"fmt"
 	"github.com/Eretic431/datingTelegramBot/internal/data/models"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+	"strconv"
+	"strings"
 )
 
-func CreateSkipKeyboardMarkup(data string) tgbotapi.InlineKeyboardMarkup {
-	if len(data) == 0 {
-		data = "-"
+var genderNames = map[int]string{
+	models.GenderMale:      "Мужчина",
+	models.GenderFemale:    "Женщина",
+	models.GenderNonBinary: "Небинарный",
+}
+
+var genderPluralNames = map[int]string{
+	models.GenderMale:      "Мужчины",
+	models.GenderFemale:    "Женщины",
+	models.GenderNonBinary: "Небинарные",
+}
+
+// ParseGender accepts a gender name or its first letter.
+func ParseGender(text string) (int, bool) {
+	for gender, name := range genderNames {
+		if text == name || text == string([]rune(name)[:1]) {
+			return gender, true
+		}
+	}
+
+	return 0, false
+}
+
+// CommandsList is shown on /start and on unknown commands.
+const CommandsList = "*Список доступных команд:* \n" +
+	"- /start - начало работы\n" +
+	"- /profile - заполнить анкету\n" +
+	"- /edit - изменить анкету\n" +
+	"- /settings - настроить поиск\n" +
+	"- /next - показать следующего пользователя\n" +
+	"- /matches - показать совпадения\n" +
+	"- /likes - посмотреть, кому Вы понравились\n" +
+	"- /undo - вернуть предыдущую анкету\n" +
+	"- /pause - скрыть анкету из поиска\n" +
+	"- /resume - снова показывать анкету\n" +
+	"- /delete - удалить анкету\n" +
+	"- /cancel - отменить жалобу, изменение анкеты или настроек поиска"
+
+// Keyboards creates inline keyboards with the callback data signed by callbacks.
+// A button whose data can't be encoded is logged and left out of its keyboard.
+type Keyboards struct {
+	callbacks *CallbackCodec
+	log       *zap.SugaredLogger
+}
+
+func NewKeyboards(callbacks *CallbackCodec, log *zap.SugaredLogger) *Keyboards {
+	return &Keyboards{callbacks: callbacks, log: log}
+}
+
+// appendButton appends the button to row unless its callback data can't be encoded.
+func (k *Keyboards) appendButton(row []tgbotapi.InlineKeyboardButton, text string, action CallbackAction, payload string) []tgbotapi.InlineKeyboardButton {
+	data, err := k.callbacks.Encode(Callback{Action: action, Payload: payload})
+	if err != nil {
+		k.log.Errorw("could not encode callback, the button is skipped",
+			"action", string(action), "payload", payload, "err", err)
+		return row
+	}
+
+	return append(row, tgbotapi.NewInlineKeyboardButtonData(text, data))
+}
+
+// appendInputButton appends a button which sends text to the current dialog step as if the user typed it.
+func (k *Keyboards) appendInputButton(row []tgbotapi.InlineKeyboardButton, text string) []tgbotapi.InlineKeyboardButton {
+	return k.appendButton(row, text, CallbackInput, text)
+}
+
+// newMarkup leaves out the rows which lost all their buttons.
+func newMarkup(rows ...[]tgbotapi.InlineKeyboardButton) tgbotapi.InlineKeyboardMarkup {
+	markup := tgbotapi.InlineKeyboardMarkup{InlineKeyboard: make([][]tgbotapi.InlineKeyboardButton, 0, len(rows))}
+	for _, row := range rows {
+		if len(row) > 0 {
+			markup.InlineKeyboard = append(markup.InlineKeyboard, row)
+		}
+	}
+
+	return markup
+}
+
+func (k *Keyboards) CreateSkipKeyboardMarkup() tgbotapi.InlineKeyboardMarkup {
+	return newMarkup(k.appendButton(nil, "Пропустить", CallbackSkip, ""))
+}
+
+// AppendCancelRow adds the cancel button under an inline keyboard, or returns the button alone if markup is nil.
+// Other markups are returned as is.
+func (k *Keyboards) AppendCancelRow(markup interface{}) interface{} {
+	row := k.appendButton(nil, "✖ Отменить изменение", CallbackCancel, "")
+
+	switch m := markup.(type) {
+	case nil:
+		return newMarkup(row)
+	case tgbotapi.InlineKeyboardMarkup:
+		rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(m.InlineKeyboard)+1)
+		return newMarkup(append(append(rows, m.InlineKeyboard...), row)...)
+	default:
+		return markup
+	}
+}
+
+// CreateChoiceKeyboardMarkup creates a row of buttons, each of them sends its own text as input.
+func (k *Keyboards) CreateChoiceKeyboardMarkup(choices ...string) tgbotapi.InlineKeyboardMarkup {
+	buttons := make([]tgbotapi.InlineKeyboardButton, 0, len(choices))
+	for _, choice := range choices {
+		buttons = k.appendInputButton(buttons, choice)
+	}
+
+	return newMarkup(buttons)
+}
+
+// CreateGenderKeyboardMarkup creates a button for every gender.
+func (k *Keyboards) CreateGenderKeyboardMarkup() tgbotapi.InlineKeyboardMarkup {
+	choices := make([]string, 0, len(models.Genders))
+	for _, gender := range models.Genders {
+		choices = append(choices, genderNames[gender])
+	}
+
+	return k.CreateChoiceKeyboardMarkup(choices...)
+}
+
+// CreateInterestedInKeyboardMarkup creates toggle buttons for a set of genders and a button to finish the choice.
+func (k *Keyboards) CreateInterestedInKeyboardMarkup(interestedIn int, doneData string) tgbotapi.InlineKeyboardMarkup {
+	buttons := make([]tgbotapi.InlineKeyboardButton, 0, len(models.Genders))
+	for _, gender := range models.Genders {
+		text := genderPluralNames[gender]
+		if interestedIn&gender != 0 {
+			text = "✅ " + text
+		}
+		buttons = k.appendButton(buttons, text, CallbackInput, genderNames[gender])
+	}
+
+	return newMarkup(buttons, k.appendInputButton(nil, doneData))
+}
+
+// Photo actions of the gallery keyboard
+const (
+	PhotoActionFirst  = "first"
+	PhotoActionDelete = "delete"
+)
+
+// CreatePhotosKeyboardMarkup lets the user make any of count photos the cover or delete it.
+// doneData button is added only if there is at least one photo.
+func (k *Keyboards) CreatePhotosKeyboardMarkup(count int, doneData string) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, count+1)
+	for i := 0; i < count; i++ {
+		number := strconv.Itoa(i + 1)
+		row := make([]tgbotapi.InlineKeyboardButton, 0, 2)
+		if i > 0 {
+			row = k.appendButton(row, "⬆ №"+number+" сделать главной", CallbackInput, "photo;"+PhotoActionFirst+";"+strconv.Itoa(i))
+		}
+		row = k.appendButton(row, "✖ Удалить №"+number, CallbackInput, "photo;"+PhotoActionDelete+";"+strconv.Itoa(i))
+		rows = append(rows, row)
+	}
+
+	if count > 0 {
+		rows = append(rows, k.appendInputButton(nil, doneData))
 	}
-	buttonData := tgbotapi.NewInlineKeyboardButtonData("Пропустить", data)
-	return tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(buttonData),
+
+	return newMarkup(rows...)
+}
+
+// ParsePhotoAction parses the input of CreatePhotosKeyboardMarkup.
+func ParsePhotoAction(data string) (action string, index int, ok bool) {
+	parts := strings.Split(data, ";")
+	if len(parts) != 3 || parts[0] != "photo" {
+		return "", 0, false
+	}
+
+	index, err := strconv.Atoi(parts[2])
+	if err != nil || index < 0 {
+		return "", 0, false
+	}
+
+	switch parts[1] {
+	case PhotoActionFirst, PhotoActionDelete:
+		return parts[1], index, true
+	}
+
+	return "", 0, false
+}
+
+// CreateEditKeyboardMarkup returns one button per profile field, fieldNames are indexed by profile stage.
+func (k *Keyboards) CreateEditKeyboardMarkup(fieldNames ...string) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, (len(fieldNames)+1)/2)
+	for i := 0; i < len(fieldNames); i += 2 {
+		row := k.appendButton(nil, fieldNames[i], CallbackEdit, strconv.Itoa(i))
+		if i+1 < len(fieldNames) {
+			row = k.appendButton(row, fieldNames[i+1], CallbackEdit, strconv.Itoa(i+1))
+		}
+		rows = append(rows, row)
+	}
+
+	return newMarkup(rows...)
+}
+
+func (k *Keyboards) CreateLikeKeyboardMarkup(toId int64) tgbotapi.InlineKeyboardMarkup {
+	return k.createLikeKeyboardMarkup(toId, false)
+}
+
+// CreateLikesInboxKeyboardMarkup is the like keyboard for /likes. Its callbacks carry LikesInboxSuffix,
+// so the next profile is taken from the same inbox.
+func (k *Keyboards) CreateLikesInboxKeyboardMarkup(toId int64) tgbotapi.InlineKeyboardMarkup {
+	return k.createLikeKeyboardMarkup(toId, true)
+}
+
+// LikesInboxSuffix marks like callbacks sent from /likes
+const LikesInboxSuffix = "inbox"
+
+func (k *Keyboards) createLikeKeyboardMarkup(toId int64, fromInbox bool) tgbotapi.InlineKeyboardMarkup {
+	swipeRow := k.appendButton(nil, "❤", CallbackLike, LikePayload(toId, fromInbox))
+	swipeRow = k.appendButton(swipeRow, "➡", CallbackDislike, LikePayload(toId, fromInbox))
+
+	return newMarkup(
+		swipeRow,
+		k.appendButton(nil, "↩ Назад", CallbackUndo, ""),
+		k.createAbuseKeyboardRow(toId),
 	)
 }
 
-func CreateLikeKeyboardMarkup(toId string) tgbotapi.InlineKeyboardMarkup {
-	likeData := tgbotapi.NewInlineKeyboardButtonData("❤", "like;"+toId)
-	dislikeData := tgbotapi.NewInlineKeyboardButtonData("➡", "dislike;"+toId)
+// CreateMatchKeyboardMarkup is sent with a match, so an abusive match can be reported or blocked.
+func (k *Keyboards) CreateMatchKeyboardMarkup(toId int64) tgbotapi.InlineKeyboardMarkup {
+	return newMarkup(k.createAbuseKeyboardRow(toId))
+}
+
+func (k *Keyboards) createAbuseKeyboardRow(toId int64) []tgbotapi.InlineKeyboardButton {
+	id := strconv.FormatInt(toId, 10)
+	row := k.appendButton(nil, "🚫 Пожаловаться", CallbackReport, id)
+	return k.appendButton(row, "Заблокировать", CallbackBlock, id)
+}
+
+// CreateColumnKeyboardMarkup creates a button per row, each of them sends its own text as input.
+func (k *Keyboards) CreateColumnKeyboardMarkup(choices ...string) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(choices))
+	for _, choice := range choices {
+		rows = append(rows, k.appendInputButton(nil, choice))
+	}
+
+	return newMarkup(rows...)
+}
+
+const (
+	// DeleteConfirm and DeleteCancel are the payloads of the /delete confirmation
+	DeleteConfirm = "confirm"
+	DeleteCancel  = "cancel"
+)
+
+func (k *Keyboards) CreateDeleteKeyboardMarkup() tgbotapi.InlineKeyboardMarkup {
+	row := k.appendButton(nil, "Удалить", CallbackDelete, DeleteConfirm)
+	return newMarkup(k.appendButton(row, "Отмена", CallbackDelete, DeleteCancel))
+}
+
+// Review actions of the moderation queue
+const (
+	ReviewActionApprove = "approve"
+	ReviewActionBan     = "ban"
+	ReviewActionDismiss = "dismiss"
+)
+
+// CreateReviewKeyboardMarkup returns the moderator decisions about userId.
+func (k *Keyboards) CreateReviewKeyboardMarkup(userId int64) tgbotapi.InlineKeyboardMarkup {
+	id := strconv.FormatInt(userId, 10)
+	decisionRow := k.appendButton(nil, "✅ Одобрить", CallbackReview, ReviewActionApprove+";"+id)
+	decisionRow = k.appendButton(decisionRow, "⛔ Забанить", CallbackReview, ReviewActionBan+";"+id)
 
-	return tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(likeData, dislikeData),
+	return newMarkup(
+		decisionRow,
+		k.appendButton(nil, "✖ Закрыть без решения", CallbackReview, ReviewActionDismiss+";"+id),
 	)
 }
 
+// ParseReviewAction parses the payload of CreateReviewKeyboardMarkup.
+func ParseReviewAction(payload string) (action string, userId int64, ok bool) {
+	parts := strings.Split(payload, ";")
+	if len(parts) != 2 {
+		return "", 0, false
+	}
+
+	userId, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+
+	switch parts[0] {
+	case ReviewActionApprove, ReviewActionBan, ReviewActionDismiss:
+		return parts[0], userId, true
+	}
+
+	return "", 0, false
+}
+
+// CreateMatchesKeyboardMarkup returns navigation buttons for the page of /matches, or nil if there is only one page.
+func (k *Keyboards) CreateMatchesKeyboardMarkup(page, total int) *tgbotapi.InlineKeyboardMarkup {
+	buttons := make([]tgbotapi.InlineKeyboardButton, 0, 2)
+	if page > 0 {
+		buttons = k.appendButton(buttons, "⬅", CallbackMatches, strconv.Itoa(page-1))
+	}
+	if page < total-1 {
+		buttons = k.appendButton(buttons, "➡", CallbackMatches, strconv.Itoa(page+1))
+	}
+
+	if len(buttons) == 0 {
+		return nil
+	}
+
+	markup := newMarkup(buttons)
+	return &markup
+}
+
 func CreateMyProfileCaption(user *models.User) string {
 	return CreateProfileCaption(user) + "\n\nПопробуйте ввести команду /next"
 }
 
+// CreateProfileCaption escapes the fields typed by the user, otherwise Telegram refuses to parse the caption.
 func CreateProfileCaption(user *models.User) string {
-	sex := ""
-	if user.Sex {
-		sex = "Мужчина"
-	} else {
-		sex = "Женщина"
-	}
-
 	caption := fmt.Sprintf("*Имя:* %s\n"+
 		"*Возраст:* %d\n"+
 		"*Город:* %s\n"+
 		"*Описание:* %s\n"+
-		"*Пол:* %s", user.Name, user.Age, user.City, user.Description, sex)
+		"*Пол:* %s",
+		escapeMarkdown(user.Name), user.Age, escapeMarkdown(user.City), escapeMarkdown(user.Description), genderNames[user.Gender])
 	return caption
 }
 
+func CreateSettingsCaption(user *models.User) string {
+	age := "любой"
+	switch {
+	case user.MinAge > 0 && user.MaxAge > 0:
+		age = fmt.Sprintf("от %d до %d", user.MinAge, user.MaxAge)
+	case user.MinAge > 0:
+		age = fmt.Sprintf("от %d", user.MinAge)
+	case user.MaxAge > 0:
+		age = fmt.Sprintf("до %d", user.MaxAge)
+	}
+
+	city := "любой"
+	if user.CityOnly {
+		city = "только " + escapeMarkdown(user.City)
+	}
+
+	interestedIn := make([]string, 0, len(models.Genders))
+	for _, gender := range models.Genders {
+		if user.InterestedIn&gender != 0 {
+			interestedIn = append(interestedIn, genderPluralNames[gender])
+		}
+	}
+
+	return fmt.Sprintf("Настройки поиска сохранены.\n\n"+
+		"*Возраст:* %s\n"+
+		"*Город:* %s\n"+
+		"*Показывать:* %s", age, city, strings.Join(interestedIn, ", "))
+}
+
 func CreateMatchCaption(user *models.User) string {
-	return "Поздравляем! У Вас совпадание с @" + user.Id + "\nМожете связаться в личных сообщениях☺\n\n" + CreateProfileCaption(user)
+	return "Поздравляем! У Вас совпадание с " + CreateUserMention(user) + "\nМожете связаться в личных сообщениях☺\n\n" + CreateProfileCaption(user)
+}
+
+func CreateMatchesCaption(user *models.User, page, total int) string {
+	return fmt.Sprintf("*Совпадение %d из %d*\n\n", page+1, total) + CreateProfileCaption(user) + "\n\n*Контакт:* " + CreateUserMention(user)
+}
+
+func CreateLikesCaption(user *models.User, count int) string {
+	return fmt.Sprintf("*Вы понравились пользователям:* %d\n\n", count) + CreateProfileCaption(user)
+}
+
+// CreateReviewCaption describes why the profile is in the moderation queue: its reports, or new photos if there are none.
+func CreateReviewCaption(user *models.User, reports []*models.Report) string {
+	caption := "*Новые фотографии*"
+	if len(reports) > 0 {
+		reasons := make([]string, 0, len(reports))
+		for _, report := range reports {
+			reasons = append(reasons, "- "+escapeMarkdown(report.Reason))
+		}
+		caption = fmt.Sprintf("*Жалоб:* %d\n%s", len(reports), strings.Join(reasons, "\n"))
+	}
+
+	return caption + "\n\n" + CreateProfileCaption(user) + "\n\n*Контакт:* " + CreateUserMention(user)
+}
+
+// CreateUserMention returns @username or, for users without one, a markdown link by Telegram id.
+func CreateUserMention(user *models.User) string {
+	if len(user.Username) > 0 {
+		return "@" + escapeMarkdown(user.Username)
+	}
+
+	return fmt.Sprintf("[%s](tg://user?id=%d)", escapeMarkdown(user.Name), user.Id)
+}
+
+func escapeMarkdown(text string) string {
+	return tgbotapi.EscapeText(tgbotapi.ModeMarkdown, text)
 }
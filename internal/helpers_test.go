@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"strings"
+	"testing"
+)
+
+func TestCreateUserMention(t *testing.T) {
+	cases := []struct {
+		name     string
+		user     *models.User
+		expected string
+	}{
+		{"username", &models.User{Id: 1, Username: "masha_k", Name: "Masha"}, "@masha\\_k"},
+		{"name", &models.User{Id: 1, Name: "Masha"}, "[Masha](tg://user?id=1)"},
+		{"name with markdown", &models.User{Id: 1, Name: "*Ma_sha`"}, "[\\*Ma\\_sha\\`](tg://user?id=1)"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.EqualValues(t, c.expected, CreateUserMention(c.user))
+		})
+	}
+}
+
+func TestCreateProfileCaption_ShouldEscapeMarkdown(t *testing.T) {
+	user := &models.User{Name: "*Ma_sha`", Age: 20, City: "[spb", Description: "_about_", Gender: models.GenderFemale}
+
+	caption := CreateProfileCaption(user)
+	assert.Contains(t, caption, "*Имя:* \\*Ma\\_sha\\`\n")
+	assert.Contains(t, caption, "*Город:* \\[spb\n")
+	assert.Contains(t, caption, "*Описание:* \\_about\\_\n")
+}
+
+func TestCreateSettingsCaption_ShouldEscapeMarkdown(t *testing.T) {
+	caption := CreateSettingsCaption(&models.User{City: "san_francisco", CityOnly: true})
+	assert.Contains(t, caption, "только san\\_francisco")
+}
+
+func TestKeyboards_ShouldSkipButtonsWhichCantBeEncoded(t *testing.T) {
+	keyboards := NewKeyboards(NewCallbackCodec([]byte("key")), zap.NewNop().Sugar())
+	tooLong := strings.Repeat("a", MaxCallbackPayload+1)
+
+	markup := keyboards.CreateColumnKeyboardMarkup("Да", tooLong, "Нет")
+	assert.Len(t, markup.InlineKeyboard, 2)
+	assert.EqualValues(t, "Да", markup.InlineKeyboard[0][0].Text)
+	assert.EqualValues(t, "Нет", markup.InlineKeyboard[1][0].Text)
+
+	markup = keyboards.CreateChoiceKeyboardMarkup(tooLong)
+	assert.Empty(t, markup.InlineKeyboard)
+}
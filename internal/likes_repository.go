@@ -4,11 +4,20 @@ package internal
 import (
 	"context"
 	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"time"
 )
 
 type LikesRepository interface {
 	Add(context.Context, *models.Like) error
-	Get(context.Context, string, string) (*models.Like, error)
+	Get(context.Context, int64, int64) (*models.Like, error)
+	GetLast(ctx context.Context, fromId int64) (*models.Like, error)
+	GetByUserId(ctx context.Context, userId int64, limit, offset int) ([]*models.Like, error)
+	GetMatches(ctx context.Context, userId int64, limit, offset int) ([]*models.Like, error)
+	CountMatches(ctx context.Context, userId int64) (int, error)
+	// CountUnansweredLikes skips likers younger than minAge
+	CountUnansweredLikes(ctx context.Context, userId int64, minAge int) (int, error)
+	// CountLikesSince returns the number of likes of fromId set after since, dislikes aren't counted
+	CountLikesSince(ctx context.Context, fromId int64, since time.Time) (int, error)
 	Update(context.Context, *models.Like) error
 	Delete(context.Context, int64) error
 	DeleteAll(ctx context.Context) error
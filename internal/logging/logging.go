@@ -0,0 +1,23 @@
+// Package logging passes the logger of the current update through context,
+// so every line logged while handling the update carries its fields.
+package logging
+
+import (
+	"context"
+	"go.uber.org/zap"
+)
+
+type ctxKey struct{}
+
+// WithLogger returns ctx carrying log.
+func WithLogger(ctx context.Context, log *zap.SugaredLogger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, log)
+}
+
+// FromContext returns the logger stored by WithLogger, or fallback if there is none.
+func FromContext(ctx context.Context, fallback *zap.SugaredLogger) *zap.SugaredLogger {
+	if log, ok := ctx.Value(ctxKey{}).(*zap.SugaredLogger); ok {
+		return log
+	}
+	return fallback
+}
@@ -0,0 +1,16 @@
+package logging
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	fallback := zaptest.NewLogger(t).Sugar()
+	log := fallback.With("update_id", 1)
+
+	assert.Same(t, fallback, FromContext(context.Background(), fallback))
+	assert.Same(t, log, FromContext(WithLogger(context.Background(), log), fallback))
+}
@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"time"
+)
+
+// PoolStater is implemented by *pgxpool.Pool
+type PoolStater interface {
+	Stat() *pgxpool.Stat
+}
+
+type poolCollector struct {
+	pool PoolStater
+
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	constructingConns    *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+}
+
+// NewPoolCollector reports the connection pool stats at every scrape.
+func NewPoolCollector(pool PoolStater) prometheus.Collector {
+	return &poolCollector{
+		pool: pool,
+
+		acquireCount: prometheus.NewDesc("pgxpool_acquire_total",
+			"Successful connection acquires from the pool.", nil, nil),
+		acquireDuration: prometheus.NewDesc("pgxpool_acquire_duration_seconds_total",
+			"Total time spent acquiring connections from the pool.", nil, nil),
+		canceledAcquireCount: prometheus.NewDesc("pgxpool_canceled_acquire_total",
+			"Acquires canceled by their context.", nil, nil),
+		emptyAcquireCount: prometheus.NewDesc("pgxpool_empty_acquire_total",
+			"Acquires which had to wait for a connection because the pool was empty.", nil, nil),
+		acquiredConns: prometheus.NewDesc("pgxpool_acquired_conns",
+			"Connections currently in use.", nil, nil),
+		idleConns: prometheus.NewDesc("pgxpool_idle_conns",
+			"Idle connections in the pool.", nil, nil),
+		constructingConns: prometheus.NewDesc("pgxpool_constructing_conns",
+			"Connections being established.", nil, nil),
+		totalConns: prometheus.NewDesc("pgxpool_total_conns",
+			"All connections of the pool.", nil, nil),
+		maxConns: prometheus.NewDesc("pgxpool_max_conns",
+			"Maximum size of the pool.", nil, nil),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.canceledAcquireCount
+	ch <- c.emptyAcquireCount
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.constructingConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquireCount, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.constructingConns, prometheus.GaugeValue, float64(stat.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+}
+
+type usersCollector struct {
+	stats   internal.StatsRepository
+	timeout time.Duration
+
+	total  *prometheus.Desc
+	active *prometheus.Desc
+}
+
+// NewUsersCollector counts users in the database at every scrape.
+func NewUsersCollector(stats internal.StatsRepository, timeout time.Duration) prometheus.Collector {
+	return &usersCollector{
+		stats:   stats,
+		timeout: timeout,
+
+		total: prometheus.NewDesc("bot_users",
+			"Registered users.", nil, nil),
+		active: prometheus.NewDesc("bot_active_users",
+			"Users who have started the bot and are shown to others: not paused, hidden or banned.", nil, nil),
+	}
+}
+
+// Describe doesn't use prometheus.DescribeByCollect, which would query the database on registration.
+func (c *usersCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.total
+	ch <- c.active
+}
+
+func (c *usersCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	stats, err := c.stats.Get(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.total, err)
+		ch <- prometheus.NewInvalidMetric(c.active, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(stats.Users))
+	ch <- prometheus.MustNewConstMetric(c.active, prometheus.GaugeValue, float64(stats.Active))
+}
@@ -0,0 +1,148 @@
+// Package metrics exposes Prometheus metrics of the bot.
+// Rates such as likes per minute are derived from the counters by Prometheus, e.g. rate(bot_likes_total[1m]) * 60.
+package metrics
+
+import (
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"time"
+)
+
+// Update types
+const (
+	UpdateMessage  = "message"
+	UpdateCommand  = "command"
+	UpdateCallback = "callback"
+)
+
+// Reasons of the send retries
+const (
+	RetryFlood = "flood"
+	RetryError = "error"
+)
+
+// Like values
+const (
+	LikeValueLike    = "like"
+	LikeValueDislike = "dislike"
+)
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	Updates         *prometheus.CounterVec   // By update type
+	HandlerDuration *prometheus.HistogramVec // By handler: message or callback
+	HandlerErrors   *prometheus.CounterVec   // By handler: message or callback
+	SendFailures    prometheus.Counter
+	SendRetries     *prometheus.CounterVec // By reason: flood or error
+	SendQueue       prometheus.Gauge
+	Likes           *prometheus.CounterVec // By value: like or dislike
+	Matches         prometheus.Counter
+	RateLimited     *prometheus.CounterVec // By budget: commands, swipes or text
+
+	queryDuration *prometheus.HistogramVec
+	queryErrors   *prometheus.CounterVec
+}
+
+// New returns metrics registered in their own registry, together with go runtime and process metrics.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		Updates: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bot_updates_total",
+			Help: "Telegram updates received, by type.",
+		}, []string{"type"}),
+		HandlerDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "bot_handler_duration_seconds",
+			Help:    "Time spent handling an update, by handler.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"handler"}),
+		HandlerErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bot_handler_errors_total",
+			Help: "Updates whose handler returned an error, by handler.",
+		}, []string{"handler"}),
+		SendFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "bot_send_failures_total",
+			Help: "Messages which could not be sent to Telegram.",
+		}),
+		SendRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bot_send_retries_total",
+			Help: "Messages put back into the send queue, by reason: flood (Telegram asked to retry after a delay) or error.",
+		}, []string{"reason"}),
+		SendQueue: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "bot_send_queue_length",
+			Help: "Messages waiting to be sent to Telegram.",
+		}),
+		Likes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bot_likes_total",
+			Help: "Profiles rated by users, by value: like or dislike.",
+		}, []string{"value"}),
+		Matches: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "bot_matches_total",
+			Help: "Mutual likes.",
+		}),
+		RateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bot_rate_limited_updates_total",
+			Help: "Updates dropped because their user exceeded the rate limit, by budget.",
+		}, []string{"budget"}),
+
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Time spent in repository calls, by repository and method.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"repository", "method"}),
+		queryErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "db_query_errors_total",
+			Help: "Repository calls which failed, by repository and method. Missing records aren't counted.",
+		}, []string{"repository", "method"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.Updates,
+		m.HandlerDuration,
+		m.HandlerErrors,
+		m.SendFailures,
+		m.SendRetries,
+		m.SendQueue,
+		m.Likes,
+		m.Matches,
+		m.RateLimited,
+		m.queryDuration,
+		m.queryErrors,
+	)
+
+	return m
+}
+
+// MustRegister adds collectors, e.g. NewPoolCollector, to the registry.
+func (m *Metrics) MustRegister(cs ...prometheus.Collector) {
+	m.registry.MustRegister(cs...)
+}
+
+// Handler serves the metrics in Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHandler records a handled update. Use it as defer m.ObserveHandler(handler, time.Now(), &err).
+func (m *Metrics) ObserveHandler(handler string, start time.Time, err *error) {
+	m.HandlerDuration.WithLabelValues(handler).Observe(time.Since(start).Seconds())
+	if *err != nil {
+		m.HandlerErrors.WithLabelValues(handler).Inc()
+	}
+}
+
+// observeQuery records a repository call. Use it as defer m.observeQuery(repository, method, time.Now(), &err).
+func (m *Metrics) observeQuery(repository, method string, start time.Time, err *error) {
+	m.queryDuration.WithLabelValues(repository, method).Observe(time.Since(start).Seconds())
+	if *err != nil && !errors.Is(*err, models.ErrNoRecord) {
+		m.queryErrors.WithLabelValues(repository, method).Inc()
+	}
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/Eretic431/datingTelegramBot/internal/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUsersRepository_ShouldCountErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedErr := errors.New("some error")
+
+	next := mock.NewMockUsersRepository(ctrl)
+	next.EXPECT().GetByUserId(gomock.Any(), int64(1)).Return(nil, models.ErrNoRecord).Times(1)
+	next.EXPECT().GetByUserId(gomock.Any(), int64(2)).Return(nil, expectedErr).Times(1)
+	next.EXPECT().GetByUserId(gomock.Any(), int64(3)).Return(&models.User{Id: 3}, nil).Times(1)
+
+	m := New()
+	users := NewUsersRepository(next, m)
+
+	_, err := users.GetByUserId(context.Background(), 1)
+	assert.True(t, errors.Is(err, models.ErrNoRecord))
+	_, err = users.GetByUserId(context.Background(), 2)
+	assert.True(t, errors.Is(err, expectedErr))
+	user, err := users.GetByUserId(context.Background(), 3)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 3, user.Id)
+
+	assert.EqualValues(t, 1, testutil.ToFloat64(m.queryErrors.WithLabelValues("users", "GetByUserId")))
+	assert.EqualValues(t, 1, testutil.CollectAndCount(m.queryDuration))
+}
+
+func TestMetrics_ObserveHandler(t *testing.T) {
+	m := New()
+
+	err := errors.New("some error")
+	m.ObserveHandler(UpdateMessage, time.Now(), &err)
+	err = nil
+	m.ObserveHandler(UpdateMessage, time.Now(), &err)
+
+	assert.EqualValues(t, 1, testutil.ToFloat64(m.HandlerErrors.WithLabelValues(UpdateMessage)))
+}
+
+func TestUsersCollector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stats := mock.NewMockStatsRepository(ctrl)
+	stats.EXPECT().Get(gomock.Any()).Return(&models.Stats{Users: 10, Active: 6}, nil).Times(1)
+
+	expected := `
+# HELP bot_active_users Users who have started the bot and are shown to others: not paused, hidden or banned.
+# TYPE bot_active_users gauge
+bot_active_users 6
+# HELP bot_users Registered users.
+# TYPE bot_users gauge
+bot_users 10
+`
+	err := testutil.CollectAndCompare(NewUsersCollector(stats, time.Second), strings.NewReader(expected))
+	assert.Nil(t, err)
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	m := New()
+	m.Likes.WithLabelValues("like").Inc()
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.EqualValues(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `bot_likes_total{value="like"} 1`)
+}
@@ -0,0 +1,303 @@
+package metrics
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"time"
+)
+
+// The repositories below measure the calls of the wrapped ones, see observeQuery.
+
+type usersRepository struct {
+	next internal.UsersRepository
+	m    *Metrics
+}
+
+var _ internal.UsersRepository = &usersRepository{}
+
+func NewUsersRepository(next internal.UsersRepository, m *Metrics) internal.UsersRepository {
+	return &usersRepository{next: next, m: m}
+}
+
+func (r *usersRepository) Add(ctx context.Context, user *models.User) (err error) {
+	defer r.m.observeQuery("users", "Add", time.Now(), &err)
+	return r.next.Add(ctx, user)
+}
+
+func (r *usersRepository) GetByUserId(ctx context.Context, userId int64) (user *models.User, err error) {
+	defer r.m.observeQuery("users", "GetByUserId", time.Now(), &err)
+	return r.next.GetByUserId(ctx, userId)
+}
+
+func (r *usersRepository) Search(ctx context.Context, query string, limit, offset int) (users []*models.User, err error) {
+	defer r.m.observeQuery("users", "Search", time.Now(), &err)
+	return r.next.Search(ctx, query, limit, offset)
+}
+
+func (r *usersRepository) UpdateByUserId(ctx context.Context, user *models.User) (err error) {
+	defer r.m.observeQuery("users", "UpdateByUserId", time.Now(), &err)
+	return r.next.UpdateByUserId(ctx, user)
+}
+
+func (r *usersRepository) DeleteByUserId(ctx context.Context, userId int64) (err error) {
+	defer r.m.observeQuery("users", "DeleteByUserId", time.Now(), &err)
+	return r.next.DeleteByUserId(ctx, userId)
+}
+
+func (r *usersRepository) GetNextUser(ctx context.Context, user *models.User) (next *models.User, err error) {
+	defer r.m.observeQuery("users", "GetNextUser", time.Now(), &err)
+	return r.next.GetNextUser(ctx, user)
+}
+
+func (r *usersRepository) GetNextLiker(ctx context.Context, userId int64, minAge int) (liker *models.User, err error) {
+	defer r.m.observeQuery("users", "GetNextLiker", time.Now(), &err)
+	return r.next.GetNextLiker(ctx, userId, minAge)
+}
+
+func (r *usersRepository) UpdateUsername(ctx context.Context, userId int64, username string) (err error) {
+	defer r.m.observeQuery("users", "UpdateUsername", time.Now(), &err)
+	return r.next.UpdateUsername(ctx, userId, username)
+}
+
+func (r *usersRepository) SetHidden(ctx context.Context, userId int64, hidden bool) (err error) {
+	defer r.m.observeQuery("users", "SetHidden", time.Now(), &err)
+	return r.next.SetHidden(ctx, userId, hidden)
+}
+
+func (r *usersRepository) SetBanned(ctx context.Context, userId int64, banned bool) (err error) {
+	defer r.m.observeQuery("users", "SetBanned", time.Now(), &err)
+	return r.next.SetBanned(ctx, userId, banned)
+}
+
+func (r *usersRepository) DeleteAll(ctx context.Context) (err error) {
+	defer r.m.observeQuery("users", "DeleteAll", time.Now(), &err)
+	return r.next.DeleteAll(ctx)
+}
+
+type likesRepository struct {
+	next internal.LikesRepository
+	m    *Metrics
+}
+
+var _ internal.LikesRepository = &likesRepository{}
+
+func NewLikesRepository(next internal.LikesRepository, m *Metrics) internal.LikesRepository {
+	return &likesRepository{next: next, m: m}
+}
+
+func (r *likesRepository) Add(ctx context.Context, like *models.Like) (err error) {
+	defer r.m.observeQuery("likes", "Add", time.Now(), &err)
+	return r.next.Add(ctx, like)
+}
+
+func (r *likesRepository) Get(ctx context.Context, fromId, toId int64) (like *models.Like, err error) {
+	defer r.m.observeQuery("likes", "Get", time.Now(), &err)
+	return r.next.Get(ctx, fromId, toId)
+}
+
+func (r *likesRepository) GetLast(ctx context.Context, fromId int64) (like *models.Like, err error) {
+	defer r.m.observeQuery("likes", "GetLast", time.Now(), &err)
+	return r.next.GetLast(ctx, fromId)
+}
+
+func (r *likesRepository) GetByUserId(ctx context.Context, userId int64, limit, offset int) (likes []*models.Like, err error) {
+	defer r.m.observeQuery("likes", "GetByUserId", time.Now(), &err)
+	return r.next.GetByUserId(ctx, userId, limit, offset)
+}
+
+func (r *likesRepository) GetMatches(ctx context.Context, userId int64, limit, offset int) (matches []*models.Like, err error) {
+	defer r.m.observeQuery("likes", "GetMatches", time.Now(), &err)
+	return r.next.GetMatches(ctx, userId, limit, offset)
+}
+
+func (r *likesRepository) CountMatches(ctx context.Context, userId int64) (count int, err error) {
+	defer r.m.observeQuery("likes", "CountMatches", time.Now(), &err)
+	return r.next.CountMatches(ctx, userId)
+}
+
+func (r *likesRepository) CountUnansweredLikes(ctx context.Context, userId int64, minAge int) (count int, err error) {
+	defer r.m.observeQuery("likes", "CountUnansweredLikes", time.Now(), &err)
+	return r.next.CountUnansweredLikes(ctx, userId, minAge)
+}
+
+func (r *likesRepository) CountLikesSince(ctx context.Context, fromId int64, since time.Time) (count int, err error) {
+	defer r.m.observeQuery("likes", "CountLikesSince", time.Now(), &err)
+	return r.next.CountLikesSince(ctx, fromId, since)
+}
+
+func (r *likesRepository) Update(ctx context.Context, like *models.Like) (err error) {
+	defer r.m.observeQuery("likes", "Update", time.Now(), &err)
+	return r.next.Update(ctx, like)
+}
+
+func (r *likesRepository) Delete(ctx context.Context, id int64) (err error) {
+	defer r.m.observeQuery("likes", "Delete", time.Now(), &err)
+	return r.next.Delete(ctx, id)
+}
+
+func (r *likesRepository) DeleteAll(ctx context.Context) (err error) {
+	defer r.m.observeQuery("likes", "DeleteAll", time.Now(), &err)
+	return r.next.DeleteAll(ctx)
+}
+
+type photosRepository struct {
+	next internal.PhotosRepository
+	m    *Metrics
+}
+
+var _ internal.PhotosRepository = &photosRepository{}
+
+func NewPhotosRepository(next internal.PhotosRepository, m *Metrics) internal.PhotosRepository {
+	return &photosRepository{next: next, m: m}
+}
+
+func (r *photosRepository) GetByUserId(ctx context.Context, userId int64) (photos []*models.Photo, err error) {
+	defer r.m.observeQuery("photos", "GetByUserId", time.Now(), &err)
+	return r.next.GetByUserId(ctx, userId)
+}
+
+func (r *photosRepository) ReplaceByUserId(ctx context.Context, userId int64, fileIds []string) (err error) {
+	defer r.m.observeQuery("photos", "ReplaceByUserId", time.Now(), &err)
+	return r.next.ReplaceByUserId(ctx, userId, fileIds)
+}
+
+func (r *photosRepository) GetNextUnreviewedUserId(ctx context.Context) (userId int64, err error) {
+	defer r.m.observeQuery("photos", "GetNextUnreviewedUserId", time.Now(), &err)
+	return r.next.GetNextUnreviewedUserId(ctx)
+}
+
+func (r *photosRepository) SetReviewedByUserId(ctx context.Context, userId int64) (err error) {
+	defer r.m.observeQuery("photos", "SetReviewedByUserId", time.Now(), &err)
+	return r.next.SetReviewedByUserId(ctx, userId)
+}
+
+type blocksRepository struct {
+	next internal.BlocksRepository
+	m    *Metrics
+}
+
+var _ internal.BlocksRepository = &blocksRepository{}
+
+func NewBlocksRepository(next internal.BlocksRepository, m *Metrics) internal.BlocksRepository {
+	return &blocksRepository{next: next, m: m}
+}
+
+func (r *blocksRepository) Add(ctx context.Context, fromId, toId int64) (err error) {
+	defer r.m.observeQuery("blocks", "Add", time.Now(), &err)
+	return r.next.Add(ctx, fromId, toId)
+}
+
+func (r *blocksRepository) IsBlocked(ctx context.Context, userId, otherId int64) (blocked bool, err error) {
+	defer r.m.observeQuery("blocks", "IsBlocked", time.Now(), &err)
+	return r.next.IsBlocked(ctx, userId, otherId)
+}
+
+type reportsRepository struct {
+	next internal.ReportsRepository
+	m    *Metrics
+}
+
+var _ internal.ReportsRepository = &reportsRepository{}
+
+func NewReportsRepository(next internal.ReportsRepository, m *Metrics) internal.ReportsRepository {
+	return &reportsRepository{next: next, m: m}
+}
+
+func (r *reportsRepository) Add(ctx context.Context, report *models.Report) (err error) {
+	defer r.m.observeQuery("reports", "Add", time.Now(), &err)
+	return r.next.Add(ctx, report)
+}
+
+func (r *reportsRepository) CountReporters(ctx context.Context, toId int64) (count int, err error) {
+	defer r.m.observeQuery("reports", "CountReporters", time.Now(), &err)
+	return r.next.CountReporters(ctx, toId)
+}
+
+func (r *reportsRepository) GetNextReportedId(ctx context.Context) (userId int64, err error) {
+	defer r.m.observeQuery("reports", "GetNextReportedId", time.Now(), &err)
+	return r.next.GetNextReportedId(ctx)
+}
+
+func (r *reportsRepository) GetUnresolvedByUserId(ctx context.Context, toId int64) (reports []*models.Report, err error) {
+	defer r.m.observeQuery("reports", "GetUnresolvedByUserId", time.Now(), &err)
+	return r.next.GetUnresolvedByUserId(ctx, toId)
+}
+
+func (r *reportsRepository) ResolveByUserId(ctx context.Context, toId int64) (err error) {
+	defer r.m.observeQuery("reports", "ResolveByUserId", time.Now(), &err)
+	return r.next.ResolveByUserId(ctx, toId)
+}
+
+type statsRepository struct {
+	next internal.StatsRepository
+	m    *Metrics
+}
+
+var _ internal.StatsRepository = &statsRepository{}
+
+func NewStatsRepository(next internal.StatsRepository, m *Metrics) internal.StatsRepository {
+	return &statsRepository{next: next, m: m}
+}
+
+func (r *statsRepository) Get(ctx context.Context) (stats *models.Stats, err error) {
+	defer r.m.observeQuery("stats", "Get", time.Now(), &err)
+	return r.next.Get(ctx)
+}
+
+type servedCardsRepository struct {
+	next internal.ServedCardsRepository
+	m    *Metrics
+}
+
+var _ internal.ServedCardsRepository = &servedCardsRepository{}
+
+func NewServedCardsRepository(next internal.ServedCardsRepository, m *Metrics) internal.ServedCardsRepository {
+	return &servedCardsRepository{next: next, m: m}
+}
+
+func (r *servedCardsRepository) Add(ctx context.Context, userId, toId int64) (err error) {
+	defer r.m.observeQuery("served_cards", "Add", time.Now(), &err)
+	return r.next.Add(ctx, userId, toId)
+}
+
+func (r *servedCardsRepository) Delete(ctx context.Context, userId, toId int64) (err error) {
+	defer r.m.observeQuery("served_cards", "Delete", time.Now(), &err)
+	return r.next.Delete(ctx, userId, toId)
+}
+
+func (r *servedCardsRepository) GetServedAt(ctx context.Context, userId, toId int64) (servedAt time.Time, err error) {
+	defer r.m.observeQuery("served_cards", "GetServedAt", time.Now(), &err)
+	return r.next.GetServedAt(ctx, userId, toId)
+}
+
+func (r *servedCardsRepository) DeleteExpired(ctx context.Context, ttl time.Duration) (deleted int64, err error) {
+	defer r.m.observeQuery("served_cards", "DeleteExpired", time.Now(), &err)
+	return r.next.DeleteExpired(ctx, ttl)
+}
+
+type outboxRepository struct {
+	next internal.OutboxRepository
+	m    *Metrics
+}
+
+var _ internal.OutboxRepository = &outboxRepository{}
+
+func NewOutboxRepository(next internal.OutboxRepository, m *Metrics) internal.OutboxRepository {
+	return &outboxRepository{next: next, m: m}
+}
+
+func (r *outboxRepository) Add(ctx context.Context, message *models.OutboxMessage) (err error) {
+	defer r.m.observeQuery("outbox", "Add", time.Now(), &err)
+	return r.next.Add(ctx, message)
+}
+
+func (r *outboxRepository) Delete(ctx context.Context, id int64) (err error) {
+	defer r.m.observeQuery("outbox", "Delete", time.Now(), &err)
+	return r.next.Delete(ctx, id)
+}
+
+func (r *outboxRepository) GetAll(ctx context.Context) (messages []*models.OutboxMessage, err error) {
+	defer r.m.observeQuery("outbox", "GetAll", time.Now(), &err)
+	return r.next.GetAll(ctx)
+}
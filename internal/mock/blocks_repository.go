@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: blocks_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBlocksRepository is a mock of BlocksRepository interface.
+type MockBlocksRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlocksRepositoryMockRecorder
+}
+
+// MockBlocksRepositoryMockRecorder is the mock recorder for MockBlocksRepository.
+type MockBlocksRepositoryMockRecorder struct {
+	mock *MockBlocksRepository
+}
+
+// NewMockBlocksRepository creates a new mock instance.
+func NewMockBlocksRepository(ctrl *gomock.Controller) *MockBlocksRepository {
+	mock := &MockBlocksRepository{ctrl: ctrl}
+	mock.recorder = &MockBlocksRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlocksRepository) EXPECT() *MockBlocksRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockBlocksRepository) Add(ctx context.Context, fromId, toId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, fromId, toId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockBlocksRepositoryMockRecorder) Add(ctx, fromId, toId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockBlocksRepository)(nil).Add), ctx, fromId, toId)
+}
+
+// IsBlocked mocks base method.
+func (m *MockBlocksRepository) IsBlocked(ctx context.Context, userId, otherId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBlocked", ctx, userId, otherId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBlocked indicates an expected call of IsBlocked.
+func (mr *MockBlocksRepositoryMockRecorder) IsBlocked(ctx, userId, otherId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlocked", reflect.TypeOf((*MockBlocksRepository)(nil).IsBlocked), ctx, userId, otherId)
+}
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/Eretic431/datingTelegramBot/internal/data/models"
 	gomock "github.com/golang/mock/gomock"
@@ -49,6 +50,51 @@ func (mr *MockLikesRepositoryMockRecorder) Add(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockLikesRepository)(nil).Add), arg0, arg1)
 }
 
+// CountLikesSince mocks base method.
+func (m *MockLikesRepository) CountLikesSince(ctx context.Context, fromId int64, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountLikesSince", ctx, fromId, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountLikesSince indicates an expected call of CountLikesSince.
+func (mr *MockLikesRepositoryMockRecorder) CountLikesSince(ctx, fromId, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountLikesSince", reflect.TypeOf((*MockLikesRepository)(nil).CountLikesSince), ctx, fromId, since)
+}
+
+// CountMatches mocks base method.
+func (m *MockLikesRepository) CountMatches(ctx context.Context, userId int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMatches", ctx, userId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMatches indicates an expected call of CountMatches.
+func (mr *MockLikesRepositoryMockRecorder) CountMatches(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMatches", reflect.TypeOf((*MockLikesRepository)(nil).CountMatches), ctx, userId)
+}
+
+// CountUnansweredLikes mocks base method.
+func (m *MockLikesRepository) CountUnansweredLikes(ctx context.Context, userId int64, minAge int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnansweredLikes", ctx, userId, minAge)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnansweredLikes indicates an expected call of CountUnansweredLikes.
+func (mr *MockLikesRepositoryMockRecorder) CountUnansweredLikes(ctx, userId, minAge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnansweredLikes", reflect.TypeOf((*MockLikesRepository)(nil).CountUnansweredLikes), ctx, userId, minAge)
+}
+
 // Delete mocks base method.
 func (m *MockLikesRepository) Delete(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -78,7 +124,7 @@ func (mr *MockLikesRepositoryMockRecorder) DeleteAll(ctx interface{}) *gomock.Ca
 }
 
 // Get mocks base method.
-func (m *MockLikesRepository) Get(arg0 context.Context, arg1, arg2 string) (*models.Like, error) {
+func (m *MockLikesRepository) Get(arg0 context.Context, arg1, arg2 int64) (*models.Like, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*models.Like)
@@ -92,6 +138,51 @@ func (mr *MockLikesRepositoryMockRecorder) Get(arg0, arg1, arg2 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLikesRepository)(nil).Get), arg0, arg1, arg2)
 }
 
+// GetByUserId mocks base method.
+func (m *MockLikesRepository) GetByUserId(ctx context.Context, userId int64, limit, offset int) ([]*models.Like, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserId", ctx, userId, limit, offset)
+	ret0, _ := ret[0].([]*models.Like)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserId indicates an expected call of GetByUserId.
+func (mr *MockLikesRepositoryMockRecorder) GetByUserId(ctx, userId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserId", reflect.TypeOf((*MockLikesRepository)(nil).GetByUserId), ctx, userId, limit, offset)
+}
+
+// GetLast mocks base method.
+func (m *MockLikesRepository) GetLast(ctx context.Context, fromId int64) (*models.Like, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLast", ctx, fromId)
+	ret0, _ := ret[0].(*models.Like)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLast indicates an expected call of GetLast.
+func (mr *MockLikesRepositoryMockRecorder) GetLast(ctx, fromId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLast", reflect.TypeOf((*MockLikesRepository)(nil).GetLast), ctx, fromId)
+}
+
+// GetMatches mocks base method.
+func (m *MockLikesRepository) GetMatches(ctx context.Context, userId int64, limit, offset int) ([]*models.Like, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMatches", ctx, userId, limit, offset)
+	ret0, _ := ret[0].([]*models.Like)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMatches indicates an expected call of GetMatches.
+func (mr *MockLikesRepositoryMockRecorder) GetMatches(ctx, userId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatches", reflect.TypeOf((*MockLikesRepository)(nil).GetMatches), ctx, userId, limit, offset)
+}
+
 // Update mocks base method.
 func (m *MockLikesRepository) Update(arg0 context.Context, arg1 *models.Like) error {
 	m.ctrl.T.Helper()
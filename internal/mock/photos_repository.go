@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: photos_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/Eretic431/datingTelegramBot/internal/data/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPhotosRepository is a mock of PhotosRepository interface.
+type MockPhotosRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPhotosRepositoryMockRecorder
+}
+
+// MockPhotosRepositoryMockRecorder is the mock recorder for MockPhotosRepository.
+type MockPhotosRepositoryMockRecorder struct {
+	mock *MockPhotosRepository
+}
+
+// NewMockPhotosRepository creates a new mock instance.
+func NewMockPhotosRepository(ctrl *gomock.Controller) *MockPhotosRepository {
+	mock := &MockPhotosRepository{ctrl: ctrl}
+	mock.recorder = &MockPhotosRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPhotosRepository) EXPECT() *MockPhotosRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByUserId mocks base method.
+func (m *MockPhotosRepository) GetByUserId(ctx context.Context, userId int64) ([]*models.Photo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserId", ctx, userId)
+	ret0, _ := ret[0].([]*models.Photo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserId indicates an expected call of GetByUserId.
+func (mr *MockPhotosRepositoryMockRecorder) GetByUserId(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserId", reflect.TypeOf((*MockPhotosRepository)(nil).GetByUserId), ctx, userId)
+}
+
+// GetNextUnreviewedUserId mocks base method.
+func (m *MockPhotosRepository) GetNextUnreviewedUserId(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextUnreviewedUserId", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextUnreviewedUserId indicates an expected call of GetNextUnreviewedUserId.
+func (mr *MockPhotosRepositoryMockRecorder) GetNextUnreviewedUserId(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextUnreviewedUserId", reflect.TypeOf((*MockPhotosRepository)(nil).GetNextUnreviewedUserId), ctx)
+}
+
+// ReplaceByUserId mocks base method.
+func (m *MockPhotosRepository) ReplaceByUserId(ctx context.Context, userId int64, fileIds []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceByUserId", ctx, userId, fileIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceByUserId indicates an expected call of ReplaceByUserId.
+func (mr *MockPhotosRepositoryMockRecorder) ReplaceByUserId(ctx, userId, fileIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceByUserId", reflect.TypeOf((*MockPhotosRepository)(nil).ReplaceByUserId), ctx, userId, fileIds)
+}
+
+// SetReviewedByUserId mocks base method.
+func (m *MockPhotosRepository) SetReviewedByUserId(ctx context.Context, userId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReviewedByUserId", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReviewedByUserId indicates an expected call of SetReviewedByUserId.
+func (mr *MockPhotosRepositoryMockRecorder) SetReviewedByUserId(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReviewedByUserId", reflect.TypeOf((*MockPhotosRepository)(nil).SetReviewedByUserId), ctx, userId)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reports_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/Eretic431/datingTelegramBot/internal/data/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReportsRepository is a mock of ReportsRepository interface.
+type MockReportsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportsRepositoryMockRecorder
+}
+
+// MockReportsRepositoryMockRecorder is the mock recorder for MockReportsRepository.
+type MockReportsRepositoryMockRecorder struct {
+	mock *MockReportsRepository
+}
+
+// NewMockReportsRepository creates a new mock instance.
+func NewMockReportsRepository(ctrl *gomock.Controller) *MockReportsRepository {
+	mock := &MockReportsRepository{ctrl: ctrl}
+	mock.recorder = &MockReportsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportsRepository) EXPECT() *MockReportsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockReportsRepository) Add(ctx context.Context, report *models.Report) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockReportsRepositoryMockRecorder) Add(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockReportsRepository)(nil).Add), ctx, report)
+}
+
+// CountReporters mocks base method.
+func (m *MockReportsRepository) CountReporters(ctx context.Context, toId int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountReporters", ctx, toId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountReporters indicates an expected call of CountReporters.
+func (mr *MockReportsRepositoryMockRecorder) CountReporters(ctx, toId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReporters", reflect.TypeOf((*MockReportsRepository)(nil).CountReporters), ctx, toId)
+}
+
+// GetNextReportedId mocks base method.
+func (m *MockReportsRepository) GetNextReportedId(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextReportedId", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextReportedId indicates an expected call of GetNextReportedId.
+func (mr *MockReportsRepositoryMockRecorder) GetNextReportedId(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextReportedId", reflect.TypeOf((*MockReportsRepository)(nil).GetNextReportedId), ctx)
+}
+
+// GetUnresolvedByUserId mocks base method.
+func (m *MockReportsRepository) GetUnresolvedByUserId(ctx context.Context, toId int64) ([]*models.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnresolvedByUserId", ctx, toId)
+	ret0, _ := ret[0].([]*models.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnresolvedByUserId indicates an expected call of GetUnresolvedByUserId.
+func (mr *MockReportsRepositoryMockRecorder) GetUnresolvedByUserId(ctx, toId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnresolvedByUserId", reflect.TypeOf((*MockReportsRepository)(nil).GetUnresolvedByUserId), ctx, toId)
+}
+
+// ResolveByUserId mocks base method.
+func (m *MockReportsRepository) ResolveByUserId(ctx context.Context, toId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByUserId", ctx, toId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveByUserId indicates an expected call of ResolveByUserId.
+func (mr *MockReportsRepositoryMockRecorder) ResolveByUserId(ctx, toId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByUserId", reflect.TypeOf((*MockReportsRepository)(nil).ResolveByUserId), ctx, toId)
+}
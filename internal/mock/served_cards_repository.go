@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: served_cards_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockServedCardsRepository is a mock of ServedCardsRepository interface.
+type MockServedCardsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockServedCardsRepositoryMockRecorder
+}
+
+// MockServedCardsRepositoryMockRecorder is the mock recorder for MockServedCardsRepository.
+type MockServedCardsRepositoryMockRecorder struct {
+	mock *MockServedCardsRepository
+}
+
+// NewMockServedCardsRepository creates a new mock instance.
+func NewMockServedCardsRepository(ctrl *gomock.Controller) *MockServedCardsRepository {
+	mock := &MockServedCardsRepository{ctrl: ctrl}
+	mock.recorder = &MockServedCardsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServedCardsRepository) EXPECT() *MockServedCardsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockServedCardsRepository) Add(ctx context.Context, userId, toId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, userId, toId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockServedCardsRepositoryMockRecorder) Add(ctx, userId, toId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockServedCardsRepository)(nil).Add), ctx, userId, toId)
+}
+
+// Delete mocks base method.
+func (m *MockServedCardsRepository) Delete(ctx context.Context, userId, toId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, toId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockServedCardsRepositoryMockRecorder) Delete(ctx, userId, toId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockServedCardsRepository)(nil).Delete), ctx, userId, toId)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockServedCardsRepository) DeleteExpired(ctx context.Context, ttl time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, ttl)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockServedCardsRepositoryMockRecorder) DeleteExpired(ctx, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockServedCardsRepository)(nil).DeleteExpired), ctx, ttl)
+}
+
+// GetServedAt mocks base method.
+func (m *MockServedCardsRepository) GetServedAt(ctx context.Context, userId, toId int64) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServedAt", ctx, userId, toId)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServedAt indicates an expected call of GetServedAt.
+func (mr *MockServedCardsRepositoryMockRecorder) GetServedAt(ctx, userId, toId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServedAt", reflect.TypeOf((*MockServedCardsRepository)(nil).GetServedAt), ctx, userId, toId)
+}
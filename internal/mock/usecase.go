@@ -9,7 +9,7 @@ import (
 	reflect "reflect"
 
 	models "github.com/Eretic431/datingTelegramBot/internal/data/models"
-	v5 "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -36,11 +36,186 @@ func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
 	return m.recorder
 }
 
+// AddOrUpdateLike mocks base method.
+func (m *MockUsecase) AddOrUpdateLike(ctx context.Context, likeValue bool, fromId, toId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOrUpdateLike", ctx, likeValue, fromId, toId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddOrUpdateLike indicates an expected call of AddOrUpdateLike.
+func (mr *MockUsecaseMockRecorder) AddOrUpdateLike(ctx, likeValue, fromId, toId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrUpdateLike", reflect.TypeOf((*MockUsecase)(nil).AddOrUpdateLike), ctx, likeValue, fromId, toId)
+}
+
+// AddTestUser mocks base method.
+func (m *MockUsecase) AddTestUser(ctx context.Context, sex bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTestUser", ctx, sex)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTestUser indicates an expected call of AddTestUser.
+func (mr *MockUsecaseMockRecorder) AddTestUser(ctx, sex interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTestUser", reflect.TypeOf((*MockUsecase)(nil).AddTestUser), ctx, sex)
+}
+
+// AddTestUserWithLike mocks base method.
+func (m *MockUsecase) AddTestUserWithLike(ctx context.Context, sex bool, toId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTestUserWithLike", ctx, sex, toId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTestUserWithLike indicates an expected call of AddTestUserWithLike.
+func (mr *MockUsecaseMockRecorder) AddTestUserWithLike(ctx, sex, toId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTestUserWithLike", reflect.TypeOf((*MockUsecase)(nil).AddTestUserWithLike), ctx, sex, toId)
+}
+
+// CreateLikeNotification mocks base method.
+func (m *MockUsecase) CreateLikeNotification(ctx context.Context, toUser *models.User) (tgbotapi.Chattable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLikeNotification", ctx, toUser)
+	ret0, _ := ret[0].(tgbotapi.Chattable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLikeNotification indicates an expected call of CreateLikeNotification.
+func (mr *MockUsecaseMockRecorder) CreateLikeNotification(ctx, toUser interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLikeNotification", reflect.TypeOf((*MockUsecase)(nil).CreateLikeNotification), ctx, toUser)
+}
+
+// CreateMatchMessages mocks base method.
+func (m *MockUsecase) CreateMatchMessages(ctx context.Context, user1, user2 *models.User) ([]tgbotapi.Chattable, []tgbotapi.Chattable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMatchMessages", ctx, user1, user2)
+	ret0, _ := ret[0].([]tgbotapi.Chattable)
+	ret1, _ := ret[1].([]tgbotapi.Chattable)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateMatchMessages indicates an expected call of CreateMatchMessages.
+func (mr *MockUsecaseMockRecorder) CreateMatchMessages(ctx, user1, user2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMatchMessages", reflect.TypeOf((*MockUsecase)(nil).CreateMatchMessages), ctx, user1, user2)
+}
+
+// DeleteAll mocks base method.
+func (m *MockUsecase) DeleteAll(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAll", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAll indicates an expected call of DeleteAll.
+func (mr *MockUsecaseMockRecorder) DeleteAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*MockUsecase)(nil).DeleteAll), ctx)
+}
+
+// DeleteExpiredCards mocks base method.
+func (m *MockUsecase) DeleteExpiredCards(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredCards", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredCards indicates an expected call of DeleteExpiredCards.
+func (mr *MockUsecaseMockRecorder) DeleteExpiredCards(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredCards", reflect.TypeOf((*MockUsecase)(nil).DeleteExpiredCards), ctx)
+}
+
+// DialogReminder mocks base method.
+func (m *MockUsecase) DialogReminder(chatId int64, user *models.User) tgbotapi.Chattable {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DialogReminder", chatId, user)
+	ret0, _ := ret[0].(tgbotapi.Chattable)
+	return ret0
+}
+
+// DialogReminder indicates an expected call of DialogReminder.
+func (mr *MockUsecaseMockRecorder) DialogReminder(chatId, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DialogReminder", reflect.TypeOf((*MockUsecase)(nil).DialogReminder), chatId, user)
+}
+
+// GetUserByIdOrNil mocks base method.
+func (m *MockUsecase) GetUserByIdOrNil(ctx context.Context, userId int64) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByIdOrNil", ctx, userId)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByIdOrNil indicates an expected call of GetUserByIdOrNil.
+func (mr *MockUsecaseMockRecorder) GetUserByIdOrNil(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByIdOrNil", reflect.TypeOf((*MockUsecase)(nil).GetUserByIdOrNil), ctx, userId)
+}
+
+// HandleAdmin mocks base method.
+func (m *MockUsecase) HandleAdmin(ctx context.Context, chatId int64, user *models.User) ([]tgbotapi.Chattable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleAdmin", ctx, chatId, user)
+	ret0, _ := ret[0].([]tgbotapi.Chattable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleAdmin indicates an expected call of HandleAdmin.
+func (mr *MockUsecaseMockRecorder) HandleAdmin(ctx, chatId, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleAdmin", reflect.TypeOf((*MockUsecase)(nil).HandleAdmin), ctx, chatId, user)
+}
+
+// HandleBlock mocks base method.
+func (m *MockUsecase) HandleBlock(ctx context.Context, chatId int64, user *models.User, toId int64) (tgbotapi.MessageConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleBlock", ctx, chatId, user, toId)
+	ret0, _ := ret[0].(tgbotapi.MessageConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleBlock indicates an expected call of HandleBlock.
+func (mr *MockUsecaseMockRecorder) HandleBlock(ctx, chatId, user, toId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlock", reflect.TypeOf((*MockUsecase)(nil).HandleBlock), ctx, chatId, user, toId)
+}
+
+// HandleCancel mocks base method.
+func (m *MockUsecase) HandleCancel(ctx context.Context, chatId int64, user *models.User) (tgbotapi.Chattable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleCancel", ctx, chatId, user)
+	ret0, _ := ret[0].(tgbotapi.Chattable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleCancel indicates an expected call of HandleCancel.
+func (mr *MockUsecaseMockRecorder) HandleCancel(ctx, chatId, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCancel", reflect.TypeOf((*MockUsecase)(nil).HandleCancel), ctx, chatId, user)
+}
+
 // HandleCommandNext mocks base method.
-func (m *MockUsecase) HandleCommandNext(arg0 context.Context, arg1 int64, arg2 *models.User) (v5.Chattable, error) {
+func (m *MockUsecase) HandleCommandNext(arg0 context.Context, arg1 int64, arg2 *models.User) ([]tgbotapi.Chattable, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "HandleCommandNext", arg0, arg1, arg2)
-	ret0, _ := ret[0].(v5.Chattable)
+	ret0, _ := ret[0].([]tgbotapi.Chattable)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -51,11 +226,71 @@ func (mr *MockUsecaseMockRecorder) HandleCommandNext(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCommandNext", reflect.TypeOf((*MockUsecase)(nil).HandleCommandNext), arg0, arg1, arg2)
 }
 
+// HandleDelete mocks base method.
+func (m *MockUsecase) HandleDelete(ctx context.Context, chatId int64, user *models.User) (tgbotapi.MessageConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleDelete", ctx, chatId, user)
+	ret0, _ := ret[0].(tgbotapi.MessageConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleDelete indicates an expected call of HandleDelete.
+func (mr *MockUsecaseMockRecorder) HandleDelete(ctx, chatId, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDelete", reflect.TypeOf((*MockUsecase)(nil).HandleDelete), ctx, chatId, user)
+}
+
+// HandleDeleteConfirm mocks base method.
+func (m *MockUsecase) HandleDeleteConfirm(ctx context.Context, chatId int64, user *models.User, confirmed bool) (tgbotapi.MessageConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleDeleteConfirm", ctx, chatId, user, confirmed)
+	ret0, _ := ret[0].(tgbotapi.MessageConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleDeleteConfirm indicates an expected call of HandleDeleteConfirm.
+func (mr *MockUsecaseMockRecorder) HandleDeleteConfirm(ctx, chatId, user, confirmed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDeleteConfirm", reflect.TypeOf((*MockUsecase)(nil).HandleDeleteConfirm), ctx, chatId, user, confirmed)
+}
+
+// HandleEdit mocks base method.
+func (m *MockUsecase) HandleEdit(ctx context.Context, chatId int64, user *models.User) (tgbotapi.MessageConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleEdit", ctx, chatId, user)
+	ret0, _ := ret[0].(tgbotapi.MessageConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleEdit indicates an expected call of HandleEdit.
+func (mr *MockUsecaseMockRecorder) HandleEdit(ctx, chatId, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEdit", reflect.TypeOf((*MockUsecase)(nil).HandleEdit), ctx, chatId, user)
+}
+
+// HandleEditField mocks base method.
+func (m *MockUsecase) HandleEditField(ctx context.Context, chatId int64, user *models.User, stage int) (tgbotapi.MessageConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleEditField", ctx, chatId, user, stage)
+	ret0, _ := ret[0].(tgbotapi.MessageConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleEditField indicates an expected call of HandleEditField.
+func (mr *MockUsecaseMockRecorder) HandleEditField(ctx, chatId, user, stage interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEditField", reflect.TypeOf((*MockUsecase)(nil).HandleEditField), ctx, chatId, user, stage)
+}
+
 // HandleFillingProfile mocks base method.
-func (m *MockUsecase) HandleFillingProfile(arg0 context.Context, arg1 string, arg2 int64, arg3 string, arg4 *models.User) (v5.Chattable, error) {
+func (m *MockUsecase) HandleFillingProfile(arg0 context.Context, arg1 string, arg2 int64, arg3 string, arg4 *models.User) (tgbotapi.Chattable, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "HandleFillingProfile", arg0, arg1, arg2, arg3, arg4)
-	ret0, _ := ret[0].(v5.Chattable)
+	ret0, _ := ret[0].(tgbotapi.Chattable)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -66,11 +301,56 @@ func (mr *MockUsecaseMockRecorder) HandleFillingProfile(arg0, arg1, arg2, arg3,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleFillingProfile", reflect.TypeOf((*MockUsecase)(nil).HandleFillingProfile), arg0, arg1, arg2, arg3, arg4)
 }
 
+// HandleLikes mocks base method.
+func (m *MockUsecase) HandleLikes(ctx context.Context, chatId int64, user *models.User) (tgbotapi.Chattable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleLikes", ctx, chatId, user)
+	ret0, _ := ret[0].(tgbotapi.Chattable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleLikes indicates an expected call of HandleLikes.
+func (mr *MockUsecaseMockRecorder) HandleLikes(ctx, chatId, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleLikes", reflect.TypeOf((*MockUsecase)(nil).HandleLikes), ctx, chatId, user)
+}
+
+// HandleMatches mocks base method.
+func (m *MockUsecase) HandleMatches(ctx context.Context, chatId int64, user *models.User, page int) (tgbotapi.Chattable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleMatches", ctx, chatId, user, page)
+	ret0, _ := ret[0].(tgbotapi.Chattable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleMatches indicates an expected call of HandleMatches.
+func (mr *MockUsecaseMockRecorder) HandleMatches(ctx, chatId, user, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleMatches", reflect.TypeOf((*MockUsecase)(nil).HandleMatches), ctx, chatId, user, page)
+}
+
+// HandlePause mocks base method.
+func (m *MockUsecase) HandlePause(ctx context.Context, chatId int64, user *models.User) (tgbotapi.MessageConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandlePause", ctx, chatId, user)
+	ret0, _ := ret[0].(tgbotapi.MessageConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandlePause indicates an expected call of HandlePause.
+func (mr *MockUsecaseMockRecorder) HandlePause(ctx, chatId, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandlePause", reflect.TypeOf((*MockUsecase)(nil).HandlePause), ctx, chatId, user)
+}
+
 // HandleProfile mocks base method.
-func (m *MockUsecase) HandleProfile(arg0 context.Context, arg1 *v5.Message, arg2 *models.User) (v5.MessageConfig, error) {
+func (m *MockUsecase) HandleProfile(arg0 context.Context, arg1 *tgbotapi.Message, arg2 *models.User) (tgbotapi.MessageConfig, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "HandleProfile", arg0, arg1, arg2)
-	ret0, _ := ret[0].(v5.MessageConfig)
+	ret0, _ := ret[0].(tgbotapi.MessageConfig)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -81,10 +361,88 @@ func (mr *MockUsecaseMockRecorder) HandleProfile(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleProfile", reflect.TypeOf((*MockUsecase)(nil).HandleProfile), arg0, arg1, arg2)
 }
 
+// HandleReport mocks base method.
+func (m *MockUsecase) HandleReport(ctx context.Context, chatId int64, user *models.User, toId int64) (tgbotapi.MessageConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleReport", ctx, chatId, user, toId)
+	ret0, _ := ret[0].(tgbotapi.MessageConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleReport indicates an expected call of HandleReport.
+func (mr *MockUsecaseMockRecorder) HandleReport(ctx, chatId, user, toId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleReport", reflect.TypeOf((*MockUsecase)(nil).HandleReport), ctx, chatId, user, toId)
+}
+
+// HandleResume mocks base method.
+func (m *MockUsecase) HandleResume(ctx context.Context, chatId int64, user *models.User) (tgbotapi.MessageConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleResume", ctx, chatId, user)
+	ret0, _ := ret[0].(tgbotapi.MessageConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleResume indicates an expected call of HandleResume.
+func (mr *MockUsecaseMockRecorder) HandleResume(ctx, chatId, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleResume", reflect.TypeOf((*MockUsecase)(nil).HandleResume), ctx, chatId, user)
+}
+
+// HandleReview mocks base method.
+func (m *MockUsecase) HandleReview(ctx context.Context, chatId int64, moderator *models.User, action string, userId int64) ([]tgbotapi.Chattable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleReview", ctx, chatId, moderator, action, userId)
+	ret0, _ := ret[0].([]tgbotapi.Chattable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleReview indicates an expected call of HandleReview.
+func (mr *MockUsecaseMockRecorder) HandleReview(ctx, chatId, moderator, action, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleReview", reflect.TypeOf((*MockUsecase)(nil).HandleReview), ctx, chatId, moderator, action, userId)
+}
+
+// HandleSettings mocks base method.
+func (m *MockUsecase) HandleSettings(arg0 context.Context, arg1 *tgbotapi.Message, arg2 *models.User) (tgbotapi.MessageConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleSettings", arg0, arg1, arg2)
+	ret0, _ := ret[0].(tgbotapi.MessageConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleSettings indicates an expected call of HandleSettings.
+func (mr *MockUsecaseMockRecorder) HandleSettings(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleSettings", reflect.TypeOf((*MockUsecase)(nil).HandleSettings), arg0, arg1, arg2)
+}
+
+// HandleSkip mocks base method.
+func (m *MockUsecase) HandleSkip(ctx context.Context, chatId int64, user *models.User) (tgbotapi.Chattable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleSkip", ctx, chatId, user)
+	ret0, _ := ret[0].(tgbotapi.Chattable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleSkip indicates an expected call of HandleSkip.
+func (mr *MockUsecaseMockRecorder) HandleSkip(ctx, chatId, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleSkip", reflect.TypeOf((*MockUsecase)(nil).HandleSkip), ctx, chatId, user)
+}
+
 // HandleStart mocks base method.
-func (m *MockUsecase) HandleStart(arg0 context.Context, arg1 *v5.Message, arg2 bool) {
+func (m *MockUsecase) HandleStart(arg0 context.Context, arg1 *tgbotapi.Message, arg2 bool) (tgbotapi.MessageConfig, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "HandleStart", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "HandleStart", arg0, arg1, arg2)
+	ret0, _ := ret[0].(tgbotapi.MessageConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // HandleStart indicates an expected call of HandleStart.
@@ -93,8 +451,38 @@ func (mr *MockUsecaseMockRecorder) HandleStart(arg0, arg1, arg2 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleStart", reflect.TypeOf((*MockUsecase)(nil).HandleStart), arg0, arg1, arg2)
 }
 
+// HandleUndo mocks base method.
+func (m *MockUsecase) HandleUndo(ctx context.Context, chatId int64, user *models.User) ([]tgbotapi.Chattable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleUndo", ctx, chatId, user)
+	ret0, _ := ret[0].([]tgbotapi.Chattable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleUndo indicates an expected call of HandleUndo.
+func (mr *MockUsecaseMockRecorder) HandleUndo(ctx, chatId, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleUndo", reflect.TypeOf((*MockUsecase)(nil).HandleUndo), ctx, chatId, user)
+}
+
+// HasLikeWithTrueValue mocks base method.
+func (m *MockUsecase) HasLikeWithTrueValue(ctx context.Context, fromId, toId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasLikeWithTrueValue", ctx, fromId, toId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasLikeWithTrueValue indicates an expected call of HasLikeWithTrueValue.
+func (mr *MockUsecaseMockRecorder) HasLikeWithTrueValue(ctx, fromId, toId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasLikeWithTrueValue", reflect.TypeOf((*MockUsecase)(nil).HasLikeWithTrueValue), ctx, fromId, toId)
+}
+
 // IsStarted mocks base method.
-func (m *MockUsecase) IsStarted(arg0 context.Context, arg1 *v5.Message) (bool, error) {
+func (m *MockUsecase) IsStarted(arg0 context.Context, arg1 *tgbotapi.Message) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "IsStarted", arg0, arg1)
 	ret0, _ := ret[0].(bool)
@@ -107,3 +495,17 @@ func (mr *MockUsecaseMockRecorder) IsStarted(arg0, arg1 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsStarted", reflect.TypeOf((*MockUsecase)(nil).IsStarted), arg0, arg1)
 }
+
+// SwipeRestriction mocks base method.
+func (m *MockUsecase) SwipeRestriction(chatId int64, user *models.User) tgbotapi.Chattable {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwipeRestriction", chatId, user)
+	ret0, _ := ret[0].(tgbotapi.Chattable)
+	return ret0
+}
+
+// SwipeRestriction indicates an expected call of SwipeRestriction.
+func (mr *MockUsecaseMockRecorder) SwipeRestriction(chatId, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwipeRestriction", reflect.TypeOf((*MockUsecase)(nil).SwipeRestriction), chatId, user)
+}
@@ -64,7 +64,7 @@ func (mr *MockUsersRepositoryMockRecorder) DeleteAll(ctx interface{}) *gomock.Ca
 }
 
 // DeleteByUserId mocks base method.
-func (m *MockUsersRepository) DeleteByUserId(arg0 context.Context, arg1 string) error {
+func (m *MockUsersRepository) DeleteByUserId(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteByUserId", arg0, arg1)
 	ret0, _ := ret[0].(error)
@@ -78,7 +78,7 @@ func (mr *MockUsersRepositoryMockRecorder) DeleteByUserId(arg0, arg1 interface{}
 }
 
 // GetByUserId mocks base method.
-func (m *MockUsersRepository) GetByUserId(arg0 context.Context, arg1 string) (*models.User, error) {
+func (m *MockUsersRepository) GetByUserId(arg0 context.Context, arg1 int64) (*models.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByUserId", arg0, arg1)
 	ret0, _ := ret[0].(*models.User)
@@ -92,19 +92,77 @@ func (mr *MockUsersRepositoryMockRecorder) GetByUserId(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserId", reflect.TypeOf((*MockUsersRepository)(nil).GetByUserId), arg0, arg1)
 }
 
+// GetNextLiker mocks base method.
+func (m *MockUsersRepository) GetNextLiker(ctx context.Context, userId int64, minAge int) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextLiker", ctx, userId, minAge)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextLiker indicates an expected call of GetNextLiker.
+func (mr *MockUsersRepositoryMockRecorder) GetNextLiker(ctx, userId, minAge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextLiker", reflect.TypeOf((*MockUsersRepository)(nil).GetNextLiker), ctx, userId, minAge)
+}
+
 // GetNextUser mocks base method.
-func (m *MockUsersRepository) GetNextUser(arg0 context.Context, arg1 string, arg2 bool) (*models.User, error) {
+func (m *MockUsersRepository) GetNextUser(arg0 context.Context, arg1 *models.User) (*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNextUser", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetNextUser", arg0, arg1)
 	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetNextUser indicates an expected call of GetNextUser.
-func (mr *MockUsersRepositoryMockRecorder) GetNextUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockUsersRepositoryMockRecorder) GetNextUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextUser", reflect.TypeOf((*MockUsersRepository)(nil).GetNextUser), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextUser", reflect.TypeOf((*MockUsersRepository)(nil).GetNextUser), arg0, arg1)
+}
+
+// Search mocks base method.
+func (m *MockUsersRepository) Search(ctx context.Context, query string, limit, offset int) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, limit, offset)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockUsersRepositoryMockRecorder) Search(ctx, query, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockUsersRepository)(nil).Search), ctx, query, limit, offset)
+}
+
+// SetBanned mocks base method.
+func (m *MockUsersRepository) SetBanned(ctx context.Context, userId int64, banned bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBanned", ctx, userId, banned)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBanned indicates an expected call of SetBanned.
+func (mr *MockUsersRepositoryMockRecorder) SetBanned(ctx, userId, banned interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBanned", reflect.TypeOf((*MockUsersRepository)(nil).SetBanned), ctx, userId, banned)
+}
+
+// SetHidden mocks base method.
+func (m *MockUsersRepository) SetHidden(ctx context.Context, userId int64, hidden bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHidden", ctx, userId, hidden)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHidden indicates an expected call of SetHidden.
+func (mr *MockUsersRepositoryMockRecorder) SetHidden(ctx, userId, hidden interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHidden", reflect.TypeOf((*MockUsersRepository)(nil).SetHidden), ctx, userId, hidden)
 }
 
 // UpdateByUserId mocks base method.
@@ -120,3 +178,17 @@ func (mr *MockUsersRepositoryMockRecorder) UpdateByUserId(arg0, arg1 interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByUserId", reflect.TypeOf((*MockUsersRepository)(nil).UpdateByUserId), arg0, arg1)
 }
+
+// UpdateUsername mocks base method.
+func (m *MockUsersRepository) UpdateUsername(ctx context.Context, userId int64, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", ctx, userId, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockUsersRepositoryMockRecorder) UpdateUsername(ctx, userId, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockUsersRepository)(nil).UpdateUsername), ctx, userId, username)
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source outbox_repository.go -destination mock/outbox_repository.go -package mock
+package internal
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+)
+
+// OutboxRepository stores the messages which are queued for sending, so they survive restarts.
+type OutboxRepository interface {
+	// Add sets the id of message
+	Add(ctx context.Context, message *models.OutboxMessage) error
+	Delete(ctx context.Context, id int64) error
+	// GetAll returns the messages in the order they were added
+	GetAll(ctx context.Context) ([]*models.OutboxMessage, error)
+}
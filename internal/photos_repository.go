@@ -0,0 +1,15 @@
+//go:generate mockgen -source photos_repository.go -destination mock/photos_repository.go -package mock
+package internal
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+)
+
+type PhotosRepository interface {
+	GetByUserId(ctx context.Context, userId int64) ([]*models.Photo, error)
+	// ReplaceByUserId keeps photos which are already reviewed reviewed
+	ReplaceByUserId(ctx context.Context, userId int64, fileIds []string) error
+	GetNextUnreviewedUserId(ctx context.Context) (int64, error)
+	SetReviewedByUserId(ctx context.Context, userId int64) error
+}
@@ -0,0 +1,96 @@
+// Package ratelimit limits how often every user can do something, with a token bucket per user.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter allows limit events per period to every key, up to limit of them at once.
+// A nil Limiter allows everything.
+type Limiter struct {
+	capacity float64
+	rate     float64 // Tokens per second
+	period   time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[int64]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	denied  bool // The last event was denied
+}
+
+// New returns nil if limit isn't positive, limiting is disabled then.
+func New(limit int, period time.Duration) *Limiter {
+	if limit <= 0 || period <= 0 {
+		return nil
+	}
+
+	return &Limiter{
+		capacity: float64(limit),
+		rate:     float64(limit) / period.Seconds(),
+		period:   period,
+		now:      time.Now,
+		buckets:  make(map[int64]*bucket),
+	}
+}
+
+// Allow takes a token of key. firstDenied is true for the first denied event after allowed ones,
+// so the user can be told about the limit once instead of on every event.
+func (l *Limiter) Allow(key int64) (allowed, firstDenied bool) {
+	if l == nil {
+		return true, false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.capacity, updated: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = l.refill(b, now)
+	b.updated = now
+
+	if b.tokens < 1 {
+		firstDenied = !b.denied
+		b.denied = true
+		return false, firstDenied
+	}
+
+	b.tokens--
+	b.denied = false
+	return true, false
+}
+
+func (l *Limiter) refill(b *bucket, now time.Time) float64 {
+	tokens := b.tokens + now.Sub(b.updated).Seconds()*l.rate
+	if tokens > l.capacity {
+		return l.capacity
+	}
+	return tokens
+}
+
+// sweep forgets the buckets which are full again, a new bucket is the same. It runs once per period.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.period {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= l.capacity {
+			delete(l.buckets, key)
+		}
+	}
+}
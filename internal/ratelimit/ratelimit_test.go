@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func newTestLimiter(limit int, period time.Duration, now *time.Time) *Limiter {
+	l := New(limit, period)
+	l.now = func() time.Time { return *now }
+	return l
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	now := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	l := newTestLimiter(2, time.Minute, &now)
+
+	for i := 0; i < 2; i++ {
+		allowed, _ := l.Allow(1)
+		assert.True(t, allowed)
+	}
+
+	allowed, firstDenied := l.Allow(1)
+	assert.False(t, allowed)
+	assert.True(t, firstDenied)
+
+	allowed, firstDenied = l.Allow(1)
+	assert.False(t, allowed)
+	assert.False(t, firstDenied)
+
+	// Other users have their own budget
+	allowed, _ = l.Allow(2)
+	assert.True(t, allowed)
+
+	// A token is refilled every period / limit
+	now = now.Add(30 * time.Second)
+	allowed, _ = l.Allow(1)
+	assert.True(t, allowed)
+
+	allowed, firstDenied = l.Allow(1)
+	assert.False(t, allowed)
+	assert.True(t, firstDenied)
+}
+
+func TestLimiter_Allow_ShouldForgetFullBuckets(t *testing.T) {
+	now := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	l := newTestLimiter(2, time.Minute, &now)
+
+	l.Allow(1)
+	l.Allow(2)
+	assert.Len(t, l.buckets, 2)
+
+	now = now.Add(time.Minute)
+	l.Allow(2)
+	assert.Len(t, l.buckets, 1)
+}
+
+func TestLimiter_Allow_NilShouldAllowEverything(t *testing.T) {
+	l := New(0, time.Minute)
+	assert.Nil(t, l)
+
+	allowed, firstDenied := l.Allow(1)
+	assert.True(t, allowed)
+	assert.False(t, firstDenied)
+}
@@ -0,0 +1,17 @@
+//go:generate mockgen -source reports_repository.go -destination mock/reports_repository.go -package mock
+package internal
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+)
+
+type ReportsRepository interface {
+	Add(ctx context.Context, report *models.Report) error
+	// CountReporters returns the number of users with unresolved reports about toId
+	CountReporters(ctx context.Context, toId int64) (int, error)
+	// GetNextReportedId returns the user with the oldest unresolved report
+	GetNextReportedId(ctx context.Context) (int64, error)
+	GetUnresolvedByUserId(ctx context.Context, toId int64) ([]*models.Report, error)
+	ResolveByUserId(ctx context.Context, toId int64) error
+}
@@ -0,0 +1,130 @@
+package sender
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Types of the persisted messages
+const (
+	payloadMessage = "message"
+	payloadPhoto   = "photo"
+	payloadAlbum   = "album"
+)
+
+var errNotPersistable = errors.New("message can't be persisted")
+
+// payload is the persisted message. Captions of photos and albums are kept in Text,
+// an album has the caption under its first photo, the same as the albums of the bot.
+type payload struct {
+	Type        string                         `json:"type"`
+	Text        string                         `json:"text,omitempty"`
+	ParseMode   string                         `json:"parse_mode,omitempty"`
+	FileIds     []string                       `json:"file_ids,omitempty"`
+	ReplyMarkup *tgbotapi.InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+}
+
+func encode(message tgbotapi.Chattable) ([]byte, error) {
+	var p payload
+
+	switch m := message.(type) {
+	case tgbotapi.MessageConfig:
+		markup, ok := inlineMarkup(m.ReplyMarkup)
+		if !ok {
+			return nil, fmt.Errorf("%w: reply markup %T", errNotPersistable, m.ReplyMarkup)
+		}
+		p = payload{Type: payloadMessage, Text: m.Text, ParseMode: m.ParseMode, ReplyMarkup: markup}
+	case tgbotapi.PhotoConfig:
+		fileId, ok := m.File.(tgbotapi.FileID)
+		if !ok {
+			return nil, fmt.Errorf("%w: photo %T", errNotPersistable, m.File)
+		}
+		markup, ok := inlineMarkup(m.ReplyMarkup)
+		if !ok {
+			return nil, fmt.Errorf("%w: reply markup %T", errNotPersistable, m.ReplyMarkup)
+		}
+		p = payload{Type: payloadPhoto, Text: m.Caption, ParseMode: m.ParseMode, FileIds: []string{string(fileId)}, ReplyMarkup: markup}
+	case tgbotapi.MediaGroupConfig:
+		p = payload{Type: payloadAlbum, FileIds: make([]string, 0, len(m.Media))}
+		for i, media := range m.Media {
+			photo, ok := media.(tgbotapi.InputMediaPhoto)
+			if !ok {
+				return nil, fmt.Errorf("%w: album media %T", errNotPersistable, media)
+			}
+			fileId, ok := photo.Media.(tgbotapi.FileID)
+			if !ok {
+				return nil, fmt.Errorf("%w: album photo %T", errNotPersistable, photo.Media)
+			}
+			if i == 0 {
+				p.Text, p.ParseMode = photo.Caption, photo.ParseMode
+			} else if len(photo.Caption) > 0 {
+				return nil, fmt.Errorf("%w: caption of album photo %d", errNotPersistable, i)
+			}
+			p.FileIds = append(p.FileIds, string(fileId))
+		}
+	default:
+		return nil, fmt.Errorf("%w: %T", errNotPersistable, message)
+	}
+
+	return json.Marshal(p)
+}
+
+func decode(chatId int64, data []byte) (tgbotapi.Chattable, error) {
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+
+	switch p.Type {
+	case payloadMessage:
+		msg := tgbotapi.NewMessage(chatId, p.Text)
+		msg.ParseMode = p.ParseMode
+		if p.ReplyMarkup != nil {
+			msg.ReplyMarkup = *p.ReplyMarkup
+		}
+		return msg, nil
+	case payloadPhoto:
+		if len(p.FileIds) != 1 {
+			return nil, fmt.Errorf("photo has %d file ids", len(p.FileIds))
+		}
+		photo := tgbotapi.NewPhoto(chatId, tgbotapi.FileID(p.FileIds[0]))
+		photo.Caption = p.Text
+		photo.ParseMode = p.ParseMode
+		if p.ReplyMarkup != nil {
+			photo.ReplyMarkup = *p.ReplyMarkup
+		}
+		return photo, nil
+	case payloadAlbum:
+		if len(p.FileIds) == 0 {
+			return nil, errors.New("album has no file ids")
+		}
+		media := make([]interface{}, 0, len(p.FileIds))
+		for i, fileId := range p.FileIds {
+			photo := tgbotapi.NewInputMediaPhoto(tgbotapi.FileID(fileId))
+			if i == 0 {
+				photo.Caption = p.Text
+				photo.ParseMode = p.ParseMode
+			}
+			media = append(media, photo)
+		}
+		return tgbotapi.NewMediaGroup(chatId, media), nil
+	default:
+		return nil, fmt.Errorf("unknown payload type %q", p.Type)
+	}
+}
+
+// inlineMarkup is false for keyboards other than the inline one, the bot doesn't send them with durable messages.
+func inlineMarkup(markup interface{}) (*tgbotapi.InlineKeyboardMarkup, bool) {
+	switch m := markup.(type) {
+	case nil:
+		return nil, true
+	case tgbotapi.InlineKeyboardMarkup:
+		return &m, true
+	case *tgbotapi.InlineKeyboardMarkup:
+		return m, true
+	default:
+		return nil, false
+	}
+}
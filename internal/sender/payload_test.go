@@ -0,0 +1,69 @@
+package sender
+
+import (
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"testing"
+)
+
+func TestPayload(t *testing.T) {
+	keyboards := internal.NewKeyboards(internal.NewCallbackCodec(nil), zap.NewNop().Sugar())
+
+	text := tgbotapi.NewMessage(1, "*match*")
+	text.ParseMode = tgbotapi.ModeMarkdown
+	text.ReplyMarkup = keyboards.CreateMatchKeyboardMarkup(2)
+
+	photo := tgbotapi.NewPhoto(1, tgbotapi.FileID("photo"))
+	photo.Caption = "*match*"
+	photo.ParseMode = tgbotapi.ModeMarkdown
+	photo.ReplyMarkup = keyboards.CreateMatchKeyboardMarkup(2)
+
+	first := tgbotapi.NewInputMediaPhoto(tgbotapi.FileID("first"))
+	first.Caption = "*match*"
+	first.ParseMode = tgbotapi.ModeMarkdown
+	album := tgbotapi.NewMediaGroup(1, []interface{}{first, tgbotapi.NewInputMediaPhoto(tgbotapi.FileID("second"))})
+
+	cases := []struct {
+		name    string
+		message tgbotapi.Chattable
+	}{
+		{"message", text},
+		{"message without keyboard", tgbotapi.NewMessage(1, "like")},
+		{"photo", photo},
+		{"album", album},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			data, err := encode(c.message)
+			assert.Nil(t, err)
+
+			decoded, err := decode(1, data)
+			assert.Nil(t, err)
+			assert.EqualValues(t, c.message, decoded)
+		})
+	}
+}
+
+func TestPayload_NotPersistable(t *testing.T) {
+	cases := []struct {
+		name    string
+		message tgbotapi.Chattable
+	}{
+		{"edit", tgbotapi.NewEditMessageText(1, 2, "text")},
+		{"uploaded photo", tgbotapi.NewPhoto(1, tgbotapi.FileBytes{Name: "photo", Bytes: []byte{1}})},
+		{"reply keyboard", tgbotapi.MessageConfig{
+			BaseChat: tgbotapi.BaseChat{ChatID: 1, ReplyMarkup: tgbotapi.NewRemoveKeyboard(true)},
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := encode(c.message)
+			assert.True(t, errors.Is(err, errNotPersistable))
+		})
+	}
+}
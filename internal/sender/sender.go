@@ -0,0 +1,391 @@
+// Package sender queues outbound messages and sends them within the limits of Telegram:
+// about 30 messages per second to all chats and one message per second to the same chat.
+package sender
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/Eretic431/datingTelegramBot/internal/logging"
+	"github.com/Eretic431/datingTelegramBot/internal/metrics"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// storeTimeout limits the outbox queries made by the sending loop, which has no context of its own
+const storeTimeout = 5 * time.Second
+
+// Bot sends messages to Telegram, *tgbotapi.BotAPI implements it.
+type Bot interface {
+	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+	SendMediaGroup(config tgbotapi.MediaGroupConfig) ([]tgbotapi.Message, error)
+	Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error)
+}
+
+type Config struct {
+	MessagesPerSecond int           // To all chats
+	ChatInterval      time.Duration // Between messages to the same chat
+	MaxAttempts       int           // Of a message failing with errors, waiting for flood control isn't counted
+	MinBackoff        time.Duration // Before the second attempt, doubled for every next one
+	MaxBackoff        time.Duration
+}
+
+// Sender keeps the order of messages to every chat. Messages to different chats are sent
+// in the order they become allowed, so a chat waiting for a retry doesn't hold the others.
+type Sender struct {
+	bot      Bot
+	store    internal.OutboxRepository // nil if messages aren't persisted
+	config   Config
+	interval time.Duration // Between any two messages
+	log      *zap.SugaredLogger
+	metrics  *metrics.Metrics
+	now      func() time.Time
+
+	mu         sync.Mutex
+	chats      map[int64]*chat
+	globalNext time.Time // No message can be sent earlier
+	seq        uint64
+	wake       chan struct{}
+}
+
+type chat struct {
+	jobs []*job
+	next time.Time // The next message to the chat can't be sent earlier
+}
+
+type job struct {
+	message  tgbotapi.Chattable
+	chatId   int64
+	seq      uint64 // Order of queueing
+	outboxId int64  // 0 if the message isn't persisted
+	attempts int
+	log      *zap.SugaredLogger // Of the update the message answers
+}
+
+// New returns a sender which doesn't persist messages if store is nil. Run has to be started to send anything.
+func New(bot Bot, store internal.OutboxRepository, config Config, log *zap.SugaredLogger, m *metrics.Metrics) *Sender {
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = 1
+	}
+
+	var interval time.Duration
+	if config.MessagesPerSecond > 0 {
+		interval = time.Second / time.Duration(config.MessagesPerSecond)
+	}
+
+	return &Sender{
+		bot:      bot,
+		store:    store,
+		config:   config,
+		interval: interval,
+		log:      log,
+		metrics:  m,
+		now:      time.Now,
+		chats:    make(map[int64]*chat),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+type durable struct {
+	tgbotapi.Chattable
+}
+
+// Durable marks message to be persisted before it's queued, so it's sent even if the bot restarts.
+// Only text messages, photos and albums of photos sent by file id can be persisted, others stay in memory.
+func Durable(message tgbotapi.Chattable) tgbotapi.Chattable {
+	if message == nil {
+		return nil
+	}
+	return durable{message}
+}
+
+// Enqueue never blocks on Telegram, failures of the message are logged with the logger of ctx.
+func (s *Sender) Enqueue(ctx context.Context, message tgbotapi.Chattable) {
+	j := &job{message: message, log: logging.FromContext(ctx, s.log)}
+	d, persist := message.(durable)
+	if persist {
+		j.message = d.Chattable
+	}
+	j.chatId = ChatId(j.message)
+
+	if persist && s.store != nil {
+		j.outboxId = s.persist(ctx, j)
+	}
+
+	s.push(j)
+}
+
+// persist returns the id of the stored message or 0 if it couldn't be stored, it's sent from memory then.
+func (s *Sender) persist(ctx context.Context, j *job) int64 {
+	payload, err := encode(j.message)
+	if err != nil {
+		j.log.Warnw("could not persist message, it's queued in memory only", "err", err)
+		return 0
+	}
+
+	message := &models.OutboxMessage{ChatId: j.chatId, Payload: payload}
+	if err := s.store.Add(ctx, message); err != nil {
+		j.log.Errorw("could not persist message, it's queued in memory only", "err", err)
+		return 0
+	}
+
+	return message.Id
+}
+
+// Restore queues the messages persisted before a restart, it has to be called before new messages are queued.
+func (s *Sender) Restore(ctx context.Context) error {
+	if s.store == nil {
+		return nil
+	}
+
+	messages, err := s.store.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range messages {
+		message, err := decode(m.ChatId, m.Payload)
+		if err != nil {
+			s.log.Errorw("could not decode persisted message, it's dropped", "outbox_id", m.Id, "err", err)
+			s.delete(m.Id, s.log)
+			continue
+		}
+
+		s.push(&job{message: message, chatId: m.ChatId, outboxId: m.Id, log: s.log})
+	}
+
+	if len(messages) > 0 {
+		s.log.Infow("restored persisted messages", "count", len(messages))
+	}
+
+	return nil
+}
+
+func (s *Sender) push(j *job) {
+	s.mu.Lock()
+	s.seq++
+	j.seq = s.seq
+	c, ok := s.chats[j.chatId]
+	if !ok {
+		c = &chat{}
+		s.chats[j.chatId] = c
+	}
+	c.jobs = append(c.jobs, j)
+	s.mu.Unlock()
+
+	s.metrics.SendQueue.Inc()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run sends the queued messages until stop is closed and the queue is empty.
+// Persisted messages left in the queue are sent after a restart, the others are lost.
+func (s *Sender) Run(stop <-chan struct{}) {
+	stopping := false
+
+	for {
+		j, wait, empty := s.next()
+		if j != nil {
+			s.send(j)
+			continue
+		}
+		if empty && stopping {
+			return
+		}
+
+		var timeout <-chan time.Time
+		var timer *time.Timer
+		if !empty {
+			timer = time.NewTimer(wait)
+			timeout = timer.C
+		}
+
+		select {
+		case <-stop:
+			stop = nil
+			stopping = true
+		case <-s.wake:
+		case <-timeout:
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// next returns the message which can be sent now, or how long to wait for one. empty is true if nothing is queued.
+func (s *Sender) next() (j *job, wait time.Duration, empty bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	// Of the chats allowed now the oldest message goes first
+	var ready *chat
+	var readyAt time.Time
+	for id, c := range s.chats {
+		if len(c.jobs) == 0 {
+			// Chats are kept only while they have to wait, a new chat can be sent to right away
+			if !c.next.After(now) {
+				delete(s.chats, id)
+			}
+			continue
+		}
+
+		at := c.next
+		if at.Before(now) {
+			at = now
+		}
+		if ready == nil || at.Before(readyAt) || (at.Equal(readyAt) && c.jobs[0].seq < ready.jobs[0].seq) {
+			ready, readyAt = c, at
+		}
+	}
+
+	if ready == nil {
+		return nil, 0, true
+	}
+
+	if s.globalNext.After(readyAt) {
+		readyAt = s.globalNext
+	}
+	if readyAt.After(now) {
+		return nil, readyAt.Sub(now), false
+	}
+
+	return ready.jobs[0], 0, false
+}
+
+// send makes an attempt to send the first message of its chat and either removes it from the queue
+// or leaves it there to be retried.
+func (s *Sender) send(j *job) {
+	err := s.deliver(j.message)
+	now := s.now()
+
+	s.mu.Lock()
+	c := s.chats[j.chatId]
+	s.globalNext = now.Add(s.interval)
+	c.next = now
+	if j.chatId != 0 {
+		c.next = now.Add(s.config.ChatInterval)
+	}
+
+	retry := true
+	var reason string
+	if retryAfter, ok := floodWait(err); ok {
+		c.next = now.Add(retryAfter)
+		reason = metrics.RetryFlood
+	} else if err != nil && temporary(err) && j.attempts+1 < s.config.MaxAttempts {
+		j.attempts++
+		c.next = now.Add(s.backoff(j.attempts))
+		reason = metrics.RetryError
+	} else {
+		retry = false
+		c.jobs[0] = nil
+		c.jobs = c.jobs[1:]
+	}
+	s.mu.Unlock()
+
+	if retry {
+		s.metrics.SendRetries.WithLabelValues(reason).Inc()
+		j.log.Infow("message will be retried", "chat_id", j.chatId, "reason", reason, "err", err)
+		return
+	}
+
+	s.metrics.SendQueue.Dec()
+	if err != nil {
+		s.metrics.SendFailures.Inc()
+		j.log.Warnw("could not send message", "chat_id", j.chatId, "attempts", j.attempts+1, "err", err)
+	}
+	if j.outboxId != 0 {
+		s.delete(j.outboxId, j.log)
+	}
+}
+
+// deliver uses SendMediaGroup for albums, because Telegram answers them with an array of messages,
+// and Request for answers to callbacks, which Telegram answers with true.
+func (s *Sender) deliver(message tgbotapi.Chattable) error {
+	switch m := message.(type) {
+	case tgbotapi.MediaGroupConfig:
+		_, err := s.bot.SendMediaGroup(m)
+		return err
+	case tgbotapi.CallbackConfig:
+		_, err := s.bot.Request(m)
+		return err
+	}
+
+	_, err := s.bot.Send(message)
+	return err
+}
+
+// delete logs errors only, the message is sent once more after a restart then.
+func (s *Sender) delete(outboxId int64, log *zap.SugaredLogger) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	if err := s.store.Delete(ctx, outboxId); err != nil {
+		log.Errorw("could not delete persisted message", "outbox_id", outboxId, "err", err)
+	}
+}
+
+func (s *Sender) backoff(attempts int) time.Duration {
+	backoff := s.config.MinBackoff
+	for i := 1; i < attempts && backoff < s.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > s.config.MaxBackoff {
+		return s.config.MaxBackoff
+	}
+	return backoff
+}
+
+// floodWait returns how long Telegram asked to wait before the next message to the chat.
+func floodWait(err error) (time.Duration, bool) {
+	var apiErr *tgbotapi.Error
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter <= 0 {
+		return 0, false
+	}
+	return time.Duration(apiErr.RetryAfter) * time.Second, true
+}
+
+// temporary is true for the errors which may not happen again: network errors and errors of Telegram servers.
+// Other errors of Telegram API, e.g. the bot is blocked by the user, won't go away with retries.
+func temporary(err error) bool {
+	var apiErr *tgbotapi.Error
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+	return apiErr.Code >= http.StatusInternalServerError || apiErr.Code == http.StatusTooManyRequests
+}
+
+// ChatId returns 0 for messages without a chat, e.g. answers to callbacks. They are queued together
+// and don't wait for Config.ChatInterval.
+func ChatId(message tgbotapi.Chattable) int64 {
+	switch m := message.(type) {
+	case tgbotapi.MessageConfig:
+		return m.ChatID
+	case tgbotapi.PhotoConfig:
+		return m.ChatID
+	case tgbotapi.MediaGroupConfig:
+		return m.ChatID
+	case tgbotapi.EditMessageTextConfig:
+		return m.ChatID
+	case tgbotapi.EditMessageMediaConfig:
+		return m.ChatID
+	case tgbotapi.EditMessageReplyMarkupConfig:
+		return m.ChatID
+	case tgbotapi.EditMessageCaptionConfig:
+		return m.ChatID
+	case tgbotapi.DeleteMessageConfig:
+		return m.ChatID
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,276 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/Eretic431/datingTelegramBot/internal/metrics"
+	"github.com/Eretic431/datingTelegramBot/internal/mock"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeBot records the sent messages and fails them with the queued errors.
+type fakeBot struct {
+	mu     sync.Mutex
+	sent   []tgbotapi.Chattable
+	errors []error
+}
+
+func (b *fakeBot) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.errors) > 0 {
+		err := b.errors[0]
+		b.errors = b.errors[1:]
+		if err != nil {
+			return tgbotapi.Message{}, err
+		}
+	}
+
+	b.sent = append(b.sent, c)
+	return tgbotapi.Message{}, nil
+}
+
+func (b *fakeBot) SendMediaGroup(config tgbotapi.MediaGroupConfig) ([]tgbotapi.Message, error) {
+	_, err := b.Send(config)
+	return nil, err
+}
+
+func (b *fakeBot) Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	_, err := b.Send(c)
+	return &tgbotapi.APIResponse{Ok: err == nil}, err
+}
+
+var testConfig = Config{
+	MessagesPerSecond: 10,
+	ChatInterval:      time.Second,
+	MaxAttempts:       3,
+	MinBackoff:        time.Second,
+	MaxBackoff:        3 * time.Second,
+}
+
+func newTestSender(bot Bot, store *mock.MockOutboxRepository, now *time.Time) *Sender {
+	s := New(bot, nil, testConfig, zap.NewNop().Sugar(), metrics.New())
+	if store != nil {
+		s.store = store
+	}
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+// sendReady sends the message allowed at now, it fails if there is none.
+func sendReady(t *testing.T, s *Sender) {
+	t.Helper()
+
+	j, wait, empty := s.next()
+	if !assert.NotNil(t, j, "wait %v, empty %v", wait, empty) {
+		t.FailNow()
+	}
+	s.send(j)
+}
+
+func TestSender_ShouldRespectLimits(t *testing.T) {
+	now := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	bot := &fakeBot{}
+	s := newTestSender(bot, nil, &now)
+
+	ctx := context.Background()
+	s.Enqueue(ctx, tgbotapi.NewMessage(1, "first"))
+	s.Enqueue(ctx, tgbotapi.NewMessage(1, "second"))
+	s.Enqueue(ctx, tgbotapi.NewMessage(2, "other chat"))
+
+	sendReady(t, s)
+	assert.Len(t, bot.sent, 1)
+
+	// The other chat waits only for the global limit
+	_, wait, _ := s.next()
+	assert.EqualValues(t, 100*time.Millisecond, wait)
+
+	now = now.Add(100 * time.Millisecond)
+	sendReady(t, s)
+	assert.EqualValues(t, tgbotapi.NewMessage(2, "other chat"), bot.sent[1])
+
+	// The same chat waits for the chat interval
+	_, wait, _ = s.next()
+	assert.EqualValues(t, 900*time.Millisecond, wait)
+
+	now = now.Add(900 * time.Millisecond)
+	sendReady(t, s)
+	assert.EqualValues(t, tgbotapi.NewMessage(1, "second"), bot.sent[2])
+
+	_, _, empty := s.next()
+	assert.True(t, empty)
+}
+
+func TestSender_ShouldNotDelayCallbackAnswersByChatInterval(t *testing.T) {
+	now := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	bot := &fakeBot{}
+	s := newTestSender(bot, nil, &now)
+
+	ctx := context.Background()
+	s.Enqueue(ctx, tgbotapi.NewCallback("1", "first"))
+	s.Enqueue(ctx, tgbotapi.NewCallback("2", "second"))
+
+	sendReady(t, s)
+
+	// Only the global limit applies
+	_, wait, _ := s.next()
+	assert.EqualValues(t, 100*time.Millisecond, wait)
+
+	now = now.Add(100 * time.Millisecond)
+	sendReady(t, s)
+	assert.EqualValues(t, []tgbotapi.Chattable{tgbotapi.NewCallback("1", "first"), tgbotapi.NewCallback("2", "second")}, bot.sent)
+}
+
+func TestSender_ShouldWaitRetryAfter(t *testing.T) {
+	now := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	bot := &fakeBot{errors: []error{
+		&tgbotapi.Error{Code: 429, Message: "Too Many Requests", ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: 5}},
+	}}
+	s := newTestSender(bot, nil, &now)
+
+	s.Enqueue(context.Background(), tgbotapi.NewMessage(1, "match"))
+
+	sendReady(t, s)
+	assert.Len(t, bot.sent, 0)
+
+	_, wait, _ := s.next()
+	assert.EqualValues(t, 5*time.Second, wait)
+
+	now = now.Add(5 * time.Second)
+	sendReady(t, s)
+	assert.EqualValues(t, []tgbotapi.Chattable{tgbotapi.NewMessage(1, "match")}, bot.sent)
+}
+
+func TestSender_ShouldRetryWithBackoff(t *testing.T) {
+	now := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	networkErr := errors.New("connection reset")
+	bot := &fakeBot{errors: []error{networkErr, networkErr, networkErr}}
+	s := newTestSender(bot, nil, &now)
+
+	s.Enqueue(context.Background(), tgbotapi.NewMessage(1, "match"))
+
+	sendReady(t, s)
+	_, wait, _ := s.next()
+	assert.EqualValues(t, time.Second, wait)
+
+	now = now.Add(wait)
+	sendReady(t, s)
+	_, wait, _ = s.next()
+	assert.EqualValues(t, 2*time.Second, wait)
+
+	// The last attempt drops the message
+	now = now.Add(wait)
+	sendReady(t, s)
+	_, _, empty := s.next()
+	assert.True(t, empty)
+	assert.Len(t, bot.sent, 0)
+}
+
+func TestSender_ShouldDropOnPermanentError(t *testing.T) {
+	now := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	bot := &fakeBot{errors: []error{
+		&tgbotapi.Error{Code: 403, Message: "Forbidden: bot was blocked by the user"},
+	}}
+	s := newTestSender(bot, nil, &now)
+
+	s.Enqueue(context.Background(), tgbotapi.NewMessage(1, "match"))
+	s.Enqueue(context.Background(), tgbotapi.NewMessage(2, "other chat"))
+
+	sendReady(t, s)
+	now = now.Add(time.Second)
+	sendReady(t, s)
+
+	_, _, empty := s.next()
+	assert.True(t, empty)
+	assert.EqualValues(t, []tgbotapi.Chattable{tgbotapi.NewMessage(2, "other chat")}, bot.sent)
+}
+
+func TestSender_ShouldPersistDurableMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	store := mock.NewMockOutboxRepository(ctrl)
+	bot := &fakeBot{}
+	s := newTestSender(bot, store, &now)
+
+	message := tgbotapi.NewMessage(1, "match")
+	payload, err := encode(message)
+	assert.Nil(t, err)
+
+	store.EXPECT().Add(gomock.Any(), &models.OutboxMessage{ChatId: 1, Payload: payload}).
+		DoAndReturn(func(_ context.Context, m *models.OutboxMessage) error {
+			m.Id = 5
+			return nil
+		})
+	store.EXPECT().Delete(gomock.Any(), int64(5)).Return(nil)
+
+	s.Enqueue(context.Background(), Durable(message))
+	// Not durable messages aren't persisted
+	s.Enqueue(context.Background(), tgbotapi.NewMessage(2, "reply"))
+
+	sendReady(t, s)
+	now = now.Add(time.Second)
+	sendReady(t, s)
+
+	assert.EqualValues(t, []tgbotapi.Chattable{message, tgbotapi.NewMessage(2, "reply")}, bot.sent)
+}
+
+func TestSender_Restore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	store := mock.NewMockOutboxRepository(ctrl)
+	bot := &fakeBot{}
+	s := newTestSender(bot, store, &now)
+
+	message := tgbotapi.NewMessage(1, "match")
+	payload, err := encode(message)
+	assert.Nil(t, err)
+
+	store.EXPECT().GetAll(gomock.Any()).Return([]*models.OutboxMessage{
+		{Id: 5, ChatId: 1, Payload: payload},
+		{Id: 6, ChatId: 1, Payload: []byte(`{"type":"sticker"}`)},
+	}, nil)
+	// The message which can't be decoded is dropped right away
+	store.EXPECT().Delete(gomock.Any(), int64(6)).Return(nil)
+	store.EXPECT().Delete(gomock.Any(), int64(5)).Return(nil)
+
+	assert.Nil(t, s.Restore(context.Background()))
+
+	sendReady(t, s)
+	assert.EqualValues(t, []tgbotapi.Chattable{message}, bot.sent)
+}
+
+func TestSender_Run_ShouldSendQueuedMessagesBeforeStopping(t *testing.T) {
+	bot := &fakeBot{}
+	s := New(bot, nil, Config{ChatInterval: time.Millisecond}, zap.NewNop().Sugar(), metrics.New())
+
+	s.Enqueue(context.Background(), tgbotapi.NewMessage(1, "first"))
+	s.Enqueue(context.Background(), tgbotapi.NewMessage(1, "second"))
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		s.Run(stop)
+		close(stopped)
+	}()
+	close(stop)
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("sender didn't stop")
+	}
+
+	assert.EqualValues(t, []tgbotapi.Chattable{tgbotapi.NewMessage(1, "first"), tgbotapi.NewMessage(1, "second")}, bot.sent)
+}
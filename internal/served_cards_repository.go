@@ -0,0 +1,19 @@
+//go:generate mockgen -source served_cards_repository.go -destination mock/served_cards_repository.go -package mock
+package internal
+
+import (
+	"context"
+	"time"
+)
+
+// ServedCardsRepository stores the profiles shown to users with the like keyboard.
+type ServedCardsRepository interface {
+	// Add records that toId was shown to userId, showing it again renews the time
+	Add(ctx context.Context, userId, toId int64) error
+	// Delete removes the card once it's swiped, or returns models.ErrNoRecord
+	Delete(ctx context.Context, userId, toId int64) error
+	// GetServedAt returns when toId was last shown to userId, or models.ErrNoRecord
+	GetServedAt(ctx context.Context, userId, toId int64) (time.Time, error)
+	// DeleteExpired removes the cards of all users shown more than ttl ago and returns how many were removed
+	DeleteExpired(ctx context.Context, ttl time.Duration) (int64, error)
+}
@@ -0,0 +1,11 @@
+//go:generate mockgen -source stats_repository.go -destination mock/stats_repository.go -package mock
+package internal
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+)
+
+type StatsRepository interface {
+	Get(ctx context.Context) (*models.Stats, error)
+}
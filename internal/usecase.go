@@ -11,16 +11,36 @@ type Usecase interface {
 	HandleStart(context.Context, *tgbotapi.Message, bool) (tgbotapi.MessageConfig, error)
 	IsStarted(context.Context, *tgbotapi.Message) (bool, error)
 	HandleProfile(context.Context, *tgbotapi.Message, *models.User) (tgbotapi.MessageConfig, error)
+	HandleSettings(context.Context, *tgbotapi.Message, *models.User) (tgbotapi.MessageConfig, error)
+	HandleEdit(ctx context.Context, chatId int64, user *models.User) (tgbotapi.MessageConfig, error)
+	HandleEditField(ctx context.Context, chatId int64, user *models.User, stage int) (tgbotapi.MessageConfig, error)
 	HandleFillingProfile(context.Context, string, int64, string, *models.User) (tgbotapi.Chattable, error)
-	HandleCommandNext(context.Context, int64, *models.User) (tgbotapi.Chattable, error)
+	HandleSkip(ctx context.Context, chatId int64, user *models.User) (tgbotapi.Chattable, error)
+	HandleCancel(ctx context.Context, chatId int64, user *models.User) (tgbotapi.Chattable, error)
+	DialogReminder(chatId int64, user *models.User) tgbotapi.Chattable
+	SwipeRestriction(chatId int64, user *models.User) tgbotapi.Chattable
+	HandleCommandNext(context.Context, int64, *models.User) ([]tgbotapi.Chattable, error)
+	HandleMatches(ctx context.Context, chatId int64, user *models.User, page int) (tgbotapi.Chattable, error)
+	HandleLikes(ctx context.Context, chatId int64, user *models.User) (tgbotapi.Chattable, error)
+	HandleUndo(ctx context.Context, chatId int64, user *models.User) ([]tgbotapi.Chattable, error)
+	HandlePause(ctx context.Context, chatId int64, user *models.User) (tgbotapi.MessageConfig, error)
+	HandleResume(ctx context.Context, chatId int64, user *models.User) (tgbotapi.MessageConfig, error)
+	HandleDelete(ctx context.Context, chatId int64, user *models.User) (tgbotapi.MessageConfig, error)
+	HandleDeleteConfirm(ctx context.Context, chatId int64, user *models.User, confirmed bool) (tgbotapi.MessageConfig, error)
+	HandleReport(ctx context.Context, chatId int64, user *models.User, toId int64) (tgbotapi.MessageConfig, error)
+	HandleBlock(ctx context.Context, chatId int64, user *models.User, toId int64) (tgbotapi.MessageConfig, error)
+	HandleAdmin(ctx context.Context, chatId int64, user *models.User) ([]tgbotapi.Chattable, error)
+	HandleReview(ctx context.Context, chatId int64, moderator *models.User, action string, userId int64) ([]tgbotapi.Chattable, error)
 
-	AddOrUpdateLike(ctx context.Context, likeValue bool, fromId, toId string) error
-	HasLikeWithTrueValue(ctx context.Context, fromId, toId string) (bool, error)
-	CreateMatchMessages(user1, user2 *models.User) (tgbotapi.Chattable, tgbotapi.Chattable, error)
+	AddOrUpdateLike(ctx context.Context, likeValue bool, fromId, toId int64) error
+	HasLikeWithTrueValue(ctx context.Context, fromId, toId int64) (bool, error)
+	CreateMatchMessages(ctx context.Context, user1, user2 *models.User) ([]tgbotapi.Chattable, []tgbotapi.Chattable, error)
+	CreateLikeNotification(ctx context.Context, toUser *models.User) (tgbotapi.Chattable, error)
 
-	GetUserByIdOrNil(ctx context.Context, userId string) (*models.User, error)
+	GetUserByIdOrNil(ctx context.Context, userId int64) (*models.User, error)
+	DeleteExpiredCards(ctx context.Context) error
 
 	DeleteAll(ctx context.Context) error
 	AddTestUser(ctx context.Context, sex bool) error
-	AddTestUserWithLike(ctx context.Context, sex bool, toId string) error
+	AddTestUserWithLike(ctx context.Context, sex bool, toId int64) error
 }
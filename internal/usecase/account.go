@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// HandlePause hides the user from others, the profile and likes are kept.
+func (u *Usecase) HandlePause(ctx context.Context, chatId int64, user *models.User) (tgbotapi.MessageConfig, error) {
+	u.logger(ctx).Info("handlePause")
+
+	if user.Paused {
+		return tgbotapi.NewMessage(chatId, "Анкета уже скрыта. Чтобы снова показывать её, отправьте /resume"), nil
+	}
+
+	user.Paused = true
+	if err := u.saveUser(ctx, user); err != nil {
+		return tgbotapi.MessageConfig{}, err
+	}
+
+	return tgbotapi.NewMessage(chatId, "Анкета скрыта, её больше никто не увидит. Чтобы снова показывать её, отправьте /resume"), nil
+}
+
+func (u *Usecase) HandleResume(ctx context.Context, chatId int64, user *models.User) (tgbotapi.MessageConfig, error) {
+	u.logger(ctx).Info("handleResume")
+
+	if !user.Paused {
+		return tgbotapi.NewMessage(chatId, "Анкета и так показывается другим пользователям."), nil
+	}
+
+	user.Paused = false
+	if err := u.saveUser(ctx, user); err != nil {
+		return tgbotapi.MessageConfig{}, err
+	}
+
+	return tgbotapi.NewMessage(chatId, "Анкета снова показывается другим пользователям."), nil
+}
+
+// HandleDelete asks to confirm the deletion, nothing is deleted until HandleDeleteConfirm.
+func (u *Usecase) HandleDelete(ctx context.Context, chatId int64, _ *models.User) (tgbotapi.MessageConfig, error) {
+	u.logger(ctx).Info("handleDelete")
+
+	outputMsg := tgbotapi.NewMessage(chatId, "Анкета, фотографии, лайки и совпадения будут удалены без возможности восстановления. "+
+		"Если Вы только хотите перестать показываться другим, отправьте /pause\n\nУдалить анкету?")
+	outputMsg.ReplyMarkup = u.keyboards.CreateDeleteKeyboardMarkup()
+
+	return outputMsg, nil
+}
+
+// HandleDeleteConfirm erases everything about the user if confirmed.
+func (u *Usecase) HandleDeleteConfirm(ctx context.Context, chatId int64, user *models.User, confirmed bool) (tgbotapi.MessageConfig, error) {
+	u.logger(ctx).Info("handleDeleteConfirm")
+
+	if !confirmed {
+		return tgbotapi.NewMessage(chatId, "Удаление отменено."), nil
+	}
+
+	if err := u.users.DeleteByUserId(ctx, user.Id); err != nil {
+		u.logger(ctx).Errorw("could not delete user", "err", err)
+		return tgbotapi.MessageConfig{}, fmt.Errorf("delete user: %w", err)
+	}
+
+	return tgbotapi.NewMessage(chatId, "Анкета удалена. Чтобы начать заново, отправьте /start"), nil
+}
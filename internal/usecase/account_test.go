@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/Eretic431/datingTelegramBot/internal/mock"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	"testing"
+)
+
+func TestUsecase_HandlePause(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &models.User{Id: 1}
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	usersRepo.EXPECT().
+		UpdateByUserId(gomock.Any(), &models.User{Id: 1, Paused: true}).
+		Return(nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	msgCfg, err := usecase.HandlePause(context.Background(), 1, user)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, msgCfg.ChatID)
+	assert.True(t, user.Paused)
+}
+
+func TestUsecase_HandleResume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &models.User{Id: 1, Paused: true}
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	usersRepo.EXPECT().
+		UpdateByUserId(gomock.Any(), &models.User{Id: 1}).
+		Return(nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	msgCfg, err := usecase.HandleResume(context.Background(), 1, user)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, msgCfg.ChatID)
+	assert.False(t, user.Paused)
+}
+
+func TestUsecase_HandleResume_ShouldNotUpdateIfNotPaused(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := NewUsecase(
+		mock.NewMockUsersRepository(ctrl),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	_, err := usecase.HandleResume(context.Background(), 1, &models.User{Id: 1})
+	assert.Nil(t, err)
+}
+
+func TestUsecase_HandleDelete_ShouldAskForConfirmation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := NewUsecase(
+		mock.NewMockUsersRepository(ctrl),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	msgCfg, err := usecase.HandleDelete(context.Background(), 1, &models.User{Id: 1})
+	assert.Nil(t, err)
+
+	_, ok := msgCfg.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup)
+	assert.True(t, ok)
+}
+
+func TestUsecase_HandleDeleteConfirm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	usersRepo.EXPECT().
+		DeleteByUserId(gomock.Any(), int64(1)).
+		Return(nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	msgCfg, err := usecase.HandleDeleteConfirm(context.Background(), 1, &models.User{Id: 1}, true)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, msgCfg.ChatID)
+}
+
+func TestUsecase_HandleDeleteConfirm_ShouldNotDeleteOnCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := NewUsecase(
+		mock.NewMockUsersRepository(ctrl),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	msgCfg, err := usecase.HandleDeleteConfirm(context.Background(), 1, &models.User{Id: 1}, false)
+	assert.Nil(t, err)
+	assert.EqualValues(t, "Удаление отменено.", msgCfg.Text)
+}
+
+func TestUsecase_HandleDeleteConfirm_ShouldReturnErrorOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("some error")
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	usersRepo.EXPECT().
+		DeleteByUserId(gomock.Any(), int64(1)).
+		Return(expectedError).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	_, err := usecase.HandleDeleteConfirm(context.Background(), 1, &models.User{Id: 1}, true)
+	assert.True(t, errors.Is(err, expectedError))
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const emptyReviewQueueText = "Очередь модерации пуста."
+
+// HandleAdmin shows the next profile to review: reported ones first, then profiles with new photos.
+// The caller is responsible for checking that the user is a moderator.
+func (u *Usecase) HandleAdmin(ctx context.Context, chatId int64, _ *models.User) ([]tgbotapi.Chattable, error) {
+	u.logger(ctx).Info("handleAdmin")
+
+	userId, reports, err := u.nextReview(ctx)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			return []tgbotapi.Chattable{tgbotapi.NewMessage(chatId, emptyReviewQueueText)}, nil
+		}
+		return nil, err
+	}
+
+	reviewed, err := u.users.GetByUserId(ctx, userId)
+	if err != nil {
+		u.logger(ctx).Errorw("could not get reviewed user", "err", err)
+		return nil, fmt.Errorf("get reviewed user: %w", err)
+	}
+
+	return u.createReviewCards(ctx, chatId, reviewed, reports)
+}
+
+// HandleReview applies the moderator decision about userId and shows the next profile to review.
+func (u *Usecase) HandleReview(ctx context.Context, chatId int64, moderator *models.User, action string, userId int64) ([]tgbotapi.Chattable, error) {
+	u.logger(ctx).Info("handleReview")
+
+	var text string
+	switch action {
+	case internal.ReviewActionApprove:
+		if err := u.unhide(ctx, userId); err != nil {
+			return nil, err
+		}
+		text = "Анкета одобрена."
+	case internal.ReviewActionBan:
+		if err := u.users.SetBanned(ctx, userId, true); err != nil && !errors.Is(err, models.ErrNoRecord) {
+			u.logger(ctx).Errorw("could not ban user", "err", err)
+			return nil, fmt.Errorf("ban user: %w", err)
+		}
+		text = "Пользователь забанен."
+	case internal.ReviewActionDismiss:
+		// The reports are resolved below, so a profile hidden by them would never be shown or reviewed again
+		if err := u.unhide(ctx, userId); err != nil {
+			return nil, err
+		}
+		text = "Анкета пропущена."
+	default:
+		return []tgbotapi.Chattable{tgbotapi.NewMessage(chatId, "Неизвестное действие.")}, nil
+	}
+
+	if err := u.reports.ResolveByUserId(ctx, userId); err != nil {
+		u.logger(ctx).Errorw("could not resolve reports", "err", err)
+		return nil, fmt.Errorf("resolve reports: %w", err)
+	}
+
+	if err := u.photos.SetReviewedByUserId(ctx, userId); err != nil {
+		u.logger(ctx).Errorw("could not set photos reviewed", "err", err)
+		return nil, fmt.Errorf("set photos reviewed: %w", err)
+	}
+
+	u.logger(ctx).Infow("moderation action", "moderator", moderator.Id, "action", action, "user", userId)
+
+	next, err := u.HandleAdmin(ctx, chatId, moderator)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]tgbotapi.Chattable{tgbotapi.NewMessage(chatId, text)}, next...), nil
+}
+
+// unhide shows the profile hidden by reports again, deleted users are skipped.
+func (u *Usecase) unhide(ctx context.Context, userId int64) error {
+	if err := u.users.SetHidden(ctx, userId, false); err != nil && !errors.Is(err, models.ErrNoRecord) {
+		u.logger(ctx).Errorw("could not unhide user", "err", err)
+		return fmt.Errorf("unhide user: %w", err)
+	}
+	return nil
+}
+
+// nextReview returns the next user in the moderation queue with its unresolved reports, or models.ErrNoRecord.
+func (u *Usecase) nextReview(ctx context.Context) (int64, []*models.Report, error) {
+	userId, err := u.reports.GetNextReportedId(ctx)
+	if err == nil {
+		reports, err := u.reports.GetUnresolvedByUserId(ctx, userId)
+		if err != nil {
+			u.logger(ctx).Errorw("could not get reports", "err", err)
+			return 0, nil, fmt.Errorf("get reports: %w", err)
+		}
+		return userId, reports, nil
+	}
+	if !errors.Is(err, models.ErrNoRecord) {
+		u.logger(ctx).Errorw("could not get next reported user", "err", err)
+		return 0, nil, fmt.Errorf("get next reported user: %w", err)
+	}
+
+	userId, err = u.photos.GetNextUnreviewedUserId(ctx)
+	if err != nil {
+		if !errors.Is(err, models.ErrNoRecord) {
+			u.logger(ctx).Errorw("could not get next unreviewed user", "err", err)
+		}
+		return 0, nil, fmt.Errorf("get next unreviewed user: %w", err)
+	}
+
+	return userId, nil, nil
+}
+
+func (u *Usecase) createReviewCards(ctx context.Context, chatId int64, user *models.User, reports []*models.Report) ([]tgbotapi.Chattable, error) {
+	fileIds, err := u.photoFileIds(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	caption := internal.CreateReviewCaption(user, reports)
+	keyboard := u.keyboards.CreateReviewKeyboardMarkup(user.Id)
+
+	switch len(fileIds) {
+	case 0:
+		outputMsg := tgbotapi.NewMessage(chatId, caption)
+		outputMsg.ParseMode = tgbotapi.ModeMarkdown
+		outputMsg.ReplyMarkup = keyboard
+		return []tgbotapi.Chattable{outputMsg}, nil
+	case 1:
+		photoCfg := tgbotapi.NewPhoto(chatId, tgbotapi.FileID(fileIds[0]))
+		photoCfg.Caption = caption
+		photoCfg.ParseMode = tgbotapi.ModeMarkdown
+		photoCfg.ReplyMarkup = keyboard
+		return []tgbotapi.Chattable{photoCfg}, nil
+	}
+
+	keyboardMsg := tgbotapi.NewMessage(chatId, "Решение по анкете:")
+	keyboardMsg.ReplyMarkup = keyboard
+
+	return []tgbotapi.Chattable{createAlbum(chatId, fileIds, caption), keyboardMsg}, nil
+}
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/Eretic431/datingTelegramBot/internal/mock"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	"testing"
+)
+
+func TestUsecase_HandleAdmin_ShouldShowReportedUserFirst(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reported := &models.User{Id: 2, Name: "name", Image: "image"}
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	photosRepo := mock.NewMockPhotosRepository(ctrl)
+	reportsRepo := mock.NewMockReportsRepository(ctrl)
+
+	reportsRepo.EXPECT().
+		GetNextReportedId(gomock.Any()).
+		Return(int64(2), nil).
+		Times(1)
+	reportsRepo.EXPECT().
+		GetUnresolvedByUserId(gomock.Any(), int64(2)).
+		Return([]*models.Report{{FromId: 1, ToId: 2, Reason: "Спам или реклама"}}, nil).
+		Times(1)
+	usersRepo.EXPECT().
+		GetByUserId(gomock.Any(), int64(2)).
+		Return(reported, nil).
+		Times(1)
+	photosRepo.EXPECT().
+		GetByUserId(gomock.Any(), int64(2)).
+		Return(nil, nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		photosRepo,
+		nil,
+		reportsRepo,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	chattables, err := usecase.HandleAdmin(context.Background(), 1, &models.User{Id: 1})
+	assert.Nil(t, err)
+	assert.Len(t, chattables, 1)
+
+	photoCfg, ok := chattables[0].(tgbotapi.PhotoConfig)
+	assert.True(t, ok)
+	assert.Contains(t, photoCfg.Caption, "Спам или реклама")
+	assert.EqualValues(t, internal.NewKeyboards(testCallbacks, zaptest.NewLogger(t).Sugar()).CreateReviewKeyboardMarkup(2), photoCfg.ReplyMarkup)
+}
+
+func TestUsecase_HandleAdmin_ShouldReturnEmptyQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	photosRepo := mock.NewMockPhotosRepository(ctrl)
+	reportsRepo := mock.NewMockReportsRepository(ctrl)
+
+	reportsRepo.EXPECT().
+		GetNextReportedId(gomock.Any()).
+		Return(int64(0), models.ErrNoRecord).
+		Times(1)
+	photosRepo.EXPECT().
+		GetNextUnreviewedUserId(gomock.Any()).
+		Return(int64(0), models.ErrNoRecord).
+		Times(1)
+
+	usecase := NewUsecase(
+		nil,
+		nil,
+		photosRepo,
+		nil,
+		reportsRepo,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	chattables, err := usecase.HandleAdmin(context.Background(), 1, &models.User{Id: 1})
+	assert.Nil(t, err)
+	assert.Len(t, chattables, 1)
+	assert.EqualValues(t, emptyReviewQueueText, chattables[0].(tgbotapi.MessageConfig).Text)
+}
+
+func TestUsecase_HandleReview(t *testing.T) {
+	cases := []struct {
+		action string
+		expect func(usersRepo *mock.MockUsersRepository)
+	}{
+		{internal.ReviewActionApprove, func(usersRepo *mock.MockUsersRepository) {
+			usersRepo.EXPECT().SetHidden(gomock.Any(), int64(2), false).Return(nil).Times(1)
+		}},
+		{internal.ReviewActionBan, func(usersRepo *mock.MockUsersRepository) {
+			usersRepo.EXPECT().SetBanned(gomock.Any(), int64(2), true).Return(nil).Times(1)
+		}},
+		{internal.ReviewActionDismiss, func(usersRepo *mock.MockUsersRepository) {
+			usersRepo.EXPECT().SetHidden(gomock.Any(), int64(2), false).Return(nil).Times(1)
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.action, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			usersRepo := mock.NewMockUsersRepository(ctrl)
+			photosRepo := mock.NewMockPhotosRepository(ctrl)
+			reportsRepo := mock.NewMockReportsRepository(ctrl)
+
+			c.expect(usersRepo)
+			reportsRepo.EXPECT().
+				ResolveByUserId(gomock.Any(), int64(2)).
+				Return(nil).
+				Times(1)
+			photosRepo.EXPECT().
+				SetReviewedByUserId(gomock.Any(), int64(2)).
+				Return(nil).
+				Times(1)
+			reportsRepo.EXPECT().
+				GetNextReportedId(gomock.Any()).
+				Return(int64(0), models.ErrNoRecord).
+				Times(1)
+			photosRepo.EXPECT().
+				GetNextUnreviewedUserId(gomock.Any()).
+				Return(int64(0), models.ErrNoRecord).
+				Times(1)
+
+			usecase := NewUsecase(
+				usersRepo,
+				nil,
+				photosRepo,
+				nil,
+				reportsRepo,
+				nil,
+				testCallbacks,
+				nil,
+				zaptest.NewLogger(t).Sugar(),
+				testConfig,
+			)
+
+			chattables, err := usecase.HandleReview(context.Background(), 1, &models.User{Id: 1}, c.action, 2)
+			assert.Nil(t, err)
+			assert.Len(t, chattables, 2)
+			assert.EqualValues(t, emptyReviewQueueText, chattables[1].(tgbotapi.MessageConfig).Text)
+		})
+	}
+}
+
+func TestUsecase_HandleReview_DismissShouldUnhideReportedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	photosRepo := mock.NewMockPhotosRepository(ctrl)
+	reportsRepo := mock.NewMockReportsRepository(ctrl)
+
+	// The user was hidden after testConfig.ReportsToHide reports, dismissing them has to show the profile again
+	gomock.InOrder(
+		usersRepo.EXPECT().
+			SetHidden(gomock.Any(), int64(2), false).
+			Return(nil).
+			Times(1),
+		reportsRepo.EXPECT().
+			ResolveByUserId(gomock.Any(), int64(2)).
+			Return(nil).
+			Times(1),
+	)
+	photosRepo.EXPECT().
+		SetReviewedByUserId(gomock.Any(), int64(2)).
+		Return(nil).
+		Times(1)
+	reportsRepo.EXPECT().
+		GetNextReportedId(gomock.Any()).
+		Return(int64(0), models.ErrNoRecord).
+		Times(1)
+	photosRepo.EXPECT().
+		GetNextUnreviewedUserId(gomock.Any()).
+		Return(int64(0), models.ErrNoRecord).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		photosRepo,
+		nil,
+		reportsRepo,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	chattables, err := usecase.HandleReview(context.Background(), 1, &models.User{Id: 1}, internal.ReviewActionDismiss, 2)
+	assert.Nil(t, err)
+	assert.EqualValues(t, "Анкета пропущена.", chattables[0].(tgbotapi.MessageConfig).Text)
+}
+
+func TestUsecase_SwipeRestriction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := NewUsecase(
+		mock.NewMockUsersRepository(ctrl),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	assert.NotNil(t, usecase.SwipeRestriction(1, &models.User{Id: 1, Age: 20, Banned: true}))
+	assert.NotNil(t, usecase.SwipeRestriction(1, &models.User{Id: 1, Age: testConfig.MinAge - 1}))
+	assert.Nil(t, usecase.SwipeRestriction(1, &models.User{Id: 1, Age: testConfig.MinAge}))
+}
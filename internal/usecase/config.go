@@ -0,0 +1,17 @@
+package usecase
+
+import "time"
+
+type Config struct {
+	MinAge int // Younger users can't fill the profile and aren't shown to others
+	MaxAge int
+
+	MaxPhotos int // Photos per profile, Telegram albums can't be larger than 10
+
+	LikeNotifications bool          // Tell users when somebody likes them
+	UndoWindow        time.Duration // Only swipes newer than that can be undone
+	ServedCardTTL     time.Duration // A profile can be liked or disliked for that long after it was shown
+	DailyLikes        int           // Likes per user in 24 hours, 0 disables the quota
+
+	ReportsToHide int // Users reported by that many others are hidden until review, 0 disables hiding
+}
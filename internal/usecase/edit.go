@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// editFields are names of the profile fields, indexed by their stage
+var editFields = []string{"Имя", "Возраст", "Город", "Описание", "Фотографии", "Пол"}
+
+// HandleEdit shows the menu with one button per profile field.
+func (u *Usecase) HandleEdit(ctx context.Context, chatId int64, user *models.User) (tgbotapi.MessageConfig, error) {
+	u.logger(ctx).Info("handleEdit")
+
+	// Gender is asked last, so without it the profile isn't filled yet
+	if user.Gender == 0 {
+		return tgbotapi.NewMessage(chatId, "Сначала заполните анкету командой /profile"), nil
+	}
+
+	outputMsg := tgbotapi.NewMessage(chatId, "Что Вы хотите изменить?")
+	outputMsg.ReplyMarkup = u.keyboards.CreateEditKeyboardMarkup(editFields...)
+
+	return outputMsg, nil
+}
+
+// HandleEditField asks a single step of the profile dialog. After it is filled the user gets back to the profile card.
+func (u *Usecase) HandleEditField(ctx context.Context, chatId int64, user *models.User, stage int) (tgbotapi.MessageConfig, error) {
+	u.logger(ctx).Info("handleEditField")
+
+	if !u.profile.Has(stage) || user.Gender == 0 {
+		return tgbotapi.NewMessage(chatId, "Данные введены некорректно, попробуйте снова."), nil
+	}
+
+	user.Editing = true
+	return u.profile.Start(ctx, chatId, user, stage)
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/Eretic431/datingTelegramBot/internal/mock"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	"testing"
+)
+
+func TestUsecase_HandleEdit(t *testing.T) {
+	usecase := NewUsecase(
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	msgCfg, err := usecase.HandleEdit(context.Background(), 1, &models.User{Id: 1, Gender: models.GenderMale})
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, msgCfg.ChatID)
+
+	keyboard, ok := msgCfg.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup)
+	assert.True(t, ok)
+	assert.EqualValues(t, callbackData(t, internal.CallbackEdit, "0"), *keyboard.InlineKeyboard[0][0].CallbackData)
+}
+
+func TestUsecase_HandleEdit_ShouldAskToFillProfileFirst(t *testing.T) {
+	usecase := NewUsecase(
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	msgCfg, err := usecase.HandleEdit(context.Background(), 1, &models.User{Id: 1})
+	assert.Nil(t, err)
+	assert.Nil(t, msgCfg.ReplyMarkup)
+}
+
+func TestUsecase_HandleEditField(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &models.User{Id: 1, City: "city", Gender: models.GenderMale, Stage: ProfileStageNone}
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	usersRepo.EXPECT().
+		UpdateByUserId(gomock.Any(), &models.User{Id: 1, City: "city", Gender: models.GenderMale, Stage: 2, Editing: true}).
+		Return(nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	msgCfg, err := usecase.HandleEditField(context.Background(), 1, user, 2)
+	assert.Nil(t, err)
+	assert.EqualValues(t, usecase.(*Usecase).Prompt(ProfileStageCity), msgCfg.Text)
+
+	keyboard, ok := msgCfg.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup)
+	assert.True(t, ok)
+	assert.EqualValues(t, callbackData(t, internal.CallbackSkip, ""), *keyboard.InlineKeyboard[0][0].CallbackData)
+	assert.EqualValues(t, callbackData(t, internal.CallbackCancel, ""), *keyboard.InlineKeyboard[1][0].CallbackData)
+}
+
+func TestUsecase_HandleEditField_ShouldReturnErrorOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("some error")
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	usersRepo.EXPECT().
+		UpdateByUserId(gomock.Any(), gomock.Any()).
+		Return(expectedError).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	_, err := usecase.HandleEditField(context.Background(), 1, &models.User{Id: 1, Gender: models.GenderMale}, 0)
+	assert.True(t, errors.Is(err, expectedError))
+}
+
+func TestUsecase_HandleFillingProfile_EditingShouldReturnToProfileCard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &models.User{Id: 1, City: "old", Image: "image", Gender: models.GenderMale, Stage: 2, Editing: true}
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	usersRepo.EXPECT().
+		UpdateByUserId(gomock.Any(), user).
+		Return(nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	chattable, err := usecase.HandleFillingProfile(context.Background(), "new", 1, "", user)
+	assert.Nil(t, err)
+
+	photoCfg, ok := chattable.(tgbotapi.PhotoConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, 1, photoCfg.ChatID)
+	assert.EqualValues(t, "new", user.City)
+	assert.EqualValues(t, ProfileStageNone, user.Stage)
+	assert.False(t, user.Editing)
+}
+
+func TestUsecase_HandleCancel_ShouldLeaveEditing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &models.User{Id: 1, City: "city", Image: "image", Gender: models.GenderMale, Stage: ProfileStageCity, Editing: true}
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	usersRepo.EXPECT().
+		UpdateByUserId(gomock.Any(), &models.User{Id: 1, City: "city", Image: "image", Gender: models.GenderMale, Stage: ProfileStageNone}).
+		Return(nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	chattable, err := usecase.HandleCancel(context.Background(), 1, user)
+	assert.Nil(t, err)
+
+	photoCfg, ok := chattable.(tgbotapi.PhotoConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, 1, photoCfg.ChatID)
+	assert.EqualValues(t, "city", user.City)
+}
+
+func TestUsecase_HandleCancel_EditingShouldKeepPhotosStageWithoutPhotos(t *testing.T) {
+	user := &models.User{Id: 1, Gender: models.GenderMale, Stage: ProfileStagePhotos, Editing: true}
+
+	usecase := NewUsecase(
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	chattable, err := usecase.HandleCancel(context.Background(), 1, user)
+	assert.Nil(t, err)
+
+	_, ok := chattable.(tgbotapi.MessageConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, ProfileStagePhotos, user.Stage)
+	assert.True(t, user.Editing)
+}
@@ -3,12 +3,64 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/Eretic431/datingTelegramBot/internal"
 	"github.com/Eretic431/datingTelegramBot/internal/data/models"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"time"
 )
 
-func (u *Usecase) AddOrUpdateLike(ctx context.Context, likeValue bool, fromId, toId string) error {
+// ErrCardNotServed is returned for a swipe of a profile which wasn't shown to the user, or was shown too long ago
+var ErrCardNotServed = errors.New("card was not served")
+
+// ErrLikeQuotaExceeded is returned for a like when the user already sent Config.DailyLikes of them in 24 hours
+var ErrLikeQuotaExceeded = errors.New("daily like quota exceeded")
+
+// AddOrUpdateLike stores the swipe of toId, which must be served to fromId by serveCard less than ServedCardTTL ago.
+// Likes are also limited by the daily quota.
+func (u *Usecase) AddOrUpdateLike(ctx context.Context, likeValue bool, fromId, toId int64) error {
+	servedAt, err := u.servedCards.GetServedAt(ctx, fromId, toId)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			u.logger(ctx).Warnw("rejected swipe of a profile which was not served", "to_id", toId)
+			return ErrCardNotServed
+		}
+		u.logger(ctx).Errorw("could not get served card", "err", err)
+		return fmt.Errorf("get served card: %w", err)
+	}
+
+	if time.Since(servedAt) > u.config.ServedCardTTL {
+		u.logger(ctx).Warnw("rejected swipe of an expired profile", "to_id", toId, "served_at", servedAt)
+		return ErrCardNotServed
+	}
+
+	if likeValue && u.config.DailyLikes > 0 {
+		count, err := u.likes.CountLikesSince(ctx, fromId, time.Now().Add(-24*time.Hour))
+		if err != nil {
+			u.logger(ctx).Errorw("could not count likes", "err", err)
+			return fmt.Errorf("count likes: %w", err)
+		}
+
+		if count >= u.config.DailyLikes {
+			u.logger(ctx).Infow("user exhausted the daily likes", "count", count)
+			return ErrLikeQuotaExceeded
+		}
+	}
+
+	if err := u.setLike(ctx, likeValue, fromId, toId); err != nil {
+		return err
+	}
+
+	// The card can't be swiped twice, undo serves it again. The swipe is stored already, so errors are only logged.
+	if err := u.servedCards.Delete(ctx, fromId, toId); err != nil && !errors.Is(err, models.ErrNoRecord) {
+		u.logger(ctx).Warnw("could not delete served card", "to_id", toId, "err", err)
+	}
+
+	return nil
+}
+
+// setLike adds the like or changes its value if the user already swiped toId.
+func (u *Usecase) setLike(ctx context.Context, likeValue bool, fromId, toId int64) error {
 	oldLike, err := u.likes.Get(ctx, fromId, toId)
 
 	if err != nil {
@@ -20,31 +72,31 @@ func (u *Usecase) AddOrUpdateLike(ctx context.Context, likeValue bool, fromId, t
 			})
 
 			if err != nil {
-				u.log.Errorf("could not insert like with error %e", err)
-				return err
+				u.logger(ctx).Errorw("could not insert like", "err", err)
+				return fmt.Errorf("insert like: %w", err)
 			}
 		} else {
-			u.log.Errorf("could not get like with error %e", err)
-			return err
+			u.logger(ctx).Errorw("could not get like", "err", err)
+			return fmt.Errorf("get like: %w", err)
 		}
 	} else {
 		oldLike.Value = likeValue
 		err := u.likes.Update(ctx, oldLike)
 		if err != nil {
-			u.log.Errorf("could not update like with error %e", err)
-			return err
+			u.logger(ctx).Errorw("could not update like", "err", err)
+			return fmt.Errorf("update like: %w", err)
 		}
 	}
 
 	return nil
 }
 
-func (u *Usecase) HasLikeWithTrueValue(ctx context.Context, fromId, toId string) (bool, error) {
+func (u *Usecase) HasLikeWithTrueValue(ctx context.Context, fromId, toId int64) (bool, error) {
 	reverseLike, err := u.likes.Get(ctx, fromId, toId)
 	if err != nil {
 		if !errors.Is(err, models.ErrNoRecord) {
-			u.log.Errorf("could not get reverse like with error %e", err)
-			return false, err
+			u.logger(ctx).Errorw("could not get reverse like", "err", err)
+			return false, fmt.Errorf("get reverse like: %w", err)
 		}
 		return false, nil
 	}
@@ -52,20 +104,107 @@ func (u *Usecase) HasLikeWithTrueValue(ctx context.Context, fromId, toId string)
 	return reverseLike.Value, nil
 }
 
-func (u *Usecase) CreateMatchMessages(user1, user2 *models.User) (tgbotapi.Chattable, tgbotapi.Chattable, error) {
+// CreateMatchMessages returns the messages for user1 and for user2, each of them gets the profile of the other one.
+func (u *Usecase) CreateMatchMessages(ctx context.Context, user1, user2 *models.User) ([]tgbotapi.Chattable, []tgbotapi.Chattable, error) {
 	if user1 == nil || user2 == nil {
-		u.log.Errorf("couldn't create match messages, because users are nil")
+		u.logger(ctx).Error("could not create match messages, because users are nil")
 		return nil, nil, errors.New("couldn't create match messages, because users are nil")
 	}
-	ava1 := tgbotapi.FileID(user1.Image)
-	match2Message := tgbotapi.NewPhoto(user2.ChatId, ava1)
-	match2Message.Caption = internal.CreateMatchCaption(user1)
-	match2Message.ParseMode = tgbotapi.ModeMarkdown
 
-	ava2 := tgbotapi.FileID(user2.Image)
-	match1Message := tgbotapi.NewPhoto(user1.ChatId, ava2)
-	match1Message.Caption = internal.CreateMatchCaption(user2)
-	match1Message.ParseMode = tgbotapi.ModeMarkdown
+	match2Messages, err := u.createMatchMessages(ctx, user2.ChatId, user1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	match1Messages, err := u.createMatchMessages(ctx, user1.ChatId, user2)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return match1Messages, match2Messages, nil
+}
+
+// createMatchMessages tells the owner of chatId about the match with user, sending all photos of user as an album.
+// An album can't have a keyboard, so the report and block buttons go in a separate message.
+func (u *Usecase) createMatchMessages(ctx context.Context, chatId int64, user *models.User) ([]tgbotapi.Chattable, error) {
+	fileIds, err := u.photoFileIds(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fileIds) > 1 {
+		keyboardMsg := tgbotapi.NewMessage(chatId, "Если с анкетой что-то не так, пожалуйтесь на неё или заблокируйте пользователя.")
+		keyboardMsg.ReplyMarkup = u.keyboards.CreateMatchKeyboardMarkup(user.Id)
+
+		return []tgbotapi.Chattable{createAlbum(chatId, fileIds, internal.CreateMatchCaption(user)), keyboardMsg}, nil
+	}
+
+	matchMessage := tgbotapi.NewPhoto(chatId, tgbotapi.FileID(user.Image))
+	matchMessage.Caption = internal.CreateMatchCaption(user)
+	matchMessage.ParseMode = tgbotapi.ModeMarkdown
+	matchMessage.ReplyMarkup = u.keyboards.CreateMatchKeyboardMarkup(user.Id)
+
+	return []tgbotapi.Chattable{matchMessage}, nil
+}
+
+const noLikesText = "Пока никто не оценил Вашу анкету. Попробуйте команду /next"
+
+// HandleLikes shows the next user who liked the current one and hasn't been rated back yet.
+func (u *Usecase) HandleLikes(ctx context.Context, chatId int64, user *models.User) (tgbotapi.Chattable, error) {
+	u.logger(ctx).Info("handleLikes")
+
+	count, err := u.likes.CountUnansweredLikes(ctx, user.Id, u.config.MinAge)
+	if err != nil {
+		u.logger(ctx).Errorw("could not count unanswered likes", "err", err)
+		return tgbotapi.MessageConfig{}, fmt.Errorf("count unanswered likes: %w", err)
+	}
+
+	if count == 0 {
+		return tgbotapi.NewMessage(chatId, noLikesText), nil
+	}
+
+	liker, err := u.users.GetNextLiker(ctx, user.Id, u.config.MinAge)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			return tgbotapi.NewMessage(chatId, noLikesText), nil
+		}
+		u.logger(ctx).Errorw("could not get next liker", "err", err)
+		return tgbotapi.MessageConfig{}, fmt.Errorf("get next liker: %w", err)
+	}
+
+	if err := u.serveCard(ctx, user.Id, liker.Id); err != nil {
+		return tgbotapi.MessageConfig{}, err
+	}
+
+	if len(liker.Image) > 0 {
+		photoCfg := tgbotapi.NewPhoto(chatId, tgbotapi.FileID(liker.Image))
+		photoCfg.Caption = internal.CreateLikesCaption(liker, count)
+		photoCfg.ParseMode = tgbotapi.ModeMarkdown
+		photoCfg.ReplyMarkup = u.keyboards.CreateLikesInboxKeyboardMarkup(liker.Id)
+		return photoCfg, nil
+	}
+
+	msgConfig := tgbotapi.NewMessage(chatId, internal.CreateLikesCaption(liker, count))
+	msgConfig.ParseMode = tgbotapi.ModeMarkdown
+	msgConfig.ReplyMarkup = u.keyboards.CreateLikesInboxKeyboardMarkup(liker.Id)
+	return msgConfig, nil
+}
+
+// CreateLikeNotification returns a message for the liked user, or nil if notifications are disabled.
+func (u *Usecase) CreateLikeNotification(ctx context.Context, toUser *models.User) (tgbotapi.Chattable, error) {
+	if !u.config.LikeNotifications || toUser == nil {
+		return nil, nil
+	}
+
+	count, err := u.likes.CountUnansweredLikes(ctx, toUser.Id, u.config.MinAge)
+	if err != nil {
+		u.logger(ctx).Errorw("could not count unanswered likes", "err", err)
+		return nil, fmt.Errorf("count unanswered likes: %w", err)
+	}
+
+	if count == 0 {
+		return nil, nil
+	}
 
-	return match1Message, match2Message, nil
+	return tgbotapi.NewMessage(toUser.ChatId, fmt.Sprintf("Вы понравились пользователям: %d. Посмотреть их анкеты: /likes", count)), nil
 }
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal"
 	"github.com/Eretic431/datingTelegramBot/internal/data/models"
 	"github.com/Eretic431/datingTelegramBot/internal/mock"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zaptest"
 	"testing"
+	"time"
 )
 
 func TestUsecase_AddOrUpdateLike(t *testing.T) {
@@ -17,8 +19,8 @@ func TestUsecase_AddOrUpdateLike(t *testing.T) {
 	defer ctrl.Finish()
 
 	likesRepo := mock.NewMockLikesRepository(ctrl)
-	fromId := "user1"
-	toId := "user2"
+	fromId := int64(1)
+	toId := int64(2)
 	ctx := context.Background()
 
 	expectedLike := &models.Like{Value: true}
@@ -34,11 +36,27 @@ func TestUsecase_AddOrUpdateLike(t *testing.T) {
 		Return(nil).
 		Times(1)
 
+	servedCardsRepo := mock.NewMockServedCardsRepository(ctrl)
+	servedCardsRepo.EXPECT().
+		GetServedAt(ctx, fromId, toId).
+		Return(time.Now(), nil).
+		Times(1)
+	servedCardsRepo.EXPECT().
+		Delete(ctx, fromId, toId).
+		Return(nil).
+		Times(1)
+
 	usecase := NewUsecase(
 		nil,
 		likesRepo,
 		nil,
+		nil,
+		nil,
+		servedCardsRepo,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	err := usecase.AddOrUpdateLike(ctx, true, fromId, toId)
@@ -50,8 +68,8 @@ func TestUsecase_AddOrUpdateLike_ShouldReturnSameErrOnUpdateFailure(t *testing.T
 	defer ctrl.Finish()
 
 	likesRepo := mock.NewMockLikesRepository(ctrl)
-	fromId := "user1"
-	toId := "user2"
+	fromId := int64(1)
+	toId := int64(2)
 	ctx := context.Background()
 
 	expectedLike := &models.Like{Value: true}
@@ -68,11 +86,23 @@ func TestUsecase_AddOrUpdateLike_ShouldReturnSameErrOnUpdateFailure(t *testing.T
 		Return(expectedErr).
 		Times(1)
 
+	servedCardsRepo := mock.NewMockServedCardsRepository(ctrl)
+	servedCardsRepo.EXPECT().
+		GetServedAt(ctx, fromId, toId).
+		Return(time.Now(), nil).
+		Times(1)
+
 	usecase := NewUsecase(
 		nil,
 		likesRepo,
 		nil,
+		nil,
+		nil,
+		servedCardsRepo,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	err := usecase.AddOrUpdateLike(ctx, true, fromId, toId)
@@ -85,8 +115,8 @@ func TestUsecase_AddOrUpdateLike_ShouldReturnSameErrOnGetLikeFailure(t *testing.
 	defer ctrl.Finish()
 
 	likesRepo := mock.NewMockLikesRepository(ctrl)
-	fromId := "user1"
-	toId := "user2"
+	fromId := int64(1)
+	toId := int64(2)
 	ctx := context.Background()
 
 	expectedErr := errors.New("some err")
@@ -96,11 +126,23 @@ func TestUsecase_AddOrUpdateLike_ShouldReturnSameErrOnGetLikeFailure(t *testing.
 		Return(nil, expectedErr).
 		Times(1)
 
+	servedCardsRepo := mock.NewMockServedCardsRepository(ctrl)
+	servedCardsRepo.EXPECT().
+		GetServedAt(ctx, fromId, toId).
+		Return(time.Now(), nil).
+		Times(1)
+
 	usecase := NewUsecase(
 		nil,
 		likesRepo,
 		nil,
+		nil,
+		nil,
+		servedCardsRepo,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	err := usecase.AddOrUpdateLike(ctx, true, fromId, toId)
@@ -113,8 +155,8 @@ func TestUsecase_AddOrUpdateLike_ShouldAddLikeIfNotExists(t *testing.T) {
 	defer ctrl.Finish()
 
 	likesRepo := mock.NewMockLikesRepository(ctrl)
-	fromId := "user1"
-	toId := "user2"
+	fromId := int64(1)
+	toId := int64(2)
 	likeToInsert := &models.Like{
 		FromId: fromId,
 		ToId:   toId,
@@ -134,24 +176,85 @@ func TestUsecase_AddOrUpdateLike_ShouldAddLikeIfNotExists(t *testing.T) {
 		Return(nil).
 		Times(1)
 
+	servedCardsRepo := mock.NewMockServedCardsRepository(ctrl)
+	servedCardsRepo.EXPECT().
+		GetServedAt(ctx, fromId, toId).
+		Return(time.Now(), nil).
+		Times(1)
+	servedCardsRepo.EXPECT().
+		Delete(ctx, fromId, toId).
+		Return(nil).
+		Times(1)
+
 	usecase := NewUsecase(
 		nil,
 		likesRepo,
 		nil,
+		nil,
+		nil,
+		servedCardsRepo,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	err := usecase.AddOrUpdateLike(ctx, true, fromId, toId)
 	assert.Nil(t, err)
 }
 
+func TestUsecase_AddOrUpdateLike_ShouldKeepSwipeIfServedCardIsNotDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	likesRepo := mock.NewMockLikesRepository(ctrl)
+	fromId := int64(1)
+	toId := int64(2)
+	ctx := context.Background()
+
+	likesRepo.EXPECT().
+		Get(ctx, fromId, toId).
+		Return(nil, models.ErrNoRecord).
+		Times(1)
+	likesRepo.EXPECT().
+		Add(ctx, gomock.Any()).
+		Return(nil).
+		Times(1)
+
+	servedCardsRepo := mock.NewMockServedCardsRepository(ctrl)
+	servedCardsRepo.EXPECT().
+		GetServedAt(ctx, fromId, toId).
+		Return(time.Now(), nil).
+		Times(1)
+	servedCardsRepo.EXPECT().
+		Delete(ctx, fromId, toId).
+		Return(errors.New("some err")).
+		Times(1)
+
+	usecase := NewUsecase(
+		nil,
+		likesRepo,
+		nil,
+		nil,
+		nil,
+		servedCardsRepo,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	err := usecase.AddOrUpdateLike(ctx, false, fromId, toId)
+	assert.Nil(t, err)
+}
+
 func TestUsecase_AddOrUpdateLike_ShouldReturnSameErrOnAddLikeFailure(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	likesRepo := mock.NewMockLikesRepository(ctrl)
-	fromId := "user1"
-	toId := "user2"
+	fromId := int64(1)
+	toId := int64(2)
 	likeToInsert := &models.Like{
 		FromId: fromId,
 		ToId:   toId,
@@ -173,11 +276,23 @@ func TestUsecase_AddOrUpdateLike_ShouldReturnSameErrOnAddLikeFailure(t *testing.
 		Return(expectedErr).
 		Times(1)
 
+	servedCardsRepo := mock.NewMockServedCardsRepository(ctrl)
+	servedCardsRepo.EXPECT().
+		GetServedAt(ctx, fromId, toId).
+		Return(time.Now(), nil).
+		Times(1)
+
 	usecase := NewUsecase(
 		nil,
 		likesRepo,
 		nil,
+		nil,
+		nil,
+		servedCardsRepo,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	err := usecase.AddOrUpdateLike(ctx, true, fromId, toId)
@@ -185,13 +300,130 @@ func TestUsecase_AddOrUpdateLike_ShouldReturnSameErrOnAddLikeFailure(t *testing.
 	assert.True(t, errors.Is(err, expectedErr))
 }
 
+func TestUsecase_AddOrUpdateLike_ShouldRejectCardsWhichWereNotServed(t *testing.T) {
+	cases := []struct {
+		name     string
+		servedAt time.Time
+		err      error
+	}{
+		{"not served", time.Time{}, models.ErrNoRecord},
+		{"expired", time.Now().Add(-2 * testConfig.ServedCardTTL), nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fromId := int64(1)
+			toId := int64(2)
+			ctx := context.Background()
+
+			servedCardsRepo := mock.NewMockServedCardsRepository(ctrl)
+			servedCardsRepo.EXPECT().
+				GetServedAt(ctx, fromId, toId).
+				Return(c.servedAt, c.err).
+				Times(1)
+
+			// The likes repository isn't expected to be called
+			likesRepo := mock.NewMockLikesRepository(ctrl)
+
+			usecase := NewUsecase(
+				nil,
+				likesRepo,
+				nil,
+				nil,
+				nil,
+				servedCardsRepo,
+				testCallbacks,
+				nil,
+				zaptest.NewLogger(t).Sugar(),
+				testConfig,
+			)
+
+			err := usecase.AddOrUpdateLike(ctx, true, fromId, toId)
+			assert.True(t, errors.Is(err, ErrCardNotServed))
+		})
+	}
+}
+
+func TestUsecase_AddOrUpdateLike_ShouldReturnSameErrOnGetServedCardFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fromId := int64(1)
+	toId := int64(2)
+	ctx := context.Background()
+
+	expectedErr := errors.New("some err")
+
+	servedCardsRepo := mock.NewMockServedCardsRepository(ctrl)
+	servedCardsRepo.EXPECT().
+		GetServedAt(ctx, fromId, toId).
+		Return(time.Time{}, expectedErr).
+		Times(1)
+
+	usecase := NewUsecase(
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		servedCardsRepo,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	err := usecase.AddOrUpdateLike(ctx, true, fromId, toId)
+	assert.True(t, errors.Is(err, expectedErr))
+}
+
+func TestUsecase_AddOrUpdateLike_ShouldRejectLikesOverDailyQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fromId := int64(1)
+	toId := int64(2)
+	ctx := context.Background()
+
+	servedCardsRepo := mock.NewMockServedCardsRepository(ctrl)
+	servedCardsRepo.EXPECT().
+		GetServedAt(ctx, fromId, toId).
+		Return(time.Now(), nil).
+		Times(1)
+
+	likesRepo := mock.NewMockLikesRepository(ctrl)
+	likesRepo.EXPECT().
+		CountLikesSince(ctx, fromId, gomock.Any()).
+		Return(10, nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		nil,
+		likesRepo,
+		nil,
+		nil,
+		nil,
+		servedCardsRepo,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		&Config{ServedCardTTL: time.Hour, DailyLikes: 10},
+	)
+
+	err := usecase.AddOrUpdateLike(ctx, true, fromId, toId)
+	assert.True(t, errors.Is(err, ErrLikeQuotaExceeded))
+}
+
 func TestUsecase_HasLikeWithTrueValue(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	likesRepo := mock.NewMockLikesRepository(ctrl)
-	fromId := "user1"
-	toId := "user2"
+	fromId := int64(1)
+	toId := int64(2)
 	ctx := context.Background()
 
 	expectedLike := &models.Like{Value: true}
@@ -205,7 +437,13 @@ func TestUsecase_HasLikeWithTrueValue(t *testing.T) {
 		nil,
 		likesRepo,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	hasLike, err := usecase.HasLikeWithTrueValue(ctx, fromId, toId)
@@ -218,8 +456,8 @@ func TestUsecase_HasLikeWithTrueValue_ShouldReturnOnErrNoRecord(t *testing.T) {
 	defer ctrl.Finish()
 
 	likesRepo := mock.NewMockLikesRepository(ctrl)
-	fromId := "user1"
-	toId := "user2"
+	fromId := int64(1)
+	toId := int64(2)
 	ctx := context.Background()
 
 	likesRepo.EXPECT().
@@ -231,7 +469,13 @@ func TestUsecase_HasLikeWithTrueValue_ShouldReturnOnErrNoRecord(t *testing.T) {
 		nil,
 		likesRepo,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	hasLike, err := usecase.HasLikeWithTrueValue(ctx, fromId, toId)
@@ -244,8 +488,8 @@ func TestUsecase_HasLikeWithTrueValue_ShouldReturnSameErrOnFailure(t *testing.T)
 	defer ctrl.Finish()
 
 	likesRepo := mock.NewMockLikesRepository(ctrl)
-	fromId := "user1"
-	toId := "user2"
+	fromId := int64(1)
+	toId := int64(2)
 	ctx := context.Background()
 
 	expectedErr := errors.New("some err")
@@ -259,7 +503,13 @@ func TestUsecase_HasLikeWithTrueValue_ShouldReturnSameErrOnFailure(t *testing.T)
 		nil,
 		likesRepo,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	hasLike, err := usecase.HasLikeWithTrueValue(ctx, fromId, toId)
@@ -273,35 +523,56 @@ func TestUsecase_CreateMatchMessages_ShouldReturnErrOnNilUsers(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
-	msg1, msg2, err := usecase.CreateMatchMessages(nil, nil)
+	msg1, msg2, err := usecase.CreateMatchMessages(context.Background(), nil, nil)
 	assert.Nil(t, msg1)
 	assert.Nil(t, msg2)
 	assert.NotNil(t, err)
 
-	msg1, msg2, err = usecase.CreateMatchMessages(&models.User{}, nil)
+	msg1, msg2, err = usecase.CreateMatchMessages(context.Background(), &models.User{}, nil)
 	assert.Nil(t, msg1)
 	assert.Nil(t, msg2)
 	assert.NotNil(t, err)
 
-	msg1, msg2, err = usecase.CreateMatchMessages(nil, &models.User{})
+	msg1, msg2, err = usecase.CreateMatchMessages(context.Background(), nil, &models.User{})
 	assert.Nil(t, msg1)
 	assert.Nil(t, msg2)
 	assert.NotNil(t, err)
 }
 
 func TestUsecase_CreateMatchMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	photosRepo := mock.NewMockPhotosRepository(ctrl)
+	photosRepo.EXPECT().
+		GetByUserId(gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		Times(2)
+
 	usecase := NewUsecase(
 		nil,
 		nil,
+		photosRepo,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
 		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	user1 := &models.User{
-		Id:          "1",
+		Id:          1,
 		Name:        "name1",
 		Age:         1,
 		Description: "desc1",
@@ -311,7 +582,7 @@ func TestUsecase_CreateMatchMessages(t *testing.T) {
 	}
 
 	user2 := &models.User{
-		Id:          "2",
+		Id:          2,
 		Name:        "name2",
 		Age:         2,
 		Description: "desc2",
@@ -320,16 +591,19 @@ func TestUsecase_CreateMatchMessages(t *testing.T) {
 		ChatId:      2,
 	}
 
-	msg1, msg2, err := usecase.CreateMatchMessages(user1, user2)
+	msg1, msg2, err := usecase.CreateMatchMessages(context.Background(), user1, user2)
 	assert.Nil(t, err)
-	assert.NotNil(t, msg1)
-	assert.NotNil(t, msg2)
-	photo1, ok := msg1.(tgbotapi.PhotoConfig)
+	assert.Len(t, msg1, 1)
+	assert.Len(t, msg2, 1)
+	if len(msg1) != 1 || len(msg2) != 1 {
+		return
+	}
+	photo1, ok := msg1[0].(tgbotapi.PhotoConfig)
 	assert.True(t, ok)
-	assert.NotNil(t, photo1)
-	photo2, ok := msg2.(tgbotapi.PhotoConfig)
+	assert.NotNil(t, photo1.ReplyMarkup)
+	photo2, ok := msg2[0].(tgbotapi.PhotoConfig)
 	assert.True(t, ok)
-	assert.NotNil(t, photo2)
+	assert.NotNil(t, photo2.ReplyMarkup)
 
 	assert.EqualValues(t, tgbotapi.ModeMarkdown, photo1.ParseMode)
 	assert.EqualValues(t, tgbotapi.ModeMarkdown, photo2.ParseMode)
@@ -337,3 +611,243 @@ func TestUsecase_CreateMatchMessages(t *testing.T) {
 	assert.EqualValues(t, user1.ChatId, photo1.ChatID)
 	assert.EqualValues(t, user2.ChatId, photo2.ChatID)
 }
+
+func TestUsecase_CreateMatchMessages_ShouldSendAlbumIfThereAreSeveralPhotos(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user1 := &models.User{Id: 1, Image: "image1", ChatId: 1}
+	user2 := &models.User{Id: 2, Image: "image2", ChatId: 2}
+
+	photosRepo := mock.NewMockPhotosRepository(ctrl)
+	photosRepo.EXPECT().
+		GetByUserId(gomock.Any(), user1.Id).
+		Return([]*models.Photo{{FileId: "image1"}, {FileId: "image1.1"}}, nil).
+		Times(1)
+	photosRepo.EXPECT().
+		GetByUserId(gomock.Any(), user2.Id).
+		Return(nil, nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		nil,
+		nil,
+		photosRepo,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	msg1, msg2, err := usecase.CreateMatchMessages(context.Background(), user1, user2)
+	assert.Nil(t, err)
+	assert.Len(t, msg1, 1)
+	assert.Len(t, msg2, 2)
+	if len(msg1) != 1 || len(msg2) != 2 {
+		return
+	}
+
+	// user1 gets the only photo of user2
+	photo, ok := msg1[0].(tgbotapi.PhotoConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, user1.ChatId, photo.ChatID)
+
+	// user2 gets the album of user1 and the keyboard in a separate message
+	album, ok := msg2[0].(tgbotapi.MediaGroupConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, user2.ChatId, album.ChatID)
+	assert.Len(t, album.Media, 2)
+
+	keyboardMsg, ok := msg2[1].(tgbotapi.MessageConfig)
+	assert.True(t, ok)
+	assert.NotNil(t, keyboardMsg.ReplyMarkup)
+}
+
+func TestUsecase_HandleLikes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	likesRepo := mock.NewMockLikesRepository(ctrl)
+
+	var chatId int64 = 1
+	user := &models.User{Id: 1}
+	liker := &models.User{Id: 2, Name: "Masha", Image: "image"}
+
+	likesRepo.EXPECT().
+		CountUnansweredLikes(gomock.Any(), user.Id, testConfig.MinAge).
+		Return(2, nil).
+		Times(1)
+
+	usersRepo.EXPECT().
+		GetNextLiker(gomock.Any(), user.Id, testConfig.MinAge).
+		Return(liker, nil).
+		Times(1)
+
+	servedCardsRepo := mock.NewMockServedCardsRepository(ctrl)
+	servedCardsRepo.EXPECT().
+		Add(gomock.Any(), user.Id, liker.Id).
+		Return(nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		likesRepo,
+		nil,
+		nil,
+		nil,
+		servedCardsRepo,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	chattable, err := usecase.HandleLikes(context.Background(), chatId, user)
+	assert.Nil(t, err)
+
+	photoCfg, ok := chattable.(tgbotapi.PhotoConfig)
+	assert.True(t, ok)
+	if !ok {
+		return
+	}
+
+	assert.EqualValues(t, chatId, photoCfg.ChatID)
+	assert.Contains(t, photoCfg.Caption, "Вы понравились пользователям:* 2")
+
+	keyboard, ok := photoCfg.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup)
+	assert.True(t, ok)
+	if !ok {
+		return
+	}
+
+	assert.EqualValues(t, callbackData(t, internal.CallbackLike, "2;inbox"), *keyboard.InlineKeyboard[0][0].CallbackData)
+	assert.EqualValues(t, callbackData(t, internal.CallbackDislike, "2;inbox"), *keyboard.InlineKeyboard[0][1].CallbackData)
+}
+
+func TestUsecase_HandleLikes_ShouldReturnMessageIfThereAreNoLikes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	likesRepo := mock.NewMockLikesRepository(ctrl)
+
+	likesRepo.EXPECT().
+		CountUnansweredLikes(gomock.Any(), int64(1), testConfig.MinAge).
+		Return(0, nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		nil,
+		likesRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	chattable, err := usecase.HandleLikes(context.Background(), 1, &models.User{Id: 1})
+	assert.Nil(t, err)
+
+	msgCfg, ok := chattable.(tgbotapi.MessageConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, noLikesText, msgCfg.Text)
+}
+
+func TestUsecase_HandleLikes_ShouldReturnSameErrOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	likesRepo := mock.NewMockLikesRepository(ctrl)
+
+	expectedErr := errors.New("some err")
+
+	likesRepo.EXPECT().
+		CountUnansweredLikes(gomock.Any(), int64(1), testConfig.MinAge).
+		Return(1, nil).
+		Times(1)
+
+	usersRepo.EXPECT().
+		GetNextLiker(gomock.Any(), int64(1), testConfig.MinAge).
+		Return(nil, expectedErr).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		likesRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	_, err := usecase.HandleLikes(context.Background(), 1, &models.User{Id: 1})
+	assert.True(t, errors.Is(err, expectedErr))
+}
+
+func TestUsecase_CreateLikeNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	likesRepo := mock.NewMockLikesRepository(ctrl)
+	toUser := &models.User{Id: 2, ChatId: 20}
+
+	likesRepo.EXPECT().
+		CountUnansweredLikes(gomock.Any(), toUser.Id, 18).
+		Return(3, nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		nil,
+		likesRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		&Config{LikeNotifications: true, MinAge: 18},
+	)
+
+	chattable, err := usecase.CreateLikeNotification(context.Background(), toUser)
+	assert.Nil(t, err)
+
+	msgCfg, ok := chattable.(tgbotapi.MessageConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, toUser.ChatId, msgCfg.ChatID)
+	assert.Contains(t, msgCfg.Text, "/likes")
+}
+
+func TestUsecase_CreateLikeNotification_ShouldReturnNilIfDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := NewUsecase(
+		nil,
+		mock.NewMockLikesRepository(ctrl),
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		&Config{LikeNotifications: false},
+	)
+
+	chattable, err := usecase.CreateLikeNotification(context.Background(), &models.User{Id: 2})
+	assert.Nil(t, err)
+	assert.Nil(t, chattable)
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const noMatchesText = "У Вас пока нет совпадений. Попробуйте команду /next"
+
+// HandleMatches shows one mutual like per page, newest first.
+func (u *Usecase) HandleMatches(ctx context.Context, chatId int64, user *models.User, page int) (tgbotapi.Chattable, error) {
+	u.logger(ctx).Info("handleMatches")
+
+	total, err := u.likes.CountMatches(ctx, user.Id)
+	if err != nil {
+		u.logger(ctx).Errorw("could not count matches", "err", err)
+		return tgbotapi.MessageConfig{}, fmt.Errorf("count matches: %w", err)
+	}
+
+	if total == 0 {
+		return tgbotapi.NewMessage(chatId, noMatchesText), nil
+	}
+
+	// Matches could have changed since the keyboard was sent
+	if page >= total {
+		page = total - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+
+	matches, err := u.likes.GetMatches(ctx, user.Id, 1, page)
+	if err != nil {
+		u.logger(ctx).Errorw("could not get matches", "err", err)
+		return tgbotapi.MessageConfig{}, fmt.Errorf("get matches: %w", err)
+	}
+
+	if len(matches) == 0 {
+		return tgbotapi.NewMessage(chatId, noMatchesText), nil
+	}
+
+	matchedUser, err := u.users.GetByUserId(ctx, matches[0].ToId)
+	if err != nil {
+		u.logger(ctx).Errorw("could not get matched user", "err", err)
+		return tgbotapi.MessageConfig{}, fmt.Errorf("get matched user: %w", err)
+	}
+
+	caption := internal.CreateMatchesCaption(matchedUser, page, total)
+	keyboard := u.keyboards.CreateMatchesKeyboardMarkup(page, total)
+
+	if len(matchedUser.Image) > 0 {
+		photoCfg := tgbotapi.NewPhoto(chatId, tgbotapi.FileID(matchedUser.Image))
+		photoCfg.Caption = caption
+		photoCfg.ParseMode = tgbotapi.ModeMarkdown
+		if keyboard != nil {
+			photoCfg.ReplyMarkup = keyboard
+		}
+		return photoCfg, nil
+	}
+
+	msgConfig := tgbotapi.NewMessage(chatId, caption)
+	msgConfig.ParseMode = tgbotapi.ModeMarkdown
+	if keyboard != nil {
+		msgConfig.ReplyMarkup = keyboard
+	}
+	return msgConfig, nil
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/Eretic431/datingTelegramBot/internal/mock"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	"testing"
+)
+
+func TestUsecase_HandleMatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	likesRepo := mock.NewMockLikesRepository(ctrl)
+
+	var chatId int64 = 1
+	user := &models.User{Id: 1}
+	matchedUser := &models.User{Id: 2, Name: "Masha", Username: "masha", Image: "image"}
+
+	likesRepo.EXPECT().
+		CountMatches(gomock.Any(), user.Id).
+		Return(3, nil).
+		Times(1)
+
+	likesRepo.EXPECT().
+		GetMatches(gomock.Any(), user.Id, 1, 1).
+		Return([]*models.Like{{FromId: user.Id, ToId: matchedUser.Id, Value: true}}, nil).
+		Times(1)
+
+	usersRepo.EXPECT().
+		GetByUserId(gomock.Any(), matchedUser.Id).
+		Return(matchedUser, nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		likesRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	chattable, err := usecase.HandleMatches(context.Background(), chatId, user, 1)
+	assert.Nil(t, err)
+
+	photoCfg, ok := chattable.(tgbotapi.PhotoConfig)
+	assert.True(t, ok)
+	if !ok {
+		return
+	}
+
+	assert.EqualValues(t, chatId, photoCfg.ChatID)
+	assert.EqualValues(t, tgbotapi.ModeMarkdown, photoCfg.ParseMode)
+	assert.Contains(t, photoCfg.Caption, "Совпадение 2 из 3")
+	assert.Contains(t, photoCfg.Caption, "@masha")
+
+	keyboard, ok := photoCfg.ReplyMarkup.(*tgbotapi.InlineKeyboardMarkup)
+	assert.True(t, ok)
+	if !ok {
+		return
+	}
+
+	assert.Len(t, keyboard.InlineKeyboard, 1)
+	assert.EqualValues(t, callbackData(t, internal.CallbackMatches, "0"), *keyboard.InlineKeyboard[0][0].CallbackData)
+	assert.EqualValues(t, callbackData(t, internal.CallbackMatches, "2"), *keyboard.InlineKeyboard[0][1].CallbackData)
+}
+
+func TestUsecase_HandleMatches_ShouldClampPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	likesRepo := mock.NewMockLikesRepository(ctrl)
+
+	user := &models.User{Id: 1}
+	matchedUser := &models.User{Id: 2, Name: "Masha"}
+
+	likesRepo.EXPECT().
+		CountMatches(gomock.Any(), user.Id).
+		Return(1, nil).
+		Times(1)
+
+	likesRepo.EXPECT().
+		GetMatches(gomock.Any(), user.Id, 1, 0).
+		Return([]*models.Like{{FromId: user.Id, ToId: matchedUser.Id, Value: true}}, nil).
+		Times(1)
+
+	usersRepo.EXPECT().
+		GetByUserId(gomock.Any(), matchedUser.Id).
+		Return(matchedUser, nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		likesRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	chattable, err := usecase.HandleMatches(context.Background(), 1, user, 5)
+	assert.Nil(t, err)
+
+	msgCfg, ok := chattable.(tgbotapi.MessageConfig)
+	assert.True(t, ok)
+	assert.Contains(t, msgCfg.Text, "Совпадение 1 из 1")
+	assert.Nil(t, msgCfg.ReplyMarkup)
+}
+
+func TestUsecase_HandleMatches_ShouldReturnMessageIfThereAreNoMatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	likesRepo := mock.NewMockLikesRepository(ctrl)
+
+	likesRepo.EXPECT().
+		CountMatches(gomock.Any(), int64(1)).
+		Return(0, nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		nil,
+		likesRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	chattable, err := usecase.HandleMatches(context.Background(), 1, &models.User{Id: 1}, 0)
+	assert.Nil(t, err)
+
+	msgCfg, ok := chattable.(tgbotapi.MessageConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, noMatchesText, msgCfg.Text)
+}
+
+func TestUsecase_HandleMatches_ShouldReturnSameErrorOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	likesRepo := mock.NewMockLikesRepository(ctrl)
+
+	expectedError := errors.New("some error")
+	likesRepo.EXPECT().
+		CountMatches(gomock.Any(), int64(1)).
+		Return(0, expectedError).
+		Times(1)
+
+	usecase := NewUsecase(
+		nil,
+		likesRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	_, err := usecase.HandleMatches(context.Background(), 1, &models.User{Id: 1}, 0)
+	assert.True(t, errors.Is(err, expectedError))
+}
@@ -3,38 +3,97 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/Eretic431/datingTelegramBot/internal"
 	"github.com/Eretic431/datingTelegramBot/internal/data/models"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
-func (u *Usecase) HandleCommandNext(ctx context.Context, chatId int64, user *models.User) (tgbotapi.Chattable, error) {
-	u.log.Info("handleCommandNext")
+// SwipeRestriction returns the reply to a user who can't see and rate other profiles, or nil if the user can.
+// Banned and under-age users can't. The router checks it before every command and button of the swipe flows:
+// /next, /likes and /undo.
+func (u *Usecase) SwipeRestriction(chatId int64, user *models.User) tgbotapi.Chattable {
+	if user.Banned {
+		return tgbotapi.NewMessage(chatId, "Ваша анкета заблокирована модератором, просмотр анкет недоступен.")
+	}
+
+	// Profiles filled before the age limit was raised keep their age
+	if user.Age > 0 && user.Age < u.config.MinAge {
+		return tgbotapi.NewMessage(chatId, u.underAgeText())
+	}
+
+	return nil
+}
+
+func (u *Usecase) HandleCommandNext(ctx context.Context, chatId int64, user *models.User) ([]tgbotapi.Chattable, error) {
+	u.logger(ctx).Info("handleCommandNext")
 
-	nextUser, err := u.users.GetNextUser(ctx, user.Id, user.Sex)
+	// Under-age profiles are never shown, whatever the user's own preferences are
+	filter := *user
+	if filter.MinAge < u.config.MinAge {
+		filter.MinAge = u.config.MinAge
+	}
+
+	nextUser, err := u.users.GetNextUser(ctx, &filter)
 	if err != nil {
 		if errors.Is(err, models.ErrNoRecord) {
-			return tgbotapi.NewMessage(chatId, "Все анкеты просмотрены. Попробуйте ещё раз немного позже."), nil
+			return []tgbotapi.Chattable{
+				tgbotapi.NewMessage(chatId, "Все анкеты просмотрены. Попробуйте ещё раз немного позже."),
+			}, nil
 		}
-		u.log.Errorf("could not get next user with error %e", err)
-		return tgbotapi.MessageConfig{}, err
+		u.logger(ctx).Errorw("could not get next user", "err", err)
+		return []tgbotapi.Chattable{tgbotapi.MessageConfig{}}, fmt.Errorf("get next user: %w", err)
 	}
 
 	if nextUser != nil {
-		if len(nextUser.Image) > 0 {
-			photoCfg := tgbotapi.NewPhoto(chatId, tgbotapi.FileID(nextUser.Image))
-			photoCfg.Caption = internal.CreateProfileCaption(nextUser)
-			photoCfg.ParseMode = tgbotapi.ModeMarkdown
-
-			photoCfg.ReplyMarkup = internal.CreateLikeKeyboardMarkup(nextUser.Id)
-			return photoCfg, nil
-		} else {
-			msgConfig := tgbotapi.NewMessage(chatId, internal.CreateProfileCaption(nextUser))
-			msgConfig.ParseMode = tgbotapi.ModeMarkdown
-			msgConfig.ReplyMarkup = internal.CreateLikeKeyboardMarkup(nextUser.Id)
-			return msgConfig, nil
+		if err := u.serveCard(ctx, user.Id, nextUser.Id); err != nil {
+			return nil, err
 		}
+		return u.createProfileCards(ctx, chatId, nextUser)
+	}
+
+	return []tgbotapi.Chattable{tgbotapi.MessageConfig{}}, nil
+}
+
+// serveCard allows the user to like or dislike toId, it is called for every profile shown with the like keyboard.
+func (u *Usecase) serveCard(ctx context.Context, userId, toId int64) error {
+	if err := u.servedCards.Add(ctx, userId, toId); err != nil {
+		u.logger(ctx).Errorw("could not add served card", "err", err)
+		return fmt.Errorf("add served card: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredCards removes the cards which can't be swiped anymore. It's called periodically,
+// AddOrUpdateLike checks the time of the card by itself.
+func (u *Usecase) DeleteExpiredCards(ctx context.Context) error {
+	deleted, err := u.servedCards.DeleteExpired(ctx, u.config.ServedCardTTL)
+	if err != nil {
+		u.logger(ctx).Errorw("could not delete expired served cards", "err", err)
+		return fmt.Errorf("delete expired served cards: %w", err)
+	}
+
+	if deleted > 0 {
+		u.logger(ctx).Infow("deleted expired served cards", "count", deleted)
+	}
+
+	return nil
+}
+
+// createProfileCard returns the profile of user with the like keyboard, as a photo if the user has one.
+func (u *Usecase) createProfileCard(chatId int64, user *models.User) tgbotapi.Chattable {
+	if len(user.Image) > 0 {
+		photoCfg := tgbotapi.NewPhoto(chatId, tgbotapi.FileID(user.Image))
+		photoCfg.Caption = internal.CreateProfileCaption(user)
+		photoCfg.ParseMode = tgbotapi.ModeMarkdown
+
+		photoCfg.ReplyMarkup = u.keyboards.CreateLikeKeyboardMarkup(user.Id)
+		return photoCfg
 	}
 
-	return tgbotapi.MessageConfig{}, nil
+	msgConfig := tgbotapi.NewMessage(chatId, internal.CreateProfileCaption(user))
+	msgConfig.ParseMode = tgbotapi.ModeMarkdown
+	msgConfig.ReplyMarkup = u.keyboards.CreateLikeKeyboardMarkup(user.Id)
+	return msgConfig
 }
@@ -17,36 +17,53 @@ func TestUsecase_HandleCommandNext(t *testing.T) {
 	defer ctrl.Finish()
 
 	usersRepo := mock.NewMockUsersRepository(ctrl)
+	photosRepo := mock.NewMockPhotosRepository(ctrl)
 
 	var expectedChatId int64 = 1
 	inputUser := &models.User{
-		Id:  "123",
-		Sex: false,
+		Id:     123,
+		MinAge: 20,
 	}
 
 	expectedUser := &models.User{
-		Id:    "123",
-		Sex:   false,
+		Id:    123,
 		Image: "123",
 	}
 
 	usersRepo.EXPECT().
-		GetNextUser(gomock.Any(), inputUser.Id, inputUser.Sex).
+		GetNextUser(gomock.Any(), inputUser).
 		Return(expectedUser, nil).
 		Times(1)
 
+	photosRepo.EXPECT().
+		GetByUserId(gomock.Any(), expectedUser.Id).
+		Return(nil, nil).
+		Times(1)
+
+	servedCardsRepo := mock.NewMockServedCardsRepository(ctrl)
+	servedCardsRepo.EXPECT().
+		Add(gomock.Any(), inputUser.Id, expectedUser.Id).
+		Return(nil).
+		Times(1)
+
 	usecase := NewUsecase(
 		usersRepo,
 		nil,
+		photosRepo,
+		nil,
+		nil,
+		servedCardsRepo,
+		testCallbacks,
 		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
-	messageConfig, err := usecase.HandleCommandNext(context.Background(), expectedChatId, inputUser)
+	messages, err := usecase.HandleCommandNext(context.Background(), expectedChatId, inputUser)
 	assert.Nil(t, err)
-	assert.NotNil(t, messageConfig)
+	assert.Len(t, messages, 1)
 
-	photoCfg, ok := messageConfig.(tgbotapi.PhotoConfig)
+	photoCfg, ok := messages[0].(tgbotapi.PhotoConfig)
 	assert.NotNil(t, photoCfg)
 	assert.True(t, ok)
 	if !ok {
@@ -57,6 +74,72 @@ func TestUsecase_HandleCommandNext(t *testing.T) {
 	assert.EqualValues(t, tgbotapi.ModeMarkdown, photoCfg.ParseMode)
 }
 
+func TestUsecase_HandleCommandNext_ShouldSendOtherPhotosAfterTheCard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	photosRepo := mock.NewMockPhotosRepository(ctrl)
+
+	var expectedChatId int64 = 1
+	inputUser := &models.User{
+		Id:     123,
+		MinAge: 20,
+	}
+
+	expectedUser := &models.User{
+		Id:    124,
+		Image: "first",
+	}
+
+	usersRepo.EXPECT().
+		GetNextUser(gomock.Any(), inputUser).
+		Return(expectedUser, nil).
+		Times(1)
+
+	photosRepo.EXPECT().
+		GetByUserId(gomock.Any(), expectedUser.Id).
+		Return([]*models.Photo{{FileId: "first"}, {FileId: "second"}, {FileId: "third"}}, nil).
+		Times(1)
+
+	servedCardsRepo := mock.NewMockServedCardsRepository(ctrl)
+	servedCardsRepo.EXPECT().
+		Add(gomock.Any(), inputUser.Id, expectedUser.Id).
+		Return(nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		photosRepo,
+		nil,
+		nil,
+		servedCardsRepo,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	messages, err := usecase.HandleCommandNext(context.Background(), expectedChatId, inputUser)
+	assert.Nil(t, err)
+	assert.Len(t, messages, 2)
+	if len(messages) != 2 {
+		return
+	}
+
+	card, ok := messages[0].(tgbotapi.PhotoConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, tgbotapi.FileID("first"), card.File)
+	assert.NotEmpty(t, card.Caption)
+	assert.NotNil(t, card.ReplyMarkup)
+
+	album, ok := messages[1].(tgbotapi.MediaGroupConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, expectedChatId, album.ChatID)
+	assert.Len(t, album.Media, 2)
+}
+
 func TestUsecase_HandleCommandNextOnErrorNoRecord(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -65,12 +148,12 @@ func TestUsecase_HandleCommandNextOnErrorNoRecord(t *testing.T) {
 
 	var expectedChatId int64 = 1
 	inputUser := &models.User{
-		Id:  "123",
-		Sex: false,
+		Id:     123,
+		MinAge: 20,
 	}
 
 	usersRepo.EXPECT().
-		GetNextUser(gomock.Any(), inputUser.Id, inputUser.Sex).
+		GetNextUser(gomock.Any(), inputUser).
 		Return(nil, models.ErrNoRecord).
 		Times(1)
 
@@ -78,14 +161,20 @@ func TestUsecase_HandleCommandNextOnErrorNoRecord(t *testing.T) {
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
-	chattable, err := usecase.HandleCommandNext(context.Background(), expectedChatId, inputUser)
+	messages, err := usecase.HandleCommandNext(context.Background(), expectedChatId, inputUser)
 	assert.Nil(t, err)
-	assert.NotNil(t, chattable)
+	assert.Len(t, messages, 1)
 
-	messageCfg, ok := chattable.(tgbotapi.MessageConfig)
+	messageCfg, ok := messages[0].(tgbotapi.MessageConfig)
 	assert.NotNil(t, messageCfg)
 	assert.True(t, ok)
 	if !ok {
@@ -104,12 +193,12 @@ func TestUsecase_HandleCommandNextOnFailure(t *testing.T) {
 	var expectedChatId int64 = 1
 	expectedError := errors.New("some error")
 	inputUser := &models.User{
-		Id:  "123",
-		Sex: false,
+		Id:     123,
+		MinAge: 20,
 	}
 
 	usersRepo.EXPECT().
-		GetNextUser(gomock.Any(), inputUser.Id, inputUser.Sex).
+		GetNextUser(gomock.Any(), inputUser).
 		Return(nil, expectedError).
 		Times(1)
 
@@ -117,14 +206,20 @@ func TestUsecase_HandleCommandNextOnFailure(t *testing.T) {
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
-	chattable, err := usecase.HandleCommandNext(context.Background(), expectedChatId, inputUser)
+	messages, err := usecase.HandleCommandNext(context.Background(), expectedChatId, inputUser)
 	assert.True(t, errors.Is(err, expectedError))
-	assert.NotNil(t, chattable)
+	assert.Len(t, messages, 1)
 
-	messageCfg, ok := chattable.(tgbotapi.MessageConfig)
+	messageCfg, ok := messages[0].(tgbotapi.MessageConfig)
 	assert.NotNil(t, messageCfg)
 	assert.True(t, ok)
 }
@@ -137,12 +232,12 @@ func TestUsecase_HandleCommandNextOnUserNilWithoutFailure(t *testing.T) {
 
 	var expectedChatId int64 = 1
 	inputUser := &models.User{
-		Id:  "123",
-		Sex: false,
+		Id:     123,
+		MinAge: 20,
 	}
 
 	usersRepo.EXPECT().
-		GetNextUser(gomock.Any(), inputUser.Id, inputUser.Sex).
+		GetNextUser(gomock.Any(), inputUser).
 		Return(nil, nil).
 		Times(1)
 
@@ -150,14 +245,55 @@ func TestUsecase_HandleCommandNextOnUserNilWithoutFailure(t *testing.T) {
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
-	chattable, err := usecase.HandleCommandNext(context.Background(), expectedChatId, inputUser)
+	messages, err := usecase.HandleCommandNext(context.Background(), expectedChatId, inputUser)
 	assert.Nil(t, err)
-	assert.NotNil(t, chattable)
+	assert.Len(t, messages, 1)
 
-	messageCfg, ok := chattable.(tgbotapi.MessageConfig)
+	messageCfg, ok := messages[0].(tgbotapi.MessageConfig)
 	assert.NotNil(t, messageCfg)
 	assert.True(t, ok)
 }
+
+func TestUsecase_HandleCommandNext_ShouldNotShowUnderAgeUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+
+	var expectedChatId int64 = 1
+	inputUser := &models.User{
+		Id:     123,
+		MinAge: 0,
+	}
+
+	usersRepo.EXPECT().
+		GetNextUser(gomock.Any(), &models.User{Id: 123, MinAge: testConfig.MinAge}).
+		Return(nil, models.ErrNoRecord).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	_, err := usecase.HandleCommandNext(context.Background(), expectedChatId, inputUser)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 0, inputUser.MinAge)
+}
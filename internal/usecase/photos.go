@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// photoFileIds returns file ids of the user photos, cover first.
+// Profiles which were filled before user_photos appeared only have user.Image.
+func (u *Usecase) photoFileIds(ctx context.Context, user *models.User) ([]string, error) {
+	photos, err := u.photos.GetByUserId(ctx, user.Id)
+	if err != nil {
+		u.logger(ctx).Errorw("could not get photos", "err", err)
+		return nil, fmt.Errorf("get photos: %w", err)
+	}
+
+	fileIds := make([]string, 0, len(photos))
+	for _, photo := range photos {
+		fileIds = append(fileIds, photo.FileId)
+	}
+
+	if len(fileIds) == 0 && len(user.Image) > 0 {
+		fileIds = append(fileIds, user.Image)
+	}
+
+	return fileIds, nil
+}
+
+// savePhotos stores the photos and sets user.Image to the cover, user itself is saved by the caller.
+func (u *Usecase) savePhotos(ctx context.Context, user *models.User, fileIds []string) error {
+	if err := u.photos.ReplaceByUserId(ctx, user.Id, fileIds); err != nil {
+		u.logger(ctx).Errorw("could not replace photos", "err", err)
+		return fmt.Errorf("replace photos: %w", err)
+	}
+
+	user.Image = ""
+	if len(fileIds) > 0 {
+		user.Image = fileIds[0]
+	}
+
+	return nil
+}
+
+func applyPhotoAction(fileIds []string, action string, index int) ([]string, bool) {
+	if index >= len(fileIds) {
+		return fileIds, false
+	}
+
+	result := make([]string, 0, len(fileIds))
+	switch action {
+	case internal.PhotoActionFirst:
+		result = append(result, fileIds[index])
+		result = append(result, fileIds[:index]...)
+		result = append(result, fileIds[index+1:]...)
+	case internal.PhotoActionDelete:
+		result = append(result, fileIds[:index]...)
+		result = append(result, fileIds[index+1:]...)
+	default:
+		return fileIds, false
+	}
+
+	return result, true
+}
+
+// createProfileCards returns the profile of user with the like keyboard. The caption and the keyboard go with the cover,
+// so a swipe can edit the card in place. The other photos follow it, an album can't have a keyboard.
+func (u *Usecase) createProfileCards(ctx context.Context, chatId int64, user *models.User) ([]tgbotapi.Chattable, error) {
+	fileIds, err := u.photoFileIds(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := []tgbotapi.Chattable{u.createProfileCard(chatId, user)}
+	switch {
+	case len(fileIds) == 2:
+		cards = append(cards, tgbotapi.NewPhoto(chatId, tgbotapi.FileID(fileIds[1])))
+	case len(fileIds) > 2:
+		cards = append(cards, createAlbum(chatId, fileIds[1:], ""))
+	}
+
+	return cards, nil
+}
+
+// createAlbum returns a media group with caption under the first photo, if there is a caption.
+func createAlbum(chatId int64, fileIds []string, caption string) tgbotapi.MediaGroupConfig {
+	media := make([]interface{}, 0, len(fileIds))
+	for i, fileId := range fileIds {
+		photo := tgbotapi.NewInputMediaPhoto(tgbotapi.FileID(fileId))
+		if i == 0 && len(caption) > 0 {
+			photo.Caption = caption
+			photo.ParseMode = tgbotapi.ModeMarkdown
+		}
+		media = append(media, photo)
+	}
+
+	return tgbotapi.NewMediaGroup(chatId, media)
+}
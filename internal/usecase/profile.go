@@ -2,31 +2,29 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"github.com/Eretic431/datingTelegramBot/internal"
 	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/Eretic431/datingTelegramBot/internal/dialog"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
-	"strconv"
 )
 
 func (u *Usecase) HandleProfile(
 	ctx context.Context,
 	inputMsg *tgbotapi.Message,
 	user *models.User) (tgbotapi.MessageConfig, error) {
-	user.Stage = 0
-	err := u.users.UpdateByUserId(ctx, user)
-	if err != nil {
-		u.log.Errorf("could not update user with error %e", err)
-		return tgbotapi.MessageConfig{}, err
-	}
-
-	outputMsg := tgbotapi.NewMessage(inputMsg.Chat.ID, u.Stages[user.Stage])
-	if len(user.Name) > 0 {
-		outputMsg.ReplyMarkup = internal.CreateSkipKeyboardMarkup(user.Name)
-	}
+	user.Editing = false
+	return u.profile.Start(ctx, inputMsg.Chat.ID, user, ProfileStageName)
+}
 
-	return outputMsg, nil
+func (u *Usecase) HandleSettings(
+	ctx context.Context,
+	inputMsg *tgbotapi.Message,
+	user *models.User) (tgbotapi.MessageConfig, error) {
+	return u.settings.Start(ctx, inputMsg.Chat.ID, user, SettingsStageMinAge)
 }
 
+// HandleFillingProfile passes the input to the dialog of user.Stage. photoId is empty if the message has no photo.
 func (u *Usecase) HandleFillingProfile(
 	ctx context.Context,
 	inputText string,
@@ -34,129 +32,124 @@ func (u *Usecase) HandleFillingProfile(
 	photoId string,
 	user *models.User,
 ) (tgbotapi.Chattable, error) {
-	var text string
-	skipData := ""
+	input := dialog.Input{Text: inputText, PhotoId: photoId}
 
-	currentData := ""
-	if len(inputText) > 0 {
-		currentData = inputText
+	for _, d := range u.dialogs() {
+		if d.Has(user.Stage) {
+			return d.Handle(ctx, chatId, input, user)
+		}
 	}
 
-	correct := true
-
-	// name, age, city, description, image, sex
-	switch user.Stage {
-	case 0:
-		name := currentData
-		if len(name) > 0 {
-			user.Name = name
-			if user.Age > 0 {
-				skipData = strconv.Itoa(user.Age)
-			}
-		} else {
-			correct = false
-			skipData = user.Name
-		}
-	case 1:
-		age, err := strconv.Atoi(currentData)
-		if err == nil && age > 0 {
-			user.Age = age
-			skipData = user.City
-		} else {
-			correct = false
-			if user.Age > 0 {
-				skipData = strconv.Itoa(user.Age)
-			}
-		}
-	case 2:
-		city := currentData
-		if len(city) > 0 {
-			user.City = city
-			skipData = user.Description
-		} else {
-			correct = false
-			skipData = user.City
-		}
-	case 3:
-		description := currentData
-		if len(description) > 0 {
-			user.Description = description
-			if len(user.Image) == 0 {
-				skipData = "emptyImage"
-			} else {
-				skipData = "NoImageData"
-			}
-		} else {
-			correct = false
-			skipData = user.Description
-		}
-	case 4:
-		if photoId == "NoImageData" {
-			if user.Sex {
-				skipData = "М"
-			} else {
-				skipData = "Ж"
-			}
-		} else {
-			if photoId != "-" {
-				user.Image = photoId
-				if user.Sex {
-					skipData = "М"
-				} else {
-					skipData = "Ж"
-				}
-			} else {
-				correct = false
-				skipData = user.Image
-			}
-		}
-	case 5:
-		sex := currentData
-		if sex == "М" || sex == "Ж" {
-			if sex == "М" {
-				user.Sex = true
-			} else {
-				user.Sex = false
-			}
-		} else {
-			correct = false
+	return tgbotapi.NewMessage(chatId, dialog.InvalidInputText), nil
+}
+
+// HandleSkip leaves the field of user.Stage as is and moves to the next step.
+func (u *Usecase) HandleSkip(ctx context.Context, chatId int64, user *models.User) (tgbotapi.Chattable, error) {
+	for _, d := range u.dialogs() {
+		if d.Has(user.Stage) {
+			return d.Skip(ctx, chatId, user)
 		}
-	case 6:
-		skipData = ""
 	}
 
-	if correct {
-		if user.Stage < MaxProfileStage {
-			user.Stage += 1
-		} else {
-			user.Stage = ProfileStageNone
+	return tgbotapi.NewMessage(chatId, dialog.InvalidInputText), nil
+}
+
+// HandleCancel leaves the report being filled, the settings or the field being edited. The reported profile
+// and the edited field stay as is, the settings answered before are kept. The first filling of the profile can't be cancelled.
+func (u *Usecase) HandleCancel(ctx context.Context, chatId int64, user *models.User) (tgbotapi.Chattable, error) {
+	u.logger(ctx).Info("handleCancel")
+
+	switch {
+	case u.report.Has(user.Stage):
+		user.ReportedId = 0
+		user.Stage = ProfileStageNone
+		if err := u.saveUser(ctx, user); err != nil {
+			return tgbotapi.MessageConfig{}, err
 		}
 
-		err := u.users.UpdateByUserId(ctx, user)
-		if err != nil {
-			u.log.Errorf("could not update user with error %e", err)
+		return u.finishReport(ctx, chatId, user)
+	case user.Editing:
+		// Photos are saved as soon as they are sent or deleted, the profile can't be left without them
+		if user.Stage == ProfileStagePhotos && len(user.Image) == 0 {
+			return tgbotapi.NewMessage(chatId, "Сначала добавьте хотя бы одну фотографию."), nil
+		}
+
+		user.Editing = false
+		user.Stage = ProfileStageNone
+		if err := u.saveUser(ctx, user); err != nil {
 			return tgbotapi.MessageConfig{}, err
 		}
 
-		if user.Stage != ProfileStageNone {
-			text = u.Stages[user.Stage]
+		return u.finishProfile(ctx, chatId, user)
+	case u.settings.Has(user.Stage):
+		user.Stage = ProfileStageNone
+		if err := u.saveUser(ctx, user); err != nil {
+			return tgbotapi.MessageConfig{}, err
 		}
-	} else {
-		text = "Данные введены некорректно, попробуйте снова."
+
+		return u.finishSettings(ctx, chatId, user)
+	case user.Stage != ProfileStageNone:
+		return u.DialogReminder(chatId, user), nil
+	default:
+		return tgbotapi.NewMessage(chatId, "Нечего отменять."), nil
+	}
+}
+
+// DialogReminder answers the commands sent in the middle of a dialog, they aren't handled until it's finished.
+func (u *Usecase) DialogReminder(chatId int64, user *models.User) tgbotapi.Chattable {
+	switch {
+	case u.report.Has(user.Stage):
+		msgConfig := tgbotapi.NewMessage(chatId, reportReminderText)
+		msgConfig.ReplyMarkup = u.createReportKeyboardMarkup()
+		return msgConfig
+	case user.Editing:
+		msgConfig := tgbotapi.NewMessage(chatId, "Сначала закончите изменение анкеты или отмените его: /cancel")
+		msgConfig.ReplyMarkup = u.keyboards.AppendCancelRow(nil)
+		return msgConfig
+	case u.settings.Has(user.Stage):
+		msgConfig := tgbotapi.NewMessage(chatId, "Сначала закончите настройку поиска или отмените её: /cancel")
+		msgConfig.ReplyMarkup = u.keyboards.AppendCancelRow(nil)
+		return msgConfig
+	default:
+		return tgbotapi.NewMessage(chatId, "Пожалуйста дозаполните анкету.")
 	}
+}
+
+func (u *Usecase) dialogs() []*dialog.Dialog {
+	return []*dialog.Dialog{u.profile, u.settings, u.report}
+}
 
-	if user.Stage == ProfileStageNone {
-		photoCfg := tgbotapi.NewPhoto(chatId, tgbotapi.FileID(user.Image))
-		photoCfg.Caption = internal.CreateMyProfileCaption(user)
-		photoCfg.ParseMode = tgbotapi.ModeMarkdown
-		return photoCfg, nil
+// Prompt returns the question of the stage.
+func (u *Usecase) Prompt(stage int) string {
+	for _, d := range u.dialogs() {
+		if step := d.Step(stage); step != nil {
+			return step.Prompt
+		}
 	}
 
-	outputMsg := tgbotapi.NewMessage(chatId, text)
+	return ""
+}
 
-	if len(skipData) > 0 && skipData != "emptyImage" {
-		outputMsg.ReplyMarkup = internal.CreateSkipKeyboardMarkup(skipData)
+func (u *Usecase) saveUser(ctx context.Context, user *models.User) error {
+	if err := u.users.UpdateByUserId(ctx, user); err != nil {
+		u.logger(ctx).Errorw("could not update user", "err", err)
+		return fmt.Errorf("update user: %w", err)
 	}
 
+	return nil
+}
+
+// finishProfile shows the filled profile with the edit menu
+func (u *Usecase) finishProfile(_ context.Context, chatId int64, user *models.User) (tgbotapi.Chattable, error) {
+	photoCfg := tgbotapi.NewPhoto(chatId, tgbotapi.FileID(user.Image))
+	photoCfg.Caption = internal.CreateMyProfileCaption(user)
+	photoCfg.ParseMode = tgbotapi.ModeMarkdown
+	photoCfg.ReplyMarkup = u.keyboards.CreateEditKeyboardMarkup(editFields...)
+	return photoCfg, nil
+}
+
+func (u *Usecase) finishSettings(_ context.Context, chatId int64, user *models.User) (tgbotapi.Chattable, error) {
+	outputMsg := tgbotapi.NewMessage(chatId, internal.CreateSettingsCaption(user))
+	outputMsg.ParseMode = tgbotapi.ModeMarkdown
 	return outputMsg, nil
 }
@@ -3,12 +3,14 @@ package usecase
 import (
 	"context"
 	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal"
 	"github.com/Eretic431/datingTelegramBot/internal/data/models"
 	"github.com/Eretic431/datingTelegramBot/internal/mock"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zaptest"
+	"strconv"
 	"testing"
 )
 
@@ -33,7 +35,13 @@ func TestUsecase_HandleProfile(t *testing.T) {
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	messageCfg, err := usecase.HandleProfile(context.Background(), inputMsg, user)
@@ -64,7 +72,13 @@ func TestUsecase_HandleProfile_ShouldReturnErrorOnFailure(t *testing.T) {
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	messageCfg, err := usecase.HandleProfile(context.Background(), inputMsg, user)
@@ -79,7 +93,7 @@ func TestUsecase_HandleFillingProfile_Correct(t *testing.T) {
 	defer ctrl.Finish()
 
 	var chatId int64 = 1
-	photoId := "NoImageData"
+	photoId := ""
 
 	usersRepo := mock.NewMockUsersRepository(ctrl)
 	usersRepo.EXPECT().
@@ -87,18 +101,30 @@ func TestUsecase_HandleFillingProfile_Correct(t *testing.T) {
 		Return(nil).
 		Times(MaxProfileStage)
 
+	photosRepo := mock.NewMockPhotosRepository(ctrl)
+	photosRepo.EXPECT().
+		GetByUserId(gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
 	usecase := NewUsecase(
 		usersRepo,
 		nil,
+		photosRepo,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
 		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
-	data := []string{"name", "1", "city", "description", "image"}
+	data := []string{"name", "20", "city", "description", "Готово"}
 
 	for stage := 0; stage < MaxProfileStage; stage++ {
 		inputText := data[stage]
-		user := &models.User{Id: "id", Stage: stage}
+		user := &models.User{Id: 1, Image: "image", Stage: stage}
 
 		chattable, err := usecase.HandleFillingProfile(context.Background(), inputText, chatId, photoId, user)
 		assert.Nil(t, err)
@@ -115,7 +141,7 @@ func TestUsecase_HandleFillingProfile_Incorrect(t *testing.T) {
 	defer ctrl.Finish()
 
 	var chatId int64 = 1
-	photoId := "-"
+	photoId := ""
 
 	usersRepo := mock.NewMockUsersRepository(ctrl)
 	//usersRepo.EXPECT().
@@ -123,18 +149,30 @@ func TestUsecase_HandleFillingProfile_Incorrect(t *testing.T) {
 	//	Return(nil).
 	//	Times(MaxProfileStage)
 
+	photosRepo := mock.NewMockPhotosRepository(ctrl)
+	photosRepo.EXPECT().
+		GetByUserId(gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
 	usecase := NewUsecase(
 		usersRepo,
 		nil,
+		photosRepo,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
 		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	data := []string{"", "", "", "", ""}
 
 	for stage := 0; stage < MaxProfileStage; stage++ {
 		inputText := data[stage]
-		user := &models.User{Id: "id", Stage: stage}
+		user := &models.User{Id: 1, Stage: stage}
 
 		chattable, err := usecase.HandleFillingProfile(context.Background(), inputText, chatId, photoId, user)
 		assert.Nil(t, err)
@@ -153,7 +191,7 @@ func TestUsecase_HandleFillingProfile_StageNameCorrect_ShouldReturnSameErrorOnUp
 	inputText := "text"
 	var chatId int64 = 1
 	photoId := "photoId"
-	user := &models.User{Id: "id", Stage: 0}
+	user := &models.User{Id: 1, Stage: 0}
 
 	usersRepo := mock.NewMockUsersRepository(ctrl)
 	expectedError := errors.New("some error")
@@ -166,7 +204,13 @@ func TestUsecase_HandleFillingProfile_StageNameCorrect_ShouldReturnSameErrorOnUp
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	chattable, err := usecase.HandleFillingProfile(context.Background(), inputText, chatId, photoId, user)
@@ -186,7 +230,7 @@ func TestUsecase_HandleFillingProfile_StageNameIncorrect(t *testing.T) {
 	inputText := ""
 	var chatId int64 = 1
 	photoId := "photoId"
-	user := &models.User{Id: "id", Stage: 0}
+	user := &models.User{Id: 1, Stage: 0}
 
 	usersRepo := mock.NewMockUsersRepository(ctrl)
 
@@ -194,7 +238,13 @@ func TestUsecase_HandleFillingProfile_StageNameIncorrect(t *testing.T) {
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	chattable, err := usecase.HandleFillingProfile(context.Background(), inputText, chatId, photoId, user)
@@ -207,14 +257,117 @@ func TestUsecase_HandleFillingProfile_StageNameIncorrect(t *testing.T) {
 
 }
 
-func TestUsecase_HandleFillingProfile_StageSex(t *testing.T) {
+func TestUsecase_HandleFillingProfile_StagePhotos(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var chatId int64 = 1
+
+	cases := []struct {
+		name           string
+		inputText      string
+		photoId        string
+		expectedPhotos []string
+	}{
+		{"add", "", "third", []string{"first", "second", "third"}},
+		{"make first", "photo;first;1", "", []string{"second", "first"}},
+		{"delete", "photo;delete;0", "", []string{"second"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			user := &models.User{Id: 1, Image: "first", Stage: ProfileStagePhotos}
+
+			usersRepo := mock.NewMockUsersRepository(ctrl)
+			photosRepo := mock.NewMockPhotosRepository(ctrl)
+
+			photosRepo.EXPECT().
+				GetByUserId(gomock.Any(), user.Id).
+				Return([]*models.Photo{{FileId: "first"}, {FileId: "second"}}, nil).
+				Times(1)
+
+			photosRepo.EXPECT().
+				ReplaceByUserId(gomock.Any(), user.Id, c.expectedPhotos).
+				Return(nil).
+				Times(1)
+
+			usersRepo.EXPECT().
+				UpdateByUserId(gomock.Any(), user).
+				Return(nil).
+				Times(1)
+
+			usecase := NewUsecase(
+				usersRepo,
+				nil,
+				photosRepo,
+				nil,
+				nil,
+				nil,
+				testCallbacks,
+				nil,
+				zaptest.NewLogger(t).Sugar(),
+				testConfig,
+			)
+
+			chattable, err := usecase.HandleFillingProfile(context.Background(), c.inputText, chatId, c.photoId, user)
+			assert.Nil(t, err)
+
+			msgCfg, ok := chattable.(tgbotapi.MessageConfig)
+			assert.True(t, ok)
+			assert.EqualValues(t, chatId, msgCfg.ChatID)
+			assert.EqualValues(t, ProfileStagePhotos, user.Stage)
+			assert.EqualValues(t, c.expectedPhotos[0], user.Image)
+		})
+	}
+}
+
+func TestUsecase_HandleFillingProfile_StagePhotosShouldLimitPhotos(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &models.User{Id: 1, Image: "1", Stage: ProfileStagePhotos}
+
+	photos := make([]*models.Photo, 0, testConfig.MaxPhotos)
+	for i := 0; i < testConfig.MaxPhotos; i++ {
+		photos = append(photos, &models.Photo{FileId: strconv.Itoa(i)})
+	}
+
+	photosRepo := mock.NewMockPhotosRepository(ctrl)
+	photosRepo.EXPECT().
+		GetByUserId(gomock.Any(), user.Id).
+		Return(photos, nil).
+		Times(2)
+
+	usecase := NewUsecase(
+		mock.NewMockUsersRepository(ctrl),
+		nil,
+		photosRepo,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	chattable, err := usecase.HandleFillingProfile(context.Background(), "", 1, "new", user)
+	assert.Nil(t, err)
+
+	msgCfg, ok := chattable.(tgbotapi.MessageConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, "Можно загрузить не больше 5 фотографий.", msgCfg.Text)
+	assert.EqualValues(t, ProfileStagePhotos, user.Stage)
+}
+
+func TestUsecase_HandleFillingProfile_StageGender(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	inputText := "М"
 	var chatId int64 = 1
 	photoId := "photoId"
-	user := &models.User{Id: "id", Stage: MaxProfileStage}
+	user := &models.User{Id: 1, Stage: MaxProfileStage}
 
 	usersRepo := mock.NewMockUsersRepository(ctrl)
 
@@ -227,7 +380,13 @@ func TestUsecase_HandleFillingProfile_StageSex(t *testing.T) {
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	chattable, err := usecase.HandleFillingProfile(context.Background(), inputText, chatId, photoId, user)
@@ -238,16 +397,18 @@ func TestUsecase_HandleFillingProfile_StageSex(t *testing.T) {
 	assert.NotNil(t, photoCfg)
 	assert.EqualValues(t, tgbotapi.ModeMarkdown, photoCfg.ParseMode)
 	assert.EqualValues(t, chatId, photoCfg.ChatID)
+	assert.EqualValues(t, models.GenderMale, user.Gender)
+	assert.EqualValues(t, models.GenderFemale, user.InterestedIn)
 }
 
-func TestUsecase_HandleFillingProfile_StageSexIncorrect(t *testing.T) {
+func TestUsecase_HandleFillingProfile_StageGenderIncorrect(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	inputText := "WrongSex"
+	inputText := "WrongGender"
 	var chatId int64 = 1
 	photoId := "photoId"
-	user := &models.User{Id: "id", Stage: MaxProfileStage}
+	user := &models.User{Id: 1, Stage: MaxProfileStage}
 
 	usersRepo := mock.NewMockUsersRepository(ctrl)
 
@@ -255,7 +416,13 @@ func TestUsecase_HandleFillingProfile_StageSexIncorrect(t *testing.T) {
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	chattable, err := usecase.HandleFillingProfile(context.Background(), inputText, chatId, photoId, user)
@@ -266,3 +433,271 @@ func TestUsecase_HandleFillingProfile_StageSexIncorrect(t *testing.T) {
 	assert.NotNil(t, messageCfg)
 	assert.EqualValues(t, chatId, messageCfg.ChatID)
 }
+
+func TestUsecase_HandleSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+
+	inputMsg := &tgbotapi.Message{
+		Chat: &tgbotapi.Chat{ID: 1},
+	}
+
+	user := &models.User{Id: 1, Stage: ProfileStageNone}
+
+	usersRepo.EXPECT().
+		UpdateByUserId(gomock.Any(), &models.User{Id: 1, Stage: SettingsStageMinAge}).
+		Return(nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	messageCfg, err := usecase.HandleSettings(context.Background(), inputMsg, user)
+	assert.Nil(t, err)
+	assert.EqualValues(t, inputMsg.Chat.ID, messageCfg.ChatID)
+	assert.EqualValues(t, SettingsStageMinAge, user.Stage)
+	assert.NotNil(t, messageCfg.ReplyMarkup)
+}
+
+func TestUsecase_HandleSettings_ShouldReturnErrorOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+
+	inputMsg := &tgbotapi.Message{
+		Chat: &tgbotapi.Chat{ID: 1},
+	}
+
+	expectedError := errors.New("some error")
+	usersRepo.EXPECT().
+		UpdateByUserId(gomock.Any(), gomock.Any()).
+		Return(expectedError).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	messageCfg, err := usecase.HandleSettings(context.Background(), inputMsg, &models.User{Id: 1})
+	assert.True(t, errors.Is(err, expectedError))
+	assert.EqualValues(t, tgbotapi.MessageConfig{}, messageCfg)
+}
+
+func TestUsecase_HandleFillingProfile_Settings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var chatId int64 = 1
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	usersRepo.EXPECT().
+		UpdateByUserId(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(MaxSettingsStage - SettingsStageMinAge + 3)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	user := &models.User{Id: 1, City: "city", Stage: SettingsStageMinAge}
+	data := []string{"18", "30", "Да", "Женщина", "Небинарный", "Готово"}
+
+	for _, inputText := range data {
+		chattable, err := usecase.HandleFillingProfile(context.Background(), inputText, chatId, "", user)
+		assert.Nil(t, err)
+		msgCfg, ok := chattable.(tgbotapi.MessageConfig)
+		assert.True(t, ok)
+		assert.EqualValues(t, chatId, msgCfg.ChatID)
+	}
+
+	assert.EqualValues(t, ProfileStageNone, user.Stage)
+	assert.EqualValues(t, 18, user.MinAge)
+	assert.EqualValues(t, 30, user.MaxAge)
+	assert.True(t, user.CityOnly)
+	assert.EqualValues(t, models.GenderFemale|models.GenderNonBinary, user.InterestedIn)
+}
+
+func TestUsecase_HandleFillingProfile_SettingsIncorrect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var chatId int64 = 1
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	cases := []struct {
+		stage     int
+		inputText string
+	}{
+		{SettingsStageMinAge, "-1"},
+		{SettingsStageMinAge, "abc"},
+		{SettingsStageMaxAge, "17"},
+		{SettingsStageCityOnly, "Может быть"},
+		{SettingsStageInterestedIn, "Кто-нибудь"},
+		{SettingsStageInterestedIn, "Готово"},
+	}
+
+	for _, c := range cases {
+		user := &models.User{Id: 1, MinAge: 18, Stage: c.stage}
+
+		chattable, err := usecase.HandleFillingProfile(context.Background(), c.inputText, chatId, "", user)
+		assert.Nil(t, err)
+		msgCfg, ok := chattable.(tgbotapi.MessageConfig)
+		assert.True(t, ok)
+		assert.EqualValues(t, "Данные введены некорректно, попробуйте снова.", msgCfg.Text)
+		assert.EqualValues(t, c.stage, user.Stage)
+	}
+}
+
+func TestUsecase_HandleFillingProfile_StageAgeOutOfRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var chatId int64 = 1
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	cases := []struct {
+		inputText    string
+		expectedText string
+	}{
+		{"15", "К сожалению, пользоваться сервисом можно только с 18 лет."},
+		{"999", "Пожалуйста, укажите свой настоящий возраст."},
+		{"age", "Данные введены некорректно, попробуйте снова."},
+	}
+
+	for _, c := range cases {
+		user := &models.User{Id: 1, Stage: 1}
+
+		chattable, err := usecase.HandleFillingProfile(context.Background(), c.inputText, chatId, "", user)
+		assert.Nil(t, err)
+		msgCfg, ok := chattable.(tgbotapi.MessageConfig)
+		assert.True(t, ok)
+		assert.EqualValues(t, c.expectedText, msgCfg.Text)
+		assert.EqualValues(t, 1, user.Stage)
+		assert.EqualValues(t, 0, user.Age)
+	}
+}
+
+func TestUsecase_HandleCancel_ShouldLeaveSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &models.User{Id: 1, MinAge: 20, Stage: SettingsStageMaxAge}
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	usersRepo.EXPECT().
+		UpdateByUserId(gomock.Any(), &models.User{Id: 1, MinAge: 20, Stage: ProfileStageNone}).
+		Return(nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	chattable, err := usecase.HandleCancel(context.Background(), 1, user)
+	assert.Nil(t, err)
+
+	msgCfg, ok := chattable.(tgbotapi.MessageConfig)
+	assert.True(t, ok)
+	assert.Contains(t, msgCfg.Text, "от 20")
+}
+
+func TestUsecase_HandleSettings_ShouldAddCancelButton(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	usersRepo.EXPECT().
+		UpdateByUserId(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	msg := &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 1}}
+	msgCfg, err := usecase.HandleSettings(context.Background(), msg, &models.User{Id: 1, Stage: ProfileStageNone})
+	assert.Nil(t, err)
+
+	keyboard, ok := msgCfg.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup)
+	assert.True(t, ok)
+	lastRow := keyboard.InlineKeyboard[len(keyboard.InlineKeyboard)-1]
+	assert.EqualValues(t, callbackData(t, internal.CallbackCancel, ""), *lastRow[0].CallbackData)
+}
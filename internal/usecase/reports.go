@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/Eretic431/datingTelegramBot/internal/dialog"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const reportReminderText = "Сначала выберите причину жалобы или отмените её: /cancel"
+
+// reportReasons are offered at ReportStageReason, the chosen one is stored with the report
+func (u *Usecase) reportReasons() []string {
+	return []string{
+		"Спам или реклама",
+		"Фейковая анкета",
+		"Оскорбления",
+		"Неприемлемые фото",
+		fmt.Sprintf("Возраст меньше %d", u.config.MinAge),
+		"Другое",
+	}
+}
+
+func (u *Usecase) createReportKeyboardMarkup() tgbotapi.InlineKeyboardMarkup {
+	return u.keyboards.CreateColumnKeyboardMarkup(append(u.reportReasons(), choiceCancel)...)
+}
+
+// HandleReport asks the user why toId is reported, the report is stored after a reason is picked.
+func (u *Usecase) HandleReport(ctx context.Context, chatId int64, user *models.User, toId int64) (tgbotapi.MessageConfig, error) {
+	u.logger(ctx).Info("handleReport")
+
+	if toId == user.Id {
+		return tgbotapi.NewMessage(chatId, dialog.InvalidInputText), nil
+	}
+
+	user.ReportedId = toId
+	return u.report.Start(ctx, chatId, user, ReportStageReason)
+}
+
+// HandleBlock hides the users from each other for good.
+func (u *Usecase) HandleBlock(ctx context.Context, chatId int64, user *models.User, toId int64) (tgbotapi.MessageConfig, error) {
+	u.logger(ctx).Info("handleBlock")
+
+	if toId == user.Id {
+		return tgbotapi.NewMessage(chatId, dialog.InvalidInputText), nil
+	}
+
+	if err := u.blocks.Add(ctx, user.Id, toId); err != nil {
+		u.logger(ctx).Errorw("could not add block", "err", err)
+		return tgbotapi.MessageConfig{}, fmt.Errorf("add block: %w", err)
+	}
+
+	return tgbotapi.NewMessage(chatId, "Пользователь заблокирован, вы больше не увидите друг друга. Продолжить: /next"), nil
+}
+
+// addReport stores the report, also skipping the reported profile. Once enough users report it, it is hidden until review.
+func (u *Usecase) addReport(ctx context.Context, user *models.User, reason string) error {
+	report := &models.Report{FromId: user.Id, ToId: user.ReportedId, Reason: reason}
+	if err := u.reports.Add(ctx, report); err != nil {
+		u.logger(ctx).Errorw("could not add report", "err", err)
+		return fmt.Errorf("add report: %w", err)
+	}
+
+	// Matches can be reported too, so the reported profile isn't required to be served
+	if err := u.setLike(ctx, false, user.Id, user.ReportedId); err != nil {
+		return err
+	}
+
+	if u.config.ReportsToHide <= 0 {
+		return nil
+	}
+
+	count, err := u.reports.CountReporters(ctx, user.ReportedId)
+	if err != nil {
+		u.logger(ctx).Errorw("could not count reporters", "err", err)
+		return fmt.Errorf("count reporters: %w", err)
+	}
+
+	if count >= u.config.ReportsToHide {
+		if err := u.users.SetHidden(ctx, user.ReportedId, true); err != nil {
+			u.logger(ctx).Errorw("could not hide user", "err", err)
+			return fmt.Errorf("hide user: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (u *Usecase) finishReport(_ context.Context, chatId int64, user *models.User) (tgbotapi.Chattable, error) {
+	if user.ReportedId == 0 {
+		return tgbotapi.NewMessage(chatId, "Жалоба отменена."), nil
+	}
+
+	return tgbotapi.NewMessage(chatId, "Спасибо, жалоба отправлена. Мы проверим анкету. Продолжить: /next"), nil
+}
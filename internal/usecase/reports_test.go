@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/Eretic431/datingTelegramBot/internal/mock"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	"testing"
+)
+
+func TestUsecase_HandleReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &models.User{Id: 1, Stage: ProfileStageNone}
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	usersRepo.EXPECT().
+		UpdateByUserId(gomock.Any(), &models.User{Id: 1, Stage: ReportStageReason, ReportedId: 2}).
+		Return(nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	msgCfg, err := usecase.HandleReport(context.Background(), 1, user, 2)
+	assert.Nil(t, err)
+	assert.EqualValues(t, usecase.(*Usecase).Prompt(ReportStageReason), msgCfg.Text)
+
+	_, ok := msgCfg.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup)
+	assert.True(t, ok)
+}
+
+func TestUsecase_HandleFillingProfile_ReportShouldHideAfterEnoughReports(t *testing.T) {
+	cases := []struct {
+		name       string
+		reporters  int
+		shouldHide bool
+	}{
+		{"below threshold", testConfig.ReportsToHide - 1, false},
+		{"threshold", testConfig.ReportsToHide, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := &models.User{Id: 1, Stage: ReportStageReason, ReportedId: 2}
+
+			usersRepo := mock.NewMockUsersRepository(ctrl)
+			likesRepo := mock.NewMockLikesRepository(ctrl)
+			reportsRepo := mock.NewMockReportsRepository(ctrl)
+
+			reportsRepo.EXPECT().
+				Add(gomock.Any(), &models.Report{FromId: 1, ToId: 2, Reason: "Спам или реклама"}).
+				Return(nil).
+				Times(1)
+			reportsRepo.EXPECT().
+				CountReporters(gomock.Any(), int64(2)).
+				Return(c.reporters, nil).
+				Times(1)
+
+			likesRepo.EXPECT().
+				Get(gomock.Any(), int64(1), int64(2)).
+				Return(nil, models.ErrNoRecord).
+				Times(1)
+			likesRepo.EXPECT().
+				Add(gomock.Any(), &models.Like{FromId: 1, ToId: 2, Value: false}).
+				Return(nil).
+				Times(1)
+
+			if c.shouldHide {
+				usersRepo.EXPECT().
+					SetHidden(gomock.Any(), int64(2), true).
+					Return(nil).
+					Times(1)
+			}
+			usersRepo.EXPECT().
+				UpdateByUserId(gomock.Any(), user).
+				Return(nil).
+				Times(1)
+
+			usecase := NewUsecase(
+				usersRepo,
+				likesRepo,
+				nil,
+				nil,
+				reportsRepo,
+				nil,
+				testCallbacks,
+				nil,
+				zaptest.NewLogger(t).Sugar(),
+				testConfig,
+			)
+
+			chattable, err := usecase.HandleFillingProfile(context.Background(), "Спам или реклама", 1, "", user)
+			assert.Nil(t, err)
+
+			_, ok := chattable.(tgbotapi.MessageConfig)
+			assert.True(t, ok)
+			assert.EqualValues(t, ProfileStageNone, user.Stage)
+		})
+	}
+}
+
+func TestUsecase_HandleFillingProfile_ReportCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &models.User{Id: 1, Stage: ReportStageReason, ReportedId: 2}
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	usersRepo.EXPECT().
+		UpdateByUserId(gomock.Any(), &models.User{Id: 1, Stage: ProfileStageNone}).
+		Return(nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		mock.NewMockReportsRepository(ctrl),
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	chattable, err := usecase.HandleFillingProfile(context.Background(), choiceCancel, 1, "", user)
+	assert.Nil(t, err)
+
+	msgCfg, ok := chattable.(tgbotapi.MessageConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, "Жалоба отменена.", msgCfg.Text)
+}
+
+func TestUsecase_HandleFillingProfile_ReportShouldRemindReasonsOnOtherInput(t *testing.T) {
+	user := &models.User{Id: 1, Stage: ReportStageReason, ReportedId: 2}
+
+	usecase := NewUsecase(
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	chattable, err := usecase.HandleFillingProfile(context.Background(), "привет", 1, "", user)
+	assert.Nil(t, err)
+
+	msgCfg, ok := chattable.(tgbotapi.MessageConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, reportReminderText, msgCfg.Text)
+	assert.NotNil(t, msgCfg.ReplyMarkup)
+	assert.EqualValues(t, ReportStageReason, user.Stage)
+}
+
+func TestUsecase_HandleCancel_ShouldCancelReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &models.User{Id: 1, Stage: ReportStageReason, ReportedId: 2}
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	usersRepo.EXPECT().
+		UpdateByUserId(gomock.Any(), &models.User{Id: 1, Stage: ProfileStageNone}).
+		Return(nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	chattable, err := usecase.HandleCancel(context.Background(), 1, user)
+	assert.Nil(t, err)
+
+	msgCfg, ok := chattable.(tgbotapi.MessageConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, "Жалоба отменена.", msgCfg.Text)
+}
+
+func TestUsecase_DialogReminder(t *testing.T) {
+	usecase := NewUsecase(
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	reminder, ok := usecase.DialogReminder(1, &models.User{Stage: ReportStageReason}).(tgbotapi.MessageConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, reportReminderText, reminder.Text)
+
+	keyboard, ok := reminder.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup)
+	assert.True(t, ok)
+	assert.EqualValues(t, "Возраст меньше 18", keyboard.InlineKeyboard[4][0].Text)
+
+	reminder, ok = usecase.DialogReminder(1, &models.User{Stage: SettingsStageMaxAge}).(tgbotapi.MessageConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, "Сначала закончите настройку поиска или отмените её: /cancel", reminder.Text)
+	assert.NotNil(t, reminder.ReplyMarkup)
+
+	reminder, ok = usecase.DialogReminder(1, &models.User{Stage: ProfileStageAge}).(tgbotapi.MessageConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, "Пожалуйста дозаполните анкету.", reminder.Text)
+}
+
+func TestUsecase_HandleBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blocksRepo := mock.NewMockBlocksRepository(ctrl)
+	blocksRepo.EXPECT().
+		Add(gomock.Any(), int64(1), int64(2)).
+		Return(nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		nil,
+		nil,
+		nil,
+		blocksRepo,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	msgCfg, err := usecase.HandleBlock(context.Background(), 1, &models.User{Id: 1}, 2)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, msgCfg.ChatID)
+}
+
+func TestUsecase_HandleBlock_ShouldReturnErrorOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedError := errors.New("some error")
+
+	blocksRepo := mock.NewMockBlocksRepository(ctrl)
+	blocksRepo.EXPECT().
+		Add(gomock.Any(), int64(1), int64(2)).
+		Return(expectedError).
+		Times(1)
+
+	usecase := NewUsecase(
+		nil,
+		nil,
+		nil,
+		blocksRepo,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	_, err := usecase.HandleBlock(context.Background(), 1, &models.User{Id: 1}, 2)
+	assert.True(t, errors.Is(err, expectedError))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/Eretic431/datingTelegramBot/internal"
 	"github.com/Eretic431/datingTelegramBot/internal/data/models"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -18,25 +19,22 @@ func (u *Usecase) HandleStart(
 		text = "Вы уже зарегистрированы в системе"
 	} else {
 		text = fmt.Sprintf("Привет! Я, %s, помогаю людям познакомиться\n\n"+
-			"*Список доступных команд:* \n"+
-			"- /start - начало работы\n"+
-			"- /profile - заполнить анкету\n"+
-			"- /next - показать следующего пользователя",
+			internal.CommandsList,
 			u.bot.Self.UserName,
 		)
 
 		user := &models.User{
-			Id:      inputMsg.From.UserName,
-			Sex:     false,
-			Started: true,
-			Stage:   ProfileStageNone,
-			ChatId:  inputMsg.Chat.ID,
+			Id:       inputMsg.From.ID,
+			Username: inputMsg.From.UserName,
+			Started:  true,
+			Stage:    ProfileStageNone,
+			ChatId:   inputMsg.Chat.ID,
 		}
 
 		err := u.users.UpdateByUserId(ctx, user)
 		if err != nil {
-			u.log.Errorf("could not update user with error %e", err)
-			return tgbotapi.MessageConfig{}, err
+			u.logger(ctx).Errorw("could not update user", "err", err)
+			return tgbotapi.MessageConfig{}, fmt.Errorf("update user: %w", err)
 		}
 	}
 
@@ -47,26 +45,33 @@ func (u *Usecase) HandleStart(
 }
 
 func (u *Usecase) IsStarted(ctx context.Context, inputMsg *tgbotapi.Message) (bool, error) {
-	user, err := u.users.GetByUserId(ctx, inputMsg.From.UserName)
+	user, err := u.users.GetByUserId(ctx, inputMsg.From.ID)
 	if err != nil {
 		if errors.Is(err, models.ErrNoRecord) {
 			user := &models.User{
-				Id:      inputMsg.From.UserName,
-				Sex:     false,
-				Started: false,
-				Stage:   ProfileStageNone,
-				ChatId:  inputMsg.Chat.ID,
+				Id:       inputMsg.From.ID,
+				Username: inputMsg.From.UserName,
+				Started:  false,
+				Stage:    ProfileStageNone,
+				ChatId:   inputMsg.Chat.ID,
 			}
 
 			err := u.users.Add(ctx, user)
 			if err != nil {
-				u.log.Errorf("could not insert user with error %e", err)
-				return false, err
+				u.logger(ctx).Errorw("could not insert user", "err", err)
+				return false, fmt.Errorf("insert user: %w", err)
 			}
 			return false, nil
 		}
 		return false, err
 	}
 
+	if user.Username != inputMsg.From.UserName {
+		if err := u.users.UpdateUsername(ctx, user.Id, inputMsg.From.UserName); err != nil {
+			u.logger(ctx).Errorw("could not update username", "err", err)
+			return false, fmt.Errorf("update username: %w", err)
+		}
+	}
+
 	return user.Started, nil
 }
@@ -22,7 +22,13 @@ func TestUsecase_HandleStart_IfStartedTrue(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	msg, err := usecase.HandleStart(context.Background(), inputMsg, true)
@@ -37,18 +43,18 @@ func TestUsecase_HandleStart_IfStartedFalse(t *testing.T) {
 	defer ctrl.Finish()
 
 	inputMsg := &tgbotapi.Message{
-		From: &tgbotapi.User{UserName: "username"},
+		From: &tgbotapi.User{ID: 1, UserName: "username"},
 		Chat: &tgbotapi.Chat{ID: 1},
 	}
 
 	usersRepo := mock.NewMockUsersRepository(ctrl)
 
 	user := &models.User{
-		Id:      inputMsg.From.UserName,
-		Sex:     false,
-		Started: true,
-		Stage:   ProfileStageNone,
-		ChatId:  inputMsg.Chat.ID,
+		Id:       inputMsg.From.ID,
+		Username: inputMsg.From.UserName,
+		Started:  true,
+		Stage:    ProfileStageNone,
+		ChatId:   inputMsg.Chat.ID,
 	}
 
 	usersRepo.EXPECT().
@@ -59,8 +65,14 @@ func TestUsecase_HandleStart_IfStartedFalse(t *testing.T) {
 	usecase := NewUsecase(
 		usersRepo,
 		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
 		&tgbotapi.BotAPI{Self: tgbotapi.User{UserName: "botName"}},
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	msg, err := usecase.HandleStart(context.Background(), inputMsg, false)
@@ -75,18 +87,18 @@ func TestUsecase_HandleStart_IfStartedFalse_ShouldReturnErrorOnFailure(t *testin
 	defer ctrl.Finish()
 
 	inputMsg := &tgbotapi.Message{
-		From: &tgbotapi.User{UserName: "username"},
+		From: &tgbotapi.User{ID: 1, UserName: "username"},
 		Chat: &tgbotapi.Chat{ID: 1},
 	}
 
 	usersRepo := mock.NewMockUsersRepository(ctrl)
 
 	user := &models.User{
-		Id:      inputMsg.From.UserName,
-		Sex:     false,
-		Started: true,
-		Stage:   ProfileStageNone,
-		ChatId:  inputMsg.Chat.ID,
+		Id:       inputMsg.From.ID,
+		Username: inputMsg.From.UserName,
+		Started:  true,
+		Stage:    ProfileStageNone,
+		ChatId:   inputMsg.Chat.ID,
 	}
 
 	expectedError := errors.New("some error")
@@ -98,8 +110,14 @@ func TestUsecase_HandleStart_IfStartedFalse_ShouldReturnErrorOnFailure(t *testin
 	usecase := NewUsecase(
 		usersRepo,
 		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
 		&tgbotapi.BotAPI{Self: tgbotapi.User{UserName: "botName"}},
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	msg, err := usecase.HandleStart(context.Background(), inputMsg, false)
@@ -114,18 +132,19 @@ func TestUsecase_IsStarted(t *testing.T) {
 	defer ctrl.Finish()
 
 	inputMsg := &tgbotapi.Message{
-		From: &tgbotapi.User{UserName: "username"},
+		From: &tgbotapi.User{ID: 1, UserName: "username"},
 		Chat: &tgbotapi.Chat{ID: 1},
 	}
 
 	usersRepo := mock.NewMockUsersRepository(ctrl)
 
 	expectedUser := &models.User{
-		Started: true,
+		Username: inputMsg.From.UserName,
+		Started:  true,
 	}
 
 	usersRepo.EXPECT().
-		GetByUserId(gomock.Any(), inputMsg.From.UserName).
+		GetByUserId(gomock.Any(), inputMsg.From.ID).
 		Return(expectedUser, nil).
 		Times(1)
 
@@ -133,7 +152,13 @@ func TestUsecase_IsStarted(t *testing.T) {
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	started, err := usecase.IsStarted(context.Background(), inputMsg)
@@ -141,12 +166,58 @@ func TestUsecase_IsStarted(t *testing.T) {
 	assert.EqualValues(t, expectedUser.Started, started)
 }
 
+func TestUsecase_IsStarted_ShouldUpdateChangedUsername(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inputMsg := &tgbotapi.Message{
+		From: &tgbotapi.User{ID: 1, UserName: "new_username"},
+		Chat: &tgbotapi.Chat{ID: 1},
+	}
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+
+	expectedUser := &models.User{
+		Id:       inputMsg.From.ID,
+		Username: "old_username",
+		Started:  true,
+	}
+
+	usersRepo.EXPECT().
+		UpdateUsername(gomock.Any(), inputMsg.From.ID, inputMsg.From.UserName).
+		After(
+			usersRepo.EXPECT().
+				GetByUserId(gomock.Any(), inputMsg.From.ID).
+				Return(expectedUser, nil).
+				Times(1),
+		).
+		Return(nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		testConfig,
+	)
+
+	started, err := usecase.IsStarted(context.Background(), inputMsg)
+	assert.Nil(t, err)
+	assert.True(t, started)
+}
+
 func TestUsecase_IsStarted_ShouldReturnSameErrorOnFailure(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	inputMsg := &tgbotapi.Message{
-		From: &tgbotapi.User{UserName: "username"},
+		From: &tgbotapi.User{ID: 1, UserName: "username"},
 		Chat: &tgbotapi.Chat{ID: 1},
 	}
 
@@ -154,7 +225,7 @@ func TestUsecase_IsStarted_ShouldReturnSameErrorOnFailure(t *testing.T) {
 
 	expectedError := errors.New("some error")
 	usersRepo.EXPECT().
-		GetByUserId(gomock.Any(), inputMsg.From.UserName).
+		GetByUserId(gomock.Any(), inputMsg.From.ID).
 		Return(nil, expectedError).
 		Times(1)
 
@@ -162,7 +233,13 @@ func TestUsecase_IsStarted_ShouldReturnSameErrorOnFailure(t *testing.T) {
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	started, err := usecase.IsStarted(context.Background(), inputMsg)
@@ -176,25 +253,25 @@ func TestUsecase_IsStarted_ShouldAddUserIfNotExists(t *testing.T) {
 	defer ctrl.Finish()
 
 	inputMsg := &tgbotapi.Message{
-		From: &tgbotapi.User{UserName: "username"},
+		From: &tgbotapi.User{ID: 1, UserName: "username"},
 		Chat: &tgbotapi.Chat{ID: 1},
 	}
 
 	usersRepo := mock.NewMockUsersRepository(ctrl)
 
 	user := &models.User{
-		Id:      inputMsg.From.UserName,
-		Sex:     false,
-		Started: false,
-		Stage:   ProfileStageNone,
-		ChatId:  inputMsg.Chat.ID,
+		Id:       inputMsg.From.ID,
+		Username: inputMsg.From.UserName,
+		Started:  false,
+		Stage:    ProfileStageNone,
+		ChatId:   inputMsg.Chat.ID,
 	}
 
 	usersRepo.EXPECT().
 		Add(gomock.Any(), user).
 		After(
 			usersRepo.EXPECT().
-				GetByUserId(gomock.Any(), inputMsg.From.UserName).
+				GetByUserId(gomock.Any(), inputMsg.From.ID).
 				Return(nil, models.ErrNoRecord).
 				Times(1),
 		).
@@ -205,7 +282,13 @@ func TestUsecase_IsStarted_ShouldAddUserIfNotExists(t *testing.T) {
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	started, err := usecase.IsStarted(context.Background(), inputMsg)
@@ -218,18 +301,18 @@ func TestUsecase_IsStarted_ShouldAddUserIfNotExists_ShouldReturnSameErrorOnFailu
 	defer ctrl.Finish()
 
 	inputMsg := &tgbotapi.Message{
-		From: &tgbotapi.User{UserName: "username"},
+		From: &tgbotapi.User{ID: 1, UserName: "username"},
 		Chat: &tgbotapi.Chat{ID: 1},
 	}
 
 	usersRepo := mock.NewMockUsersRepository(ctrl)
 
 	user := &models.User{
-		Id:      inputMsg.From.UserName,
-		Sex:     false,
-		Started: false,
-		Stage:   ProfileStageNone,
-		ChatId:  inputMsg.Chat.ID,
+		Id:       inputMsg.From.ID,
+		Username: inputMsg.From.UserName,
+		Started:  false,
+		Stage:    ProfileStageNone,
+		ChatId:   inputMsg.Chat.ID,
 	}
 
 	expectedError := errors.New("some error")
@@ -238,7 +321,7 @@ func TestUsecase_IsStarted_ShouldAddUserIfNotExists_ShouldReturnSameErrorOnFailu
 		Add(gomock.Any(), user).
 		After(
 			usersRepo.EXPECT().
-				GetByUserId(gomock.Any(), inputMsg.From.UserName).
+				GetByUserId(gomock.Any(), inputMsg.From.ID).
 				Return(nil, models.ErrNoRecord).
 				Times(1),
 		).
@@ -249,7 +332,13 @@ func TestUsecase_IsStarted_ShouldAddUserIfNotExists_ShouldReturnSameErrorOnFailu
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	started, err := usecase.IsStarted(context.Background(), inputMsg)
@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/Eretic431/datingTelegramBot/internal/dialog"
+	"strconv"
+)
+
+// profileSteps are the questions of /profile, each of them can also be asked alone from /edit
+func (u *Usecase) profileSteps() map[int]*dialog.Step {
+	return map[int]*dialog.Step{
+		ProfileStageName: {
+			Prompt:   "Как Вас зовут?",
+			Skip:     func(user *models.User) string { return user.Name },
+			Validate: notEmpty,
+			Parse: func(_ context.Context, input dialog.Input, user *models.User) (dialog.Result, error) {
+				user.Name = input.Text
+				return dialog.Result{}, nil
+			},
+			Next: ProfileStageAge,
+		},
+		ProfileStageAge: {
+			Prompt:   "Сколько Вам лет?",
+			Skip:     u.skipAge,
+			Validate: u.validateAge,
+			Parse: func(_ context.Context, input dialog.Input, user *models.User) (dialog.Result, error) {
+				user.Age, _ = strconv.Atoi(input.Text)
+				return dialog.Result{}, nil
+			},
+			Next: ProfileStageCity,
+		},
+		ProfileStageCity: {
+			Prompt:   "Из какого Вы города?",
+			Skip:     func(user *models.User) string { return user.City },
+			Validate: notEmpty,
+			Parse: func(_ context.Context, input dialog.Input, user *models.User) (dialog.Result, error) {
+				user.City = input.Text
+				return dialog.Result{}, nil
+			},
+			Next: ProfileStageDescription,
+		},
+		ProfileStageDescription: {
+			Prompt:   "Введите краткое описание своего профиля.",
+			Skip:     func(user *models.User) string { return user.Description },
+			Validate: notEmpty,
+			Parse: func(_ context.Context, input dialog.Input, user *models.User) (dialog.Result, error) {
+				user.Description = input.Text
+				return dialog.Result{}, nil
+			},
+			Next: ProfileStagePhotos,
+		},
+		ProfileStagePhotos: {
+			Prompt: fmt.Sprintf("Пришлите до %d фотографий, которые будут показываться другим пользователям в ленте. "+
+				"Когда закончите, нажмите «%s».", u.config.MaxPhotos, choiceDone),
+			Keyboard: u.photosKeyboard,
+			Parse:    u.parsePhotos,
+			Next:     ProfileStageGender,
+		},
+		ProfileStageGender: {
+			Prompt: "Укажите Ваш пол.",
+			Keyboard: func(context.Context, *models.User) (interface{}, error) {
+				return u.keyboards.CreateGenderKeyboardMarkup(), nil
+			},
+			Parse: func(_ context.Context, input dialog.Input, user *models.User) (dialog.Result, error) {
+				gender, ok := internal.ParseGender(input.Text)
+				if !ok {
+					return dialog.Result{}, dialog.ErrInvalidInput
+				}
+
+				user.Gender = gender
+				if user.InterestedIn == 0 {
+					user.InterestedIn = defaultInterestedIn(gender)
+				}
+				return dialog.Result{}, nil
+			},
+			Next: dialog.Done,
+		},
+	}
+}
+
+// settingsSteps are the questions of /settings
+func (u *Usecase) settingsSteps() map[int]*dialog.Step {
+	return map[int]*dialog.Step{
+		SettingsStageMinAge: {
+			Prompt: "Укажите минимальный возраст собеседника. Введите 0, если ограничение не нужно.",
+			Skip:   func(user *models.User) string { return strconv.Itoa(user.MinAge) },
+			Validate: func(input dialog.Input, _ *models.User) error {
+				if minAge, err := strconv.Atoi(input.Text); err != nil || minAge < 0 {
+					return dialog.ErrInvalidInput
+				}
+				return nil
+			},
+			Parse: func(_ context.Context, input dialog.Input, user *models.User) (dialog.Result, error) {
+				user.MinAge, _ = strconv.Atoi(input.Text)
+				return dialog.Result{}, nil
+			},
+			Next: SettingsStageMaxAge,
+		},
+		SettingsStageMaxAge: {
+			Prompt: "Укажите максимальный возраст собеседника. Введите 0, если ограничение не нужно.",
+			Skip:   func(user *models.User) string { return strconv.Itoa(user.MaxAge) },
+			Validate: func(input dialog.Input, user *models.User) error {
+				if maxAge, err := strconv.Atoi(input.Text); err != nil || (maxAge != 0 && maxAge < user.MinAge) {
+					return dialog.ErrInvalidInput
+				}
+				return nil
+			},
+			Parse: func(_ context.Context, input dialog.Input, user *models.User) (dialog.Result, error) {
+				user.MaxAge, _ = strconv.Atoi(input.Text)
+				return dialog.Result{}, nil
+			},
+			Next: SettingsStageCityOnly,
+		},
+		SettingsStageCityOnly: {
+			Prompt: "Показывать анкеты только из Вашего города?",
+			Keyboard: func(context.Context, *models.User) (interface{}, error) {
+				return u.keyboards.CreateChoiceKeyboardMarkup(choiceYes, choiceNo), nil
+			},
+			Parse: func(_ context.Context, input dialog.Input, user *models.User) (dialog.Result, error) {
+				switch input.Text {
+				case choiceYes:
+					user.CityOnly = true
+				case choiceNo:
+					user.CityOnly = false
+				default:
+					return dialog.Result{}, dialog.ErrInvalidInput
+				}
+				return dialog.Result{}, nil
+			},
+			Next: SettingsStageInterestedIn,
+		},
+		SettingsStageInterestedIn: {
+			Prompt: "Кого Вы хотите видеть? Можно выбрать несколько вариантов.",
+			Keyboard: func(_ context.Context, user *models.User) (interface{}, error) {
+				return u.keyboards.CreateInterestedInKeyboardMarkup(user.InterestedIn, choiceDone), nil
+			},
+			Parse: func(_ context.Context, input dialog.Input, user *models.User) (dialog.Result, error) {
+				if input.Text == choiceDone {
+					if user.InterestedIn == 0 {
+						return dialog.Result{}, dialog.ErrInvalidInput
+					}
+					return dialog.Result{}, nil
+				}
+
+				gender, ok := internal.ParseGender(input.Text)
+				if !ok {
+					return dialog.Result{}, dialog.ErrInvalidInput
+				}
+
+				// Toggling a gender keeps the user on the step
+				user.InterestedIn ^= gender
+				return dialog.Result{Stay: true}, nil
+			},
+			Next: dialog.Done,
+		},
+	}
+}
+
+// reportSteps ask the reason of a report about user.ReportedId
+func (u *Usecase) reportSteps() map[int]*dialog.Step {
+	return map[int]*dialog.Step{
+		ReportStageReason: {
+			Prompt: "Почему Вы хотите пожаловаться на анкету?",
+			Keyboard: func(context.Context, *models.User) (interface{}, error) {
+				return u.createReportKeyboardMarkup(), nil
+			},
+			Parse: func(ctx context.Context, input dialog.Input, user *models.User) (dialog.Result, error) {
+				if input.Text == choiceCancel {
+					user.ReportedId = 0
+					return dialog.Result{}, nil
+				}
+
+				for _, reason := range u.reportReasons() {
+					if input.Text == reason {
+						return dialog.Result{}, u.addReport(ctx, user, reason)
+					}
+				}
+
+				return dialog.Result{}, dialog.Invalid(reportReminderText)
+			},
+			Next: dialog.Done,
+		},
+	}
+}
+
+func notEmpty(input dialog.Input, _ *models.User) error {
+	if len(input.Text) == 0 {
+		return dialog.ErrInvalidInput
+	}
+	return nil
+}
+
+func (u *Usecase) validateAge(input dialog.Input, _ *models.User) error {
+	age, err := strconv.Atoi(input.Text)
+	switch {
+	case err != nil || age <= 0:
+		return dialog.ErrInvalidInput
+	case age < u.config.MinAge:
+		return dialog.Invalid(u.underAgeText())
+	case age > u.config.MaxAge:
+		return dialog.Invalid("Пожалуйста, укажите свой настоящий возраст.")
+	}
+	return nil
+}
+
+func (u *Usecase) underAgeText() string {
+	return fmt.Sprintf("К сожалению, пользоваться сервисом можно только с %d лет.", u.config.MinAge)
+}
+
+// skipAge doesn't offer ages which wouldn't pass validateAge
+func (u *Usecase) skipAge(user *models.User) string {
+	if user.Age < u.config.MinAge || user.Age > u.config.MaxAge {
+		return ""
+	}
+	return strconv.Itoa(user.Age)
+}
+
+func (u *Usecase) photosKeyboard(ctx context.Context, user *models.User) (interface{}, error) {
+	photos, err := u.photoFileIds(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return u.keyboards.CreatePhotosKeyboardMarkup(len(photos), choiceDone), nil
+}
+
+// parsePhotos adds a sent photo or applies a button to the existing ones. The user stays on the step until choiceDone.
+func (u *Usecase) parsePhotos(ctx context.Context, input dialog.Input, user *models.User) (dialog.Result, error) {
+	photos, err := u.photoFileIds(ctx, user)
+	if err != nil {
+		return dialog.Result{}, err
+	}
+
+	switch action, index, isAction := internal.ParsePhotoAction(input.Text); {
+	case input.Text == choiceDone:
+		if len(photos) == 0 {
+			return dialog.Result{}, dialog.ErrInvalidInput
+		}
+		return dialog.Result{}, nil
+	case isAction:
+		var ok bool
+		if photos, ok = applyPhotoAction(photos, action, index); !ok {
+			return dialog.Result{}, dialog.ErrInvalidInput
+		}
+	case len(input.PhotoId) > 0:
+		if len(photos) >= u.config.MaxPhotos {
+			return dialog.Result{}, dialog.Invalid(fmt.Sprintf("Можно загрузить не больше %d фотографий.", u.config.MaxPhotos))
+		}
+		photos = append(photos, input.PhotoId)
+	default:
+		return dialog.Result{}, dialog.ErrInvalidInput
+	}
+
+	if err := u.savePhotos(ctx, user, photos); err != nil {
+		return dialog.Result{}, err
+	}
+
+	return dialog.Result{
+		Stay: true,
+		Text: fmt.Sprintf("Фотографий в анкете: %d из %d. Пришлите ещё или нажмите «%s».",
+			len(photos), u.config.MaxPhotos, choiceDone),
+		ReplyMarkup: u.keyboards.CreatePhotosKeyboardMarkup(len(photos), choiceDone),
+	}, nil
+}
+
+func defaultInterestedIn(gender int) int {
+	switch gender {
+	case models.GenderMale:
+		return models.GenderFemale
+	case models.GenderFemale:
+		return models.GenderMale
+	default:
+		return models.GenderAny
+	}
+}
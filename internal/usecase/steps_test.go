@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/Eretic431/datingTelegramBot/internal/dialog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	"testing"
+)
+
+func TestUsecase_ProfileSteps_Age(t *testing.T) {
+	usecase := NewUsecase(nil, nil, nil, nil, nil, nil, testCallbacks, nil, zaptest.NewLogger(t).Sugar(), testConfig).(*Usecase)
+	step := usecase.profile.Step(ProfileStageAge)
+
+	cases := []struct {
+		inputText string
+		valid     bool
+	}{
+		{"18", true},
+		{"100", true},
+		{"17", false},
+		{"101", false},
+		{"age", false},
+	}
+
+	for _, c := range cases {
+		err := step.Validate(dialog.Input{Text: c.inputText}, &models.User{})
+		var invalid *dialog.InvalidInputError
+		assert.EqualValues(t, !c.valid, errors.As(err, &invalid), c.inputText)
+	}
+
+	user := &models.User{}
+	_, err := step.Parse(context.Background(), dialog.Input{Text: "20"}, user)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 20, user.Age)
+
+	assert.EqualValues(t, "20", step.Skip(user))
+	assert.EqualValues(t, "", step.Skip(&models.User{Age: 5}))
+}
+
+func TestUsecase_ProfileSteps_Gender(t *testing.T) {
+	usecase := NewUsecase(nil, nil, nil, nil, nil, nil, testCallbacks, nil, zaptest.NewLogger(t).Sugar(), testConfig).(*Usecase)
+	step := usecase.profile.Step(ProfileStageGender)
+
+	user := &models.User{InterestedIn: models.GenderNonBinary}
+	result, err := step.Parse(context.Background(), dialog.Input{Text: "Ж"}, user)
+	assert.Nil(t, err)
+	assert.False(t, result.Stay)
+	assert.EqualValues(t, models.GenderFemale, user.Gender)
+	assert.EqualValues(t, models.GenderNonBinary, user.InterestedIn)
+	assert.EqualValues(t, dialog.Done, step.Next)
+
+	_, err = step.Parse(context.Background(), dialog.Input{Text: "?"}, user)
+	assert.True(t, errors.Is(err, dialog.ErrInvalidInput))
+}
+
+func TestUsecase_SettingsSteps_InterestedInShouldStayOnToggle(t *testing.T) {
+	usecase := NewUsecase(nil, nil, nil, nil, nil, nil, testCallbacks, nil, zaptest.NewLogger(t).Sugar(), testConfig).(*Usecase)
+	step := usecase.settings.Step(SettingsStageInterestedIn)
+
+	user := &models.User{}
+	result, err := step.Parse(context.Background(), dialog.Input{Text: "Мужчина"}, user)
+	assert.Nil(t, err)
+	assert.True(t, result.Stay)
+	assert.EqualValues(t, models.GenderMale, user.InterestedIn)
+
+	result, err = step.Parse(context.Background(), dialog.Input{Text: choiceDone}, user)
+	assert.Nil(t, err)
+	assert.False(t, result.Stay)
+}
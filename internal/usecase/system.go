@@ -2,47 +2,58 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"github.com/Eretic431/datingTelegramBot/internal/data/models"
 )
 
 func (u *Usecase) DeleteAll(ctx context.Context) error {
 	if err := u.likes.DeleteAll(ctx); err != nil {
-		u.log.Errorf("couldn't delete all likes with err = %e", err)
-		return err
+		u.logger(ctx).Errorw("could not delete all likes", "err", err)
+		return fmt.Errorf("delete all likes: %w", err)
 	}
 	if err := u.users.DeleteAll(ctx); err != nil {
-		u.log.Errorf("couldn't delete all users with err = %e", err)
-		return err
+		u.logger(ctx).Errorw("could not delete all users", "err", err)
+		return fmt.Errorf("delete all users: %w", err)
 	}
 	return nil
 }
 
+// TestUserId is an id Telegram never assigns to real users.
+const TestUserId int64 = -1000
+
 func (u *Usecase) AddTestUser(ctx context.Context, sex bool) error {
+	gender := models.GenderFemale
+	if sex {
+		gender = models.GenderMale
+	}
+
 	if err := u.users.Add(ctx, &models.User{
-		Id:          "TestId",
-		Name:        "TestName",
-		Sex:         sex,
-		Age:         0,
-		Description: "TestDescription",
-		City:        "TestCity",
-		Image:       "",
-		Started:     true,
-		Stage:       -1,
-		ChatId:      0,
+		Id:           TestUserId,
+		Username:     "TestUsername",
+		Name:         "TestName",
+		Gender:       gender,
+		InterestedIn: models.GenderAny,
+		Age:          18,
+		Description:  "TestDescription",
+		City:         "TestCity",
+		Image:        "",
+		Started:      true,
+		Stage:        -1,
+		ChatId:       0,
 	}); err != nil {
-		u.log.Errorf("couldn't insert test user with err = %e", err)
-		return err
+		u.logger(ctx).Errorw("could not insert test user", "err", err)
+		return fmt.Errorf("insert test user: %w", err)
 	}
 	return nil
 }
 
-func (u *Usecase) AddTestUserWithLike(ctx context.Context, sex bool, toId string) error {
+func (u *Usecase) AddTestUserWithLike(ctx context.Context, sex bool, toId int64) error {
 	if err := u.AddTestUser(ctx, sex); err != nil {
 		return err
 	}
-	if err := u.likes.Add(ctx, &models.Like{FromId: "TestId", ToId: toId, Value: true}); err != nil {
-		u.log.Errorf("couldn't insert test user with err = %e", err)
-		return err
+	if err := u.likes.Add(ctx, &models.Like{FromId: TestUserId, ToId: toId, Value: true}); err != nil {
+		u.logger(ctx).Errorw("could not insert test like", "err", err)
+		return fmt.Errorf("insert test like: %w", err)
 	}
 	return nil
 }
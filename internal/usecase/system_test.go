@@ -27,12 +27,18 @@ func TestUsecase_DeleteAll_ShouldReturnErrorOnUsersRepoFailure(t *testing.T) {
 		usersRepo,
 		likesRepo,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	err := usecase.DeleteAll(ctx)
 	assert.NotNil(t, err)
-	assert.EqualValues(t, expectedErr, err)
+	assert.True(t, errors.Is(err, expectedErr))
 }
 
 func TestUsecase_DeleteAll_ShouldReturnErrorOnLikesRepoFailure(t *testing.T) {
@@ -51,12 +57,18 @@ func TestUsecase_DeleteAll_ShouldReturnErrorOnLikesRepoFailure(t *testing.T) {
 		usersRepo,
 		likesRepo,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	err := usecase.DeleteAll(ctx)
 	assert.NotNil(t, err)
-	assert.EqualValues(t, expectedErr, err)
+	assert.True(t, errors.Is(err, expectedErr))
 }
 
 func TestUsecase_AddTestUser(t *testing.T) {
@@ -73,7 +85,13 @@ func TestUsecase_AddTestUser(t *testing.T) {
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	err := usecase.AddTestUser(ctx, false)
@@ -95,12 +113,18 @@ func TestUsecase_AddTestUser_ShouldReturnErrorOnFailure(t *testing.T) {
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	err := usecase.AddTestUser(ctx, false)
 	assert.NotNil(t, err)
-	assert.EqualValues(t, expectedErr, err)
+	assert.True(t, errors.Is(err, expectedErr))
 }
 
 func TestUsecase_AddTestUserWithLike(t *testing.T) {
@@ -119,10 +143,16 @@ func TestUsecase_AddTestUserWithLike(t *testing.T) {
 		usersRepo,
 		likesRepo,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
-	err := usecase.AddTestUserWithLike(ctx, false, "toId")
+	err := usecase.AddTestUserWithLike(ctx, false, 1)
 	assert.Nil(t, err)
 }
 
@@ -143,10 +173,16 @@ func TestUsecase_AddTestUserWithLike_ShouldReturnErrorOnFailure(t *testing.T) {
 		usersRepo,
 		likesRepo,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
-	err := usecase.AddTestUserWithLike(ctx, false, "toId")
+	err := usecase.AddTestUserWithLike(ctx, false, 1)
 	assert.NotNil(t, err)
-	assert.EqualValues(t, expectedErr, err)
+	assert.True(t, errors.Is(err, expectedErr))
 }
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"time"
+)
+
+const (
+	nothingToUndoText      = "Нечего отменять."
+	profileUnavailableText = "Эта анкета больше недоступна."
+)
+
+// HandleUndo removes the most recent like or dislike of the user and shows that profile again.
+// A profile which /next wouldn't show anymore isn't shown, its swipe is kept then.
+func (u *Usecase) HandleUndo(ctx context.Context, chatId int64, user *models.User) ([]tgbotapi.Chattable, error) {
+	u.logger(ctx).Info("handleUndo")
+
+	like, err := u.likes.GetLast(ctx, user.Id)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			return []tgbotapi.Chattable{tgbotapi.NewMessage(chatId, nothingToUndoText)}, nil
+		}
+		u.logger(ctx).Errorw("could not get last like", "err", err)
+		return nil, fmt.Errorf("get last like: %w", err)
+	}
+
+	if time.Since(like.UpdatedAt) > u.config.UndoWindow {
+		return []tgbotapi.Chattable{tgbotapi.NewMessage(chatId, nothingToUndoText)}, nil
+	}
+
+	likedUser, err := u.users.GetByUserId(ctx, like.ToId)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			return []tgbotapi.Chattable{tgbotapi.NewMessage(chatId, nothingToUndoText)}, nil
+		}
+		u.logger(ctx).Errorw("could not get user", "err", err)
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	visible, err := u.isVisibleTo(ctx, user, likedUser)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return []tgbotapi.Chattable{tgbotapi.NewMessage(chatId, profileUnavailableText)}, nil
+	}
+
+	if err := u.likes.Delete(ctx, like.Id); err != nil && !errors.Is(err, models.ErrNoRecord) {
+		u.logger(ctx).Errorw("could not delete like", "err", err)
+		return nil, fmt.Errorf("delete like: %w", err)
+	}
+
+	if err := u.serveCard(ctx, user.Id, likedUser.Id); err != nil {
+		return nil, err
+	}
+
+	return u.createProfileCards(ctx, chatId, likedUser)
+}
+
+// isVisibleTo applies the rules of GetNextUser which don't depend on the search settings of user:
+// paused, hidden, banned, under-age and blocked profiles aren't shown.
+func (u *Usecase) isVisibleTo(ctx context.Context, user, other *models.User) (bool, error) {
+	if other.Paused || other.Hidden || other.Banned || other.Age < u.config.MinAge {
+		return false, nil
+	}
+
+	blocked, err := u.blocks.IsBlocked(ctx, user.Id, other.Id)
+	if err != nil {
+		u.logger(ctx).Errorw("could not check block", "err", err)
+		return false, fmt.Errorf("check block: %w", err)
+	}
+
+	return !blocked, nil
+}
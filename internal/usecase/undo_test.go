@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/Eretic431/datingTelegramBot/internal/data/models"
+	"github.com/Eretic431/datingTelegramBot/internal/mock"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	"testing"
+	"time"
+)
+
+func TestUsecase_HandleUndo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	likesRepo := mock.NewMockLikesRepository(ctrl)
+	photosRepo := mock.NewMockPhotosRepository(ctrl)
+	blocksRepo := mock.NewMockBlocksRepository(ctrl)
+
+	var chatId int64 = 1
+	user := &models.User{Id: 1}
+	likedUser := &models.User{Id: 2, Age: 20, Image: "image"}
+	like := &models.Like{Id: 10, FromId: user.Id, ToId: likedUser.Id, UpdatedAt: time.Now()}
+
+	likesRepo.EXPECT().
+		Delete(gomock.Any(), like.Id).
+		After(
+			likesRepo.EXPECT().
+				GetLast(gomock.Any(), user.Id).
+				Return(like, nil).
+				Times(1),
+		).
+		Return(nil).
+		Times(1)
+
+	usersRepo.EXPECT().
+		GetByUserId(gomock.Any(), likedUser.Id).
+		Return(likedUser, nil).
+		Times(1)
+
+	blocksRepo.EXPECT().
+		IsBlocked(gomock.Any(), user.Id, likedUser.Id).
+		Return(false, nil).
+		Times(1)
+
+	photosRepo.EXPECT().
+		GetByUserId(gomock.Any(), likedUser.Id).
+		Return([]*models.Photo{{FileId: "image"}, {FileId: "second"}}, nil).
+		Times(1)
+
+	servedCardsRepo := mock.NewMockServedCardsRepository(ctrl)
+	servedCardsRepo.EXPECT().
+		Add(gomock.Any(), user.Id, likedUser.Id).
+		Return(nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		likesRepo,
+		photosRepo,
+		blocksRepo,
+		nil,
+		servedCardsRepo,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		&Config{UndoWindow: time.Minute, MinAge: testConfig.MinAge, ServedCardTTL: testConfig.ServedCardTTL},
+	)
+
+	chattables, err := usecase.HandleUndo(context.Background(), chatId, user)
+	assert.Nil(t, err)
+	assert.Len(t, chattables, 2)
+
+	photoCfg, ok := chattables[0].(tgbotapi.PhotoConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, chatId, photoCfg.ChatID)
+	assert.NotNil(t, photoCfg.ReplyMarkup)
+
+	secondCfg, ok := chattables[1].(tgbotapi.PhotoConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, tgbotapi.FileID("second"), secondCfg.File)
+}
+
+func TestUsecase_HandleUndo_ShouldNotShowProfilesHiddenFromNext(t *testing.T) {
+	cases := []struct {
+		name      string
+		likedUser *models.User
+		blocked   bool
+	}{
+		{"banned", &models.User{Id: 2, Age: 20, Banned: true}, false},
+		{"paused", &models.User{Id: 2, Age: 20, Paused: true}, false},
+		{"hidden", &models.User{Id: 2, Age: 20, Hidden: true}, false},
+		{"under age", &models.User{Id: 2, Age: 16}, false},
+		{"blocked", &models.User{Id: 2, Age: 20}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			usersRepo := mock.NewMockUsersRepository(ctrl)
+			likesRepo := mock.NewMockLikesRepository(ctrl)
+			blocksRepo := mock.NewMockBlocksRepository(ctrl)
+
+			// The swipe is kept, so neither Delete nor Add of the served card are expected
+			likesRepo.EXPECT().
+				GetLast(gomock.Any(), int64(1)).
+				Return(&models.Like{Id: 10, FromId: 1, ToId: 2, UpdatedAt: time.Now()}, nil).
+				Times(1)
+			usersRepo.EXPECT().
+				GetByUserId(gomock.Any(), int64(2)).
+				Return(c.likedUser, nil).
+				Times(1)
+			blocksRepo.EXPECT().
+				IsBlocked(gomock.Any(), int64(1), int64(2)).
+				Return(c.blocked, nil).
+				AnyTimes()
+
+			usecase := NewUsecase(
+				usersRepo,
+				likesRepo,
+				nil,
+				blocksRepo,
+				nil,
+				nil,
+				testCallbacks,
+				nil,
+				zaptest.NewLogger(t).Sugar(),
+				&Config{UndoWindow: time.Minute, MinAge: testConfig.MinAge},
+			)
+
+			chattables, err := usecase.HandleUndo(context.Background(), 1, &models.User{Id: 1})
+			assert.Nil(t, err)
+			assert.Len(t, chattables, 1)
+
+			msgCfg, ok := chattables[0].(tgbotapi.MessageConfig)
+			assert.True(t, ok)
+			assert.EqualValues(t, profileUnavailableText, msgCfg.Text)
+		})
+	}
+}
+
+func TestUsecase_HandleUndo_ShouldNotUndoOldSwipes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	likesRepo := mock.NewMockLikesRepository(ctrl)
+
+	likesRepo.EXPECT().
+		GetLast(gomock.Any(), int64(1)).
+		Return(&models.Like{Id: 10, FromId: 1, ToId: 2, UpdatedAt: time.Now().Add(-time.Hour)}, nil).
+		Times(1)
+
+	usecase := NewUsecase(
+		nil,
+		likesRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		&Config{UndoWindow: time.Minute},
+	)
+
+	chattables, err := usecase.HandleUndo(context.Background(), 1, &models.User{Id: 1})
+	assert.Nil(t, err)
+
+	msgCfg, ok := chattables[0].(tgbotapi.MessageConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, nothingToUndoText, msgCfg.Text)
+}
+
+func TestUsecase_HandleUndo_ShouldReturnMessageIfThereAreNoLikes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	likesRepo := mock.NewMockLikesRepository(ctrl)
+
+	likesRepo.EXPECT().
+		GetLast(gomock.Any(), int64(1)).
+		Return(nil, models.ErrNoRecord).
+		Times(1)
+
+	usecase := NewUsecase(
+		nil,
+		likesRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		&Config{UndoWindow: time.Minute},
+	)
+
+	chattables, err := usecase.HandleUndo(context.Background(), 1, &models.User{Id: 1})
+	assert.Nil(t, err)
+
+	msgCfg, ok := chattables[0].(tgbotapi.MessageConfig)
+	assert.True(t, ok)
+	assert.EqualValues(t, nothingToUndoText, msgCfg.Text)
+}
+
+func TestUsecase_HandleUndo_ShouldReturnSameErrOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usersRepo := mock.NewMockUsersRepository(ctrl)
+	likesRepo := mock.NewMockLikesRepository(ctrl)
+	blocksRepo := mock.NewMockBlocksRepository(ctrl)
+
+	usersRepo.EXPECT().
+		GetByUserId(gomock.Any(), int64(2)).
+		Return(&models.User{Id: 2, Age: 20}, nil).
+		Times(1)
+	blocksRepo.EXPECT().
+		IsBlocked(gomock.Any(), int64(1), int64(2)).
+		Return(false, nil).
+		Times(1)
+
+	expectedErr := errors.New("some err")
+	likesRepo.EXPECT().
+		Delete(gomock.Any(), int64(10)).
+		After(
+			likesRepo.EXPECT().
+				GetLast(gomock.Any(), int64(1)).
+				Return(&models.Like{Id: 10, FromId: 1, ToId: 2, UpdatedAt: time.Now()}, nil).
+				Times(1),
+		).
+		Return(expectedErr).
+		Times(1)
+
+	usecase := NewUsecase(
+		usersRepo,
+		likesRepo,
+		nil,
+		blocksRepo,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+		&Config{UndoWindow: time.Minute, MinAge: testConfig.MinAge},
+	)
+
+	_, err := usecase.HandleUndo(context.Background(), 1, &models.User{Id: 1})
+	assert.True(t, errors.Is(err, expectedErr))
+}
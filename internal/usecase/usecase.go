@@ -1,17 +1,28 @@
 package usecase
 
 import (
+	"context"
 	"github.com/Eretic431/datingTelegramBot/internal"
+	"github.com/Eretic431/datingTelegramBot/internal/dialog"
+	"github.com/Eretic431/datingTelegramBot/internal/logging"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"go.uber.org/zap"
 )
 
 type Usecase struct {
-	users  internal.UsersRepository
-	likes  internal.LikesRepository
-	bot    *tgbotapi.BotAPI
-	log    *zap.SugaredLogger
-	Stages map[int]string
+	users       internal.UsersRepository
+	likes       internal.LikesRepository
+	photos      internal.PhotosRepository
+	blocks      internal.BlocksRepository
+	reports     internal.ReportsRepository
+	servedCards internal.ServedCardsRepository
+	keyboards   *internal.Keyboards
+	bot         *tgbotapi.BotAPI
+	log         *zap.SugaredLogger
+	config      *Config
+	profile     *dialog.Dialog
+	settings    *dialog.Dialog
+	report      *dialog.Dialog
 }
 
 var _ internal.Usecase = &Usecase{}
@@ -19,27 +30,63 @@ var _ internal.Usecase = &Usecase{}
 func NewUsecase(
 	users internal.UsersRepository,
 	likes internal.LikesRepository,
+	photos internal.PhotosRepository,
+	blocks internal.BlocksRepository,
+	reports internal.ReportsRepository,
+	servedCards internal.ServedCardsRepository,
+	callbacks *internal.CallbackCodec,
 	bot *tgbotapi.BotAPI,
-	log *zap.SugaredLogger) internal.Usecase {
-	stages := make(map[int]string, 6)
-
-	stages[0] = "Как Вас зовут?"
-	stages[1] = "Сколько Вам лет?"
-	stages[2] = "Из какого Вы города?"
-	stages[3] = "Введите краткое описание своего профиля."
-	stages[4] = "Пришлите фотографию, которая будет показываться другим пользователям в ленте."
-	stages[5] = "Какого Вы пола? М/Ж"
-
-	return &Usecase{
-		users:  users,
-		likes:  likes,
-		bot:    bot,
-		log:    log,
-		Stages: stages,
+	log *zap.SugaredLogger,
+	config *Config) internal.Usecase {
+	u := &Usecase{
+		users:       users,
+		likes:       likes,
+		photos:      photos,
+		blocks:      blocks,
+		reports:     reports,
+		servedCards: servedCards,
+		keyboards:   internal.NewKeyboards(callbacks, log),
+		bot:         bot,
+		log:         log,
+		config:      config,
 	}
+
+	u.profile = dialog.New(u.profileSteps(), u.saveUser, u.finishProfile, u.keyboards)
+	u.settings = dialog.New(u.settingsSteps(), u.saveUser, u.finishSettings, u.keyboards).Cancelable()
+	u.report = dialog.New(u.reportSteps(), u.saveUser, u.finishReport, u.keyboards)
+
+	return u
 }
 
+// logger returns the logger of the update being handled, see logging.WithLogger.
+func (u *Usecase) logger(ctx context.Context) *zap.SugaredLogger {
+	return logging.FromContext(ctx, u.log)
+}
+
+// Stages are ids of the dialog steps, they are stored in user.Stage
 const (
 	MaxProfileStage  = 5
-	ProfileStageNone = -1
+	ProfileStageNone = dialog.Done
+
+	ProfileStageName        = 0
+	ProfileStageAge         = 1
+	ProfileStageCity        = 2
+	ProfileStageDescription = 3
+	ProfileStagePhotos      = 4
+	ProfileStageGender      = 5
+
+	SettingsStageMinAge       = 6
+	SettingsStageMaxAge       = 7
+	SettingsStageCityOnly     = 8
+	SettingsStageInterestedIn = 9
+	MaxSettingsStage          = 9
+
+	ReportStageReason = 10
+)
+
+const (
+	choiceYes    = "Да"
+	choiceNo     = "Нет"
+	choiceDone   = "Готово"
+	choiceCancel = "Отмена"
 )
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"github.com/Eretic431/datingTelegramBot/internal"
+	"testing"
+	"time"
+)
+
+var testConfig = &Config{
+	MinAge: 18,
+	MaxAge: 100,
+
+	MaxPhotos: 5,
+
+	ServedCardTTL: time.Hour,
+
+	ReportsToHide: 3,
+}
+
+var testCallbacks = internal.NewCallbackCodec([]byte("key"))
+
+// callbackData returns the data of a button created by the usecase with testCallbacks.
+func callbackData(t *testing.T, action internal.CallbackAction, payload string) string {
+	data, err := testCallbacks.Encode(internal.Callback{Action: action, Payload: payload})
+	if err != nil {
+		t.Fatalf("could not encode callback: %s", err)
+	}
+	return data
+}
@@ -3,18 +3,19 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/Eretic431/datingTelegramBot/internal/data/models"
 )
 
-func (u *Usecase) GetUserByIdOrNil(ctx context.Context, userId string) (*models.User, error) {
+func (u *Usecase) GetUserByIdOrNil(ctx context.Context, userId int64) (*models.User, error) {
 	user, err := u.users.GetByUserId(ctx, userId)
 	if err != nil {
 		if errors.Is(err, models.ErrNoRecord) {
 			return nil, nil
 		}
 
-		u.log.Errorf("could not get user with error %e", err)
-		return nil, err
+		u.logger(ctx).Errorw("could not get user", "err", err)
+		return nil, fmt.Errorf("get user: %w", err)
 	}
 	return user, nil
 }
@@ -17,7 +17,7 @@ func TestUsecase_GetUserByIdOrNil(t *testing.T) {
 
 	usersRepo := mock.NewMockUsersRepository(ctrl)
 
-	userId := "user_id"
+	userId := int64(1)
 	expectedUser := &models.User{Id: userId}
 
 	ctx := context.Background()
@@ -30,7 +30,13 @@ func TestUsecase_GetUserByIdOrNil(t *testing.T) {
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	user, err := usecase.GetUserByIdOrNil(ctx, userId)
@@ -44,7 +50,7 @@ func TestUsecase_GetUserByIdOrNil_ShouldReturnNilOnErrNoRecord(t *testing.T) {
 
 	usersRepo := mock.NewMockUsersRepository(ctrl)
 
-	userId := "user_id"
+	userId := int64(1)
 
 	ctx := context.Background()
 	usersRepo.EXPECT().
@@ -56,7 +62,13 @@ func TestUsecase_GetUserByIdOrNil_ShouldReturnNilOnErrNoRecord(t *testing.T) {
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	user, err := usecase.GetUserByIdOrNil(ctx, userId)
@@ -70,7 +82,7 @@ func TestUsecase_GetUserByIdOrNil_ShouldReturnSameErrorOnFailure(t *testing.T) {
 
 	usersRepo := mock.NewMockUsersRepository(ctrl)
 
-	userId := "user_id"
+	userId := int64(1)
 	expectedErr := errors.New("some err")
 
 	ctx := context.Background()
@@ -83,7 +95,13 @@ func TestUsecase_GetUserByIdOrNil_ShouldReturnSameErrorOnFailure(t *testing.T) {
 		usersRepo,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		testCallbacks,
+		nil,
 		zaptest.NewLogger(t).Sugar(),
+		testConfig,
 	)
 
 	user, err := usecase.GetUserByIdOrNil(ctx, userId)
@@ -8,9 +8,16 @@ import (
 
 type UsersRepository interface {
 	Add(context.Context, *models.User) error
-	GetByUserId(context.Context, string) (*models.User, error)
+	GetByUserId(context.Context, int64) (*models.User, error)
+	Search(ctx context.Context, query string, limit, offset int) ([]*models.User, error)
+	// UpdateByUserId writes every field but the username, see UpdateUsername
 	UpdateByUserId(context.Context, *models.User) error
-	DeleteByUserId(context.Context, string) error
-	GetNextUser(context.Context, string, bool) (*models.User, error)
+	UpdateUsername(ctx context.Context, userId int64, username string) error
+	DeleteByUserId(context.Context, int64) error
+	GetNextUser(context.Context, *models.User) (*models.User, error)
+	// GetNextLiker skips likers younger than minAge
+	GetNextLiker(ctx context.Context, userId int64, minAge int) (*models.User, error)
+	SetHidden(ctx context.Context, userId int64, hidden bool) error
+	SetBanned(ctx context.Context, userId int64, banned bool) error
 	DeleteAll(ctx context.Context) error
 }
@@ -0,0 +1,28 @@
+ALTER TABLE likes DROP CONSTRAINT likes_from_id_fkey;
+ALTER TABLE likes DROP CONSTRAINT likes_to_id_fkey;
+ALTER TABLE users DROP CONSTRAINT users_pkey;
+
+/* Users without username fall back to their numeric id */
+UPDATE users SET username = id::varchar WHERE username = '';
+
+ALTER TABLE likes RENAME COLUMN from_id TO from_user_id;
+ALTER TABLE likes RENAME COLUMN to_id TO to_user_id;
+ALTER TABLE likes ADD COLUMN from_id varchar;
+ALTER TABLE likes ADD COLUMN to_id varchar;
+
+UPDATE likes SET from_id = users.username FROM users WHERE likes.from_user_id = users.id;
+UPDATE likes SET to_id = users.username FROM users WHERE likes.to_user_id = users.id;
+
+ALTER TABLE likes DROP COLUMN from_user_id;
+ALTER TABLE likes DROP COLUMN to_user_id;
+
+ALTER TABLE users DROP COLUMN id;
+ALTER TABLE users ALTER COLUMN username DROP DEFAULT;
+ALTER TABLE users RENAME COLUMN username TO id;
+ALTER TABLE users ALTER COLUMN chat_id TYPE int;
+ALTER TABLE users ADD PRIMARY KEY (id);
+
+ALTER TABLE likes ALTER COLUMN from_id SET NOT NULL;
+ALTER TABLE likes ALTER COLUMN to_id SET NOT NULL;
+ALTER TABLE likes ADD FOREIGN KEY (from_id) REFERENCES users (id);
+ALTER TABLE likes ADD FOREIGN KEY (to_id) REFERENCES users (id);
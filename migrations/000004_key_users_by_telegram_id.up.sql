@@ -0,0 +1,34 @@
+ALTER TABLE likes DROP CONSTRAINT likes_from_id_fkey;
+ALTER TABLE likes DROP CONSTRAINT likes_to_id_fkey;
+ALTER TABLE users DROP CONSTRAINT users_pkey;
+
+ALTER TABLE users RENAME COLUMN id TO username;
+ALTER TABLE users ALTER COLUMN chat_id TYPE bigint;
+ALTER TABLE users ADD COLUMN id bigint;
+
+/* Private chat id is equal to Telegram user id */
+UPDATE users SET id = chat_id WHERE chat_id != 0;
+
+/* Seed users have no chat, so give them negative ids that can't clash with real ones */
+UPDATE users
+SET id = -numbered.n
+FROM (SELECT username, row_number() OVER (ORDER BY username) AS n FROM users WHERE chat_id = 0) numbered
+WHERE users.username = numbered.username;
+
+ALTER TABLE users ALTER COLUMN id SET NOT NULL;
+ALTER TABLE users ADD PRIMARY KEY (id);
+ALTER TABLE users ALTER COLUMN username SET DEFAULT '';
+
+ALTER TABLE likes RENAME COLUMN from_id TO from_username;
+ALTER TABLE likes RENAME COLUMN to_id TO to_username;
+ALTER TABLE likes ADD COLUMN from_id bigint REFERENCES users (id);
+ALTER TABLE likes ADD COLUMN to_id bigint REFERENCES users (id);
+
+UPDATE likes SET from_id = users.id FROM users WHERE likes.from_username = users.username;
+UPDATE likes SET to_id = users.id FROM users WHERE likes.to_username = users.username;
+DELETE FROM likes WHERE from_id IS NULL OR to_id IS NULL;
+
+ALTER TABLE likes ALTER COLUMN from_id SET NOT NULL;
+ALTER TABLE likes ALTER COLUMN to_id SET NOT NULL;
+ALTER TABLE likes DROP COLUMN from_username;
+ALTER TABLE likes DROP COLUMN to_username;
@@ -0,0 +1,4 @@
+ALTER TABLE users DROP COLUMN min_age;
+ALTER TABLE users DROP COLUMN max_age;
+ALTER TABLE users DROP COLUMN city_only;
+ALTER TABLE users DROP COLUMN search_sex;
@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN min_age int NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN max_age int NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN city_only boolean NOT NULL DEFAULT false;
+ALTER TABLE users ADD COLUMN search_sex int NOT NULL DEFAULT 0;
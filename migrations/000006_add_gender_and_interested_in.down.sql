@@ -0,0 +1,15 @@
+ALTER TABLE users ADD COLUMN sex boolean NOT NULL DEFAULT false;
+ALTER TABLE users ADD COLUMN search_sex int NOT NULL DEFAULT 0;
+
+UPDATE users SET sex = gender = 1;
+
+UPDATE users
+SET search_sex = CASE interested_in
+                     WHEN 0 THEN 0
+                     WHEN 1 THEN 1
+                     WHEN 2 THEN 2
+                     ELSE 3
+    END;
+
+ALTER TABLE users DROP COLUMN gender;
+ALTER TABLE users DROP COLUMN interested_in;
@@ -0,0 +1,17 @@
+ALTER TABLE users ADD COLUMN gender int NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN interested_in int NOT NULL DEFAULT 0;
+
+/* Genders are bit flags: 1 - male, 2 - female, 4 - non-binary */
+UPDATE users SET gender = CASE WHEN sex THEN 1 ELSE 2 END;
+
+/* search_sex: 0 - opposite, 1 - male, 2 - female, 3 - any */
+UPDATE users
+SET interested_in = CASE search_sex
+                        WHEN 1 THEN 1
+                        WHEN 2 THEN 2
+                        WHEN 3 THEN 7
+                        ELSE CASE WHEN sex THEN 2 ELSE 1 END
+    END;
+
+ALTER TABLE users DROP COLUMN sex;
+ALTER TABLE users DROP COLUMN search_sex;
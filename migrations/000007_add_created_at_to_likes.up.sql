@@ -0,0 +1 @@
+ALTER TABLE likes ADD COLUMN created_at timestamptz NOT NULL DEFAULT now();
@@ -0,0 +1 @@
+ALTER TABLE likes DROP COLUMN updated_at;
@@ -0,0 +1,2 @@
+ALTER TABLE likes ADD COLUMN updated_at timestamptz NOT NULL DEFAULT now();
+UPDATE likes SET updated_at = created_at;
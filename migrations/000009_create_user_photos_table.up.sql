@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS user_photos
+(
+    id       bigserial PRIMARY KEY,
+    user_id  bigint NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    file_id  text   NOT NULL,
+    position int    NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS user_photos_user_id_idx ON user_photos (user_id, position);
+
+INSERT INTO user_photos (user_id, file_id, position)
+SELECT id, image, 0
+FROM users
+WHERE image != '';
@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN editing boolean NOT NULL DEFAULT false;
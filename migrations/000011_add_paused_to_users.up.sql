@@ -0,0 +1,2 @@
+/* Paused users keep their profile but aren't shown to others */
+ALTER TABLE users ADD COLUMN paused boolean NOT NULL DEFAULT false;
@@ -0,0 +1,4 @@
+ALTER TABLE users DROP COLUMN reported_id;
+ALTER TABLE users DROP COLUMN hidden;
+DROP TABLE IF EXISTS reports;
+DROP TABLE IF EXISTS blocks;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS blocks
+(
+    id         bigserial PRIMARY KEY,
+    from_id    bigint      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    to_id      bigint      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    UNIQUE (from_id, to_id)
+);
+
+CREATE INDEX IF NOT EXISTS blocks_to_id_idx ON blocks (to_id);
+
+CREATE TABLE IF NOT EXISTS reports
+(
+    id         bigserial PRIMARY KEY,
+    from_id    bigint      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    to_id      bigint      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    reason     text        NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    resolved   boolean     NOT NULL DEFAULT false
+);
+
+CREATE INDEX IF NOT EXISTS reports_to_id_idx ON reports (to_id) WHERE NOT resolved;
+
+/* Reported users are hidden until a moderator reviews the reports */
+ALTER TABLE users ADD COLUMN hidden boolean NOT NULL DEFAULT false;
+
+/* The user being reported, while the reporter picks a reason */
+ALTER TABLE users ADD COLUMN reported_id bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS user_photos_unreviewed_idx;
+ALTER TABLE user_photos DROP COLUMN reviewed;
+ALTER TABLE users DROP COLUMN banned;
@@ -0,0 +1,8 @@
+/* Banned users can't search and aren't shown to others */
+ALTER TABLE users ADD COLUMN banned boolean NOT NULL DEFAULT false;
+
+/* Photos uploaded before moderation appeared count as reviewed */
+ALTER TABLE user_photos ADD COLUMN reviewed boolean NOT NULL DEFAULT false;
+UPDATE user_photos SET reviewed = true;
+
+CREATE INDEX IF NOT EXISTS user_photos_unreviewed_idx ON user_photos (id) WHERE NOT reviewed;
@@ -0,0 +1,8 @@
+/* Profiles shown to a user with the like keyboard, only they can be liked or disliked */
+CREATE TABLE IF NOT EXISTS served_cards
+(
+    user_id   bigint      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    to_id     bigint      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    served_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, to_id)
+);
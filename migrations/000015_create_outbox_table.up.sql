@@ -0,0 +1,8 @@
+/* Messages which must reach Telegram even if the bot restarts before sending them, e.g. match notifications */
+CREATE TABLE IF NOT EXISTS outbox
+(
+    id         bigserial PRIMARY KEY,
+    chat_id    bigint      NOT NULL,
+    payload    jsonb       NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
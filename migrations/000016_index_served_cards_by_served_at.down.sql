@@ -0,0 +1 @@
+DROP INDEX IF EXISTS served_cards_served_at_idx;
@@ -0,0 +1,2 @@
+/* Expired cards are removed by served_at */
+CREATE INDEX IF NOT EXISTS served_cards_served_at_idx ON served_cards (served_at);
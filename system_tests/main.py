@@ -7,6 +7,7 @@ from telethon import TelegramClient
 from telethon.tl.custom import Conversation
 from telethon.tl.custom.message import Message
 
+# The bot must be built with the testhooks tag: make start-test-build
 baseUrl = "http://localhost:8090"
 # TODO: get from env
 api_id = 1
@@ -20,7 +21,16 @@ start_answer = "Привет! Я, SpbstuDatingBot, помогаю людям п
                + "Список доступных команд: \n" \
                + "- /start - начало работы\n" \
                + "- /profile - заполнить анкету\n" \
-               + "- /next - показать следующего пользователя"
+               + "- /edit - изменить анкету\n" \
+               + "- /settings - настроить поиск\n" \
+               + "- /next - показать следующего пользователя\n" \
+               + "- /matches - показать совпадения\n" \
+               + "- /likes - посмотреть, кому Вы понравились\n" \
+               + "- /undo - вернуть предыдущую анкету\n" \
+               + "- /pause - скрыть анкету из поиска\n" \
+               + "- /resume - снова показывать анкету\n" \
+               + "- /delete - удалить анкету\n" \
+               + "- /cancel - отменить жалобу, изменение анкеты или настроек поиска"
 
 
 def clear_system():
@@ -44,7 +54,7 @@ async def sendProfile(conv: Conversation, sex):
     await conv.send_message("name")
     resp: Message = await conv.get_response()
     assert resp.raw_text == "Сколько Вам лет?"
-    await conv.send_message("1")
+    await conv.send_message("20")
     resp: Message = await conv.get_response()
     assert resp.raw_text == "Из какого Вы города?"
     await conv.send_message("City")
@@ -52,13 +62,16 @@ async def sendProfile(conv: Conversation, sex):
     assert resp.raw_text == "Введите краткое описание своего профиля."
     await conv.send_message("Description")
     resp: Message = await conv.get_response()
-    assert resp.raw_text == "Пришлите фотографию, которая будет показываться другим пользователям в ленте."
+    assert resp.raw_text == "Пришлите до 5 фотографий, которые будут показываться другим пользователям в ленте. Когда закончите, нажмите «Готово»."
     await conv.send_file("img.jpg")
     resp: Message = await conv.get_response()
-    assert resp.raw_text == "Какого Вы пола? М/Ж"
+    assert resp.raw_text == "Фотографий в анкете: 1 из 5. Пришлите ещё или нажмите «Готово»."
+    await resp.click(text="Готово")
+    resp: Message = await conv.get_response()
+    assert resp.raw_text == "Укажите Ваш пол."
     await conv.send_message(sex)
     resp: Message = await conv.get_response()
-    assert resp.raw_text == "Имя: name\nВозраст: 1\nГород: City\nОписание: Description\nПол: " + fullSex + "\n\nПопробуйте ввести команду /next"
+    assert resp.raw_text == "Имя: name\nВозраст: 20\nГород: City\nОписание: Description\nПол: " + fullSex + "\n\nПопробуйте ввести команду /next"
     return resp.raw_text
 
 
@@ -187,7 +200,7 @@ async def test_scenario7(client: TelegramClient):
         await sendProfile(conv, "М")
         await conv.send_message("/next")
         resp: Message = await conv.get_response()
-        assert resp.raw_text == "Имя: TestName\nВозраст: 0\nГород: TestCity\nОписание: TestDescription\nПол: Женщина"
+        assert resp.raw_text == "Имя: TestName\nВозраст: 18\nГород: TestCity\nОписание: TestDescription\nПол: Женщина"
 
 
 @pytest.mark.asyncio
@@ -246,7 +259,7 @@ async def test_scenario13(client: TelegramClient):
         await conv.send_message("name")
         resp: Message = await conv.get_response()
         assert resp.raw_text == "Сколько Вам лет?"
-        await conv.send_message("1")
+        await conv.send_message("20")
         resp: Message = await conv.get_response()
         assert resp.raw_text == "Из какого Вы города?"
         await conv.send_message("City")
@@ -254,7 +267,7 @@ async def test_scenario13(client: TelegramClient):
         assert resp.raw_text == "Введите краткое описание своего профиля."
         await conv.send_message("Description")
         resp: Message = await conv.get_response()
-        assert resp.raw_text == "Пришлите фотографию, которая будет показываться другим пользователям в ленте."
+        assert resp.raw_text == "Пришлите до 5 фотографий, которые будут показываться другим пользователям в ленте. Когда закончите, нажмите «Готово»."
         await conv.send_message("not photo")
         resp: Message = await conv.get_response()
         assert resp.raw_text == "Данные введены некорректно, попробуйте снова."
@@ -268,7 +281,7 @@ async def test_scenario15(client: TelegramClient):
         await sendStart(conv)
         await conv.send_message("/wrong")
         resp: Message = await conv.get_response()
-        assert resp.raw_text == "Такой команды не существует.\n\nСписок доступных команд: \n- /start - начало работы\n- /profile - заполнить анкету\n- /next - показать следующего пользователя"
+        assert resp.raw_text == "Такой команды не существует.\n\nСписок доступных команд: \n- /start - начало работы\n- /profile - заполнить анкету\n- /edit - изменить анкету\n- /settings - настроить поиск\n- /next - показать следующего пользователя\n- /matches - показать совпадения\n- /likes - посмотреть, кому Вы понравились\n- /undo - вернуть предыдущую анкету\n- /pause - скрыть анкету из поиска\n- /resume - снова показывать анкету\n- /delete - удалить анкету\n- /cancel - отменить жалобу, изменение анкеты или настроек поиска"
 
 
 @pytest.mark.asyncio
@@ -311,11 +324,11 @@ async def test_scenario19(client: TelegramClient):
         assert resp.raw_text == "Введите краткое описание своего профиля."
         await resp.click(0)
         resp: Message = await conv.get_response()
-        assert resp.raw_text == "Пришлите фотографию, которая будет показываться другим пользователям в ленте."
-        await resp.click(0)
+        assert resp.raw_text == "Пришлите до 5 фотографий, которые будут показываться другим пользователям в ленте. Когда закончите, нажмите «Готово»."
+        await resp.click(text="Готово")
         resp: Message = await conv.get_response()
-        assert resp.raw_text == "Какого Вы пола? М/Ж"
-        await resp.click(0)
+        assert resp.raw_text == "Укажите Ваш пол."
+        await resp.click(text="Женщина")
         resp: Message = await conv.get_response()
         assert exp == resp.raw_text
 
@@ -345,7 +358,7 @@ async def test_scenario22(client: TelegramClient):
         await conv.send_message("name")
         resp: Message = await conv.get_response()
         assert resp.raw_text == "Сколько Вам лет?"
-        await conv.send_message("1")
+        await conv.send_message("20")
         resp: Message = await conv.get_response()
         assert resp.raw_text == "Из какого Вы города?"
         await conv.send_message("City")
@@ -353,10 +366,13 @@ async def test_scenario22(client: TelegramClient):
         assert resp.raw_text == "Введите краткое описание своего профиля."
         await conv.send_message("Description")
         resp: Message = await conv.get_response()
-        assert resp.raw_text == "Пришлите фотографию, которая будет показываться другим пользователям в ленте."
+        assert resp.raw_text == "Пришлите до 5 фотографий, которые будут показываться другим пользователям в ленте. Когда закончите, нажмите «Готово»."
         await conv.send_file("img.jpg")
         resp: Message = await conv.get_response()
-        assert resp.raw_text == "Какого Вы пола? М/Ж"
+        assert resp.raw_text == "Фотографий в анкете: 1 из 5. Пришлите ещё или нажмите «Готово»."
+        await resp.click(text="Готово")
+        resp: Message = await conv.get_response()
+        assert resp.raw_text == "Укажите Ваш пол."
         await conv.send_message("WRONG")
         resp: Message = await conv.get_response()
         assert resp.raw_text == "Данные введены некорректно, попробуйте снова."